@@ -0,0 +1,563 @@
+// Package bigcalc evaluates arithmetic expressions with math/big so the
+// integer-division surprise from 03_basic_types (3 / 2 == 1) can be compared
+// against float64 rounding and exact arithmetic side by side.
+package bigcalc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------
+// 1. MODES
+// ---------------------------------------------------------
+
+// Mode selects the arithmetic used for the "exact" column.
+type Mode int
+
+const (
+	// Rat evaluates with big.Rat: every result is an exact fraction.
+	Rat Mode = iota
+	// Int evaluates with big.Int: no overflow, but division still truncates.
+	Int
+	// Float evaluates with big.Float at a fixed binary precision.
+	Float
+)
+
+// DefaultPrec is the big.Float mantissa size (in bits) used when none is given.
+const DefaultPrec = 256
+
+func (m Mode) String() string {
+	switch m {
+	case Rat:
+		return "rat"
+	case Int:
+		return "int"
+	case Float:
+		return "float"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// ParseMode converts a flag value ("rat", "int", "float") into a Mode.
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "rat", "exact":
+		return Rat, nil
+	case "int", "bigint":
+		return Int, nil
+	case "float", "bigfloat":
+		return Float, nil
+	}
+	return 0, fmt.Errorf("bigcalc: unknown mode %q (want rat, int or float)", s)
+}
+
+// ---------------------------------------------------------
+// 2. ERRORS
+// ---------------------------------------------------------
+
+// ErrDivisionByZero is returned by every evaluator when a divisor is zero.
+var ErrDivisionByZero = errors.New("bigcalc: division by zero")
+
+// ErrNotInteger is returned by integer evaluators for literals like 1.5,
+// which would not compile as an int constant in Go either.
+var ErrNotInteger = errors.New("bigcalc: literal is not an integer")
+
+// SyntaxError reports where parsing failed (Pos is a 1-based column).
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("bigcalc: column %d: %s", e.Pos, e.Msg)
+}
+
+// ---------------------------------------------------------
+// 3. PARSER (recursive descent)
+// ---------------------------------------------------------
+// Grammar:
+//   expr   = term { ("+" | "-") term }
+//   term   = unary { ("*" | "/" | "%") unary }
+//   unary  = [ "-" | "+" ] unary | primary
+//   primary = number | "(" expr ")"
+
+// Expr is a parsed expression tree. It can be evaluated many times in
+// different arithmetic modes.
+type Expr struct {
+	root node
+}
+
+type node interface{}
+
+type numLit struct {
+	text string // literal as written, e.g. "3" or "0.1"
+}
+
+type unaryOp struct {
+	op byte
+	x  node
+}
+
+type binaryOp struct {
+	op   byte
+	l, r node
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+// Parse parses an arithmetic expression made of decimal numbers,
+// + - * / %, unary minus and parentheses.
+func Parse(src string) (*Expr, error) {
+	p := &parser{src: src}
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos])
+	}
+	return &Expr{root: n}, nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Pos: p.pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) expr() (node, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryOp{op: op, l: left, r: right}
+	}
+}
+
+func (p *parser) term() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' && op != '%' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryOp{op: op, l: left, r: right}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	if op := p.peek(); op == '-' || op == '+' {
+		p.pos++
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryOp{op: op, x: x}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	c := p.peek()
+	switch {
+	case c == '(':
+		p.pos++
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("expected ')'")
+		}
+		p.pos++
+		return n, nil
+	case c >= '0' && c <= '9' || c == '.':
+		start := p.pos
+		for p.pos < len(p.src) && (p.src[p.pos] >= '0' && p.src[p.pos] <= '9' || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		text := p.src[start:p.pos]
+		if strings.Count(text, ".") > 1 || text == "." {
+			p.pos = start
+			return nil, p.errorf("malformed number %q", text)
+		}
+		return numLit{text: text}, nil
+	case c == 0:
+		return nil, p.errorf("unexpected end of expression")
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+// ---------------------------------------------------------
+// 4. EVALUATORS
+// ---------------------------------------------------------
+
+// EvalInt evaluates the expression with Go's int: division truncates
+// toward zero and overflow wraps around silently, exactly like the lesson.
+func (e *Expr) EvalInt() (int, error) {
+	return evalInt(e.root)
+}
+
+func evalInt(n node) (int, error) {
+	switch n := n.(type) {
+	case numLit:
+		v, err := strconv.ParseInt(n.text, 10, strconv.IntSize)
+		if err != nil {
+			if strings.Contains(n.text, ".") {
+				return 0, fmt.Errorf("%w: %s", ErrNotInteger, n.text)
+			}
+			return 0, fmt.Errorf("bigcalc: %s overflows int", n.text)
+		}
+		return int(v), nil
+	case unaryOp:
+		x, err := evalInt(n.x)
+		if n.op == '-' {
+			x = -x
+		}
+		return x, err
+	case binaryOp:
+		l, err := evalInt(n.l)
+		if err != nil {
+			return 0, err
+		}
+		r, err := evalInt(n.r)
+		if err != nil {
+			return 0, err
+		}
+		switch n.op {
+		case '+':
+			return l + r, nil
+		case '-':
+			return l - r, nil
+		case '*':
+			return l * r, nil
+		}
+		if r == 0 {
+			return 0, ErrDivisionByZero
+		}
+		if n.op == '/' {
+			return l / r, nil
+		}
+		return l % r, nil
+	}
+	panic("bigcalc: unknown node")
+}
+
+// EvalFloat64 evaluates the expression with float64. Division by zero is
+// reported as an error instead of producing ±Inf or NaN.
+func (e *Expr) EvalFloat64() (float64, error) {
+	return evalFloat64(e.root)
+}
+
+func evalFloat64(n node) (float64, error) {
+	switch n := n.(type) {
+	case numLit:
+		return strconv.ParseFloat(n.text, 64)
+	case unaryOp:
+		x, err := evalFloat64(n.x)
+		if n.op == '-' {
+			x = -x
+		}
+		return x, err
+	case binaryOp:
+		l, err := evalFloat64(n.l)
+		if err != nil {
+			return 0, err
+		}
+		r, err := evalFloat64(n.r)
+		if err != nil {
+			return 0, err
+		}
+		switch n.op {
+		case '+':
+			return l + r, nil
+		case '-':
+			return l - r, nil
+		case '*':
+			return l * r, nil
+		}
+		if r == 0 {
+			return 0, ErrDivisionByZero
+		}
+		if n.op == '/' {
+			return l / r, nil
+		}
+		// math.Mod keeps the sign of l, same as Go's integer %.
+		return math.Mod(l, r), nil
+	}
+	panic("bigcalc: unknown node")
+}
+
+// EvalRat evaluates the expression exactly. The % operator is only defined
+// for integer operands and follows Go's truncated remainder.
+func (e *Expr) EvalRat() (*big.Rat, error) {
+	return evalRat(e.root)
+}
+
+func evalRat(n node) (*big.Rat, error) {
+	switch n := n.(type) {
+	case numLit:
+		r, ok := new(big.Rat).SetString(n.text)
+		if !ok {
+			return nil, fmt.Errorf("bigcalc: bad number %q", n.text)
+		}
+		return r, nil
+	case unaryOp:
+		x, err := evalRat(n.x)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == '-' {
+			x.Neg(x)
+		}
+		return x, nil
+	case binaryOp:
+		l, err := evalRat(n.l)
+		if err != nil {
+			return nil, err
+		}
+		r, err := evalRat(n.r)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case '+':
+			return l.Add(l, r), nil
+		case '-':
+			return l.Sub(l, r), nil
+		case '*':
+			return l.Mul(l, r), nil
+		}
+		if r.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		if n.op == '/' {
+			return l.Quo(l, r), nil
+		}
+		if !l.IsInt() || !r.IsInt() {
+			return nil, fmt.Errorf("%w: %% needs integer operands", ErrNotInteger)
+		}
+		m := new(big.Int).Rem(l.Num(), r.Num())
+		return new(big.Rat).SetInt(m), nil
+	}
+	panic("bigcalc: unknown node")
+}
+
+// EvalBigInt evaluates the expression with arbitrary-size integers. It never
+// overflows, but "/" still truncates toward zero like Go's int division.
+func (e *Expr) EvalBigInt() (*big.Int, error) {
+	return evalBigInt(e.root)
+}
+
+func evalBigInt(n node) (*big.Int, error) {
+	switch n := n.(type) {
+	case numLit:
+		v, ok := new(big.Int).SetString(n.text, 10)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotInteger, n.text)
+		}
+		return v, nil
+	case unaryOp:
+		x, err := evalBigInt(n.x)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == '-' {
+			x.Neg(x)
+		}
+		return x, nil
+	case binaryOp:
+		l, err := evalBigInt(n.l)
+		if err != nil {
+			return nil, err
+		}
+		r, err := evalBigInt(n.r)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case '+':
+			return l.Add(l, r), nil
+		case '-':
+			return l.Sub(l, r), nil
+		case '*':
+			return l.Mul(l, r), nil
+		}
+		if r.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		if n.op == '/' {
+			return l.Quo(l, r), nil // Quo truncates, Div would floor
+		}
+		return l.Rem(l, r), nil
+	}
+	panic("bigcalc: unknown node")
+}
+
+// EvalBigFloat evaluates the expression with big.Float at prec bits of
+// mantissa. Results are rounded to nearest-even after every operation.
+func (e *Expr) EvalBigFloat(prec uint) (*big.Float, error) {
+	if prec == 0 {
+		prec = DefaultPrec
+	}
+	return evalBigFloat(e.root, prec)
+}
+
+func evalBigFloat(n node, prec uint) (*big.Float, error) {
+	switch n := n.(type) {
+	case numLit:
+		f, _, err := big.ParseFloat(n.text, 10, prec, big.ToNearestEven)
+		return f, err
+	case unaryOp:
+		x, err := evalBigFloat(n.x, prec)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == '-' {
+			x.Neg(x)
+		}
+		return x, nil
+	case binaryOp:
+		l, err := evalBigFloat(n.l, prec)
+		if err != nil {
+			return nil, err
+		}
+		r, err := evalBigFloat(n.r, prec)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case '+':
+			return l.Add(l, r), nil
+		case '-':
+			return l.Sub(l, r), nil
+		case '*':
+			return l.Mul(l, r), nil
+		}
+		if r.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		if n.op == '/' {
+			return l.Quo(l, r), nil
+		}
+		// l % r = l - trunc(l/r)*r
+		q := new(big.Float).SetPrec(prec).Quo(l, r)
+		qi, _ := q.Int(nil)
+		q.SetInt(qi)
+		return l.Sub(l, q.Mul(q, r)), nil
+	}
+	panic("bigcalc: unknown node")
+}
+
+// ---------------------------------------------------------
+// 5. SIDE-BY-SIDE COMPARISON
+// ---------------------------------------------------------
+
+// Result is the same expression evaluated three ways. Each column holds
+// either a formatted value or the error text, so a failure in one column
+// (e.g. 1.5 in int mode) does not hide the others.
+type Result struct {
+	Expr    string
+	Int     string
+	Float64 string
+	Exact   string
+	Mode    Mode
+}
+
+// Evaluate parses src and evaluates it as int, float64 and in the given
+// exact mode. prec only matters for Float (0 means DefaultPrec).
+func Evaluate(src string, mode Mode, prec uint) (Result, error) {
+	e, err := Parse(src)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{Expr: strings.TrimSpace(src), Mode: mode}
+
+	if v, err := e.EvalInt(); err != nil {
+		res.Int = "error: " + err.Error()
+	} else {
+		res.Int = strconv.Itoa(v)
+	}
+
+	if v, err := e.EvalFloat64(); err != nil {
+		res.Float64 = "error: " + err.Error()
+	} else {
+		res.Float64 = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	res.Exact, err = e.Format(mode, prec)
+	if err != nil {
+		res.Exact = "error: " + err.Error()
+	}
+	return res, nil
+}
+
+// Format evaluates the expression in mode and renders the result. Rats are
+// shown as a fraction plus a decimal approximation when not whole.
+func (e *Expr) Format(mode Mode, prec uint) (string, error) {
+	switch mode {
+	case Rat:
+		r, err := e.EvalRat()
+		if err != nil {
+			return "", err
+		}
+		if r.IsInt() {
+			return r.Num().String(), nil
+		}
+		return fmt.Sprintf("%s (≈ %s)", r.RatString(), r.FloatString(20)), nil
+	case Int:
+		v, err := e.EvalBigInt()
+		if err != nil {
+			return "", err
+		}
+		return v.String(), nil
+	case Float:
+		v, err := e.EvalBigFloat(prec)
+		if err != nil {
+			return "", err
+		}
+		return v.Text('g', -1), nil
+	}
+	return "", fmt.Errorf("bigcalc: unknown mode %v", mode)
+}
@@ -0,0 +1,253 @@
+package bigcalc
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func parse(t *testing.T, src string) *Expr {
+	t.Helper()
+	e, err := Parse(src)
+	if err != nil {
+		t.Fatalf("%s: %v", src, err)
+	}
+	return e
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct {
+		src  string
+		pos  int
+		want string
+	}{
+		{"", 1, "unexpected end of expression"},
+		{"1 +", 4, "unexpected end of expression"},
+		{"(1 + 2", 7, "expected ')'"},
+		{"1 + 2)", 6, `unexpected ')'`},
+		{"1 2", 3, `unexpected '2'`},
+		{"2 * x", 5, `unexpected 'x'`},
+		{"1 + 1.2.3", 5, `malformed number "1.2.3"`},
+		{".", 1, `malformed number "."`},
+		{"2 ^ 3", 3, `unexpected '^'`},
+	} {
+		_, err := Parse(tt.src)
+		var se *SyntaxError
+		if !errors.As(err, &se) || se.Pos != tt.pos || se.Msg != tt.want {
+			t.Errorf("Parse(%q) = %v, want column %d: %s", tt.src, err, tt.pos, tt.want)
+		}
+	}
+	if _, err := Parse("\t-(+1 -\t.5) * 2. % 3 "); err != nil {
+		t.Errorf("Parse with tabs, unary signs and bare dots: %v", err)
+	}
+}
+
+func TestEvalInt(t *testing.T) {
+	for _, tt := range []struct {
+		src  string
+		want int
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"7 / 2", 3},
+		{"-7 / 2", -3}, // truncates toward zero
+		{"7 % -3", 1},
+		{"-7 % 3", -1}, // the sign follows the dividend
+		{"2 * 3 % 4", 2},
+		{"--5 + -+1", 4},
+	} {
+		if got, err := parse(t, tt.src).EvalInt(); err != nil || got != tt.want {
+			t.Errorf("%s = %d, %v, want %d", tt.src, got, err, tt.want)
+		}
+	}
+	if strconv.IntSize == 64 {
+		if got, _ := parse(t, "9223372036854775807 + 1").EvalInt(); got != math.MinInt {
+			t.Errorf("MaxInt + 1 = %d, want it to wrap to MinInt", got)
+		}
+	}
+	for _, tt := range []struct {
+		src  string
+		want string
+	}{
+		{"1.5 + 1", ErrNotInteger.Error()},
+		{"1 / (2 - 2)", ErrDivisionByZero.Error()},
+		{"1 % 0", ErrDivisionByZero.Error()},
+		{"99999999999999999999", "overflows int"},
+	} {
+		if _, err := parse(t, tt.src).EvalInt(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error %v, want %q", tt.src, err, tt.want)
+		}
+	}
+}
+
+func TestEvalBigInt(t *testing.T) {
+	for _, tt := range []struct{ src, want string }{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"99999999999999999999 * 99999999999999999999", "9999999999999999999800000000000000000001"},
+		{"-7 / 2", "-3"}, // Quo, not Div
+		{"-7 % 3", "-1"}, // Rem, not Mod
+		{"-(2 - 5)", "3"},
+	} {
+		got, err := parse(t, tt.src).EvalBigInt()
+		if err != nil || got.String() != tt.want {
+			t.Errorf("%s = %v, %v, want %s", tt.src, got, err, tt.want)
+		}
+	}
+	if _, err := parse(t, "2.5 * 2").EvalBigInt(); !errors.Is(err, ErrNotInteger) {
+		t.Errorf("2.5 * 2: %v, want ErrNotInteger", err)
+	}
+	if _, err := parse(t, "1 / 0").EvalBigInt(); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("1 / 0: %v, want ErrDivisionByZero", err)
+	}
+}
+
+func TestEvalRat(t *testing.T) {
+	for _, tt := range []struct{ src, want string }{
+		{"1 / 3 + 1 / 6", "1/2"},
+		{"0.1 + 0.2", "3/10"},
+		{"7 / 2", "7/2"},
+		{"-7 % 3", "-1"},
+		{"(4 / 2) % 3", "2"}, // whole fractions count as integers
+		{"-(1 / 3)", "-1/3"},
+		{".5 * 4", "2"},
+	} {
+		got, err := parse(t, tt.src).EvalRat()
+		if err != nil || got.RatString() != tt.want {
+			t.Errorf("%s = %v, %v, want %s", tt.src, got, err, tt.want)
+		}
+	}
+	if _, err := parse(t, "1.5 % 1").EvalRat(); !errors.Is(err, ErrNotInteger) {
+		t.Errorf("1.5 %% 1: %v, want ErrNotInteger", err)
+	}
+	if _, err := parse(t, "1 / (1 / 2 - 0.5)").EvalRat(); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("1 / 0: %v, want ErrDivisionByZero", err)
+	}
+}
+
+func TestEvalBigFloat(t *testing.T) {
+	// At 53 bits big.Float rounds like float64, so it repeats its error.
+	got, err := parse(t, "0.1 + 0.2").EvalBigFloat(53)
+	if f, _ := got.Float64(); err != nil || f != 0.30000000000000004 {
+		t.Errorf("0.1 + 0.2 at 53 bits = %v, %v, want float64's 0.30000000000000004", got, err)
+	}
+	// At the default precision the error is far below what float64 can show.
+	got, err = parse(t, "0.1 + 0.2").EvalBigFloat(0)
+	if f, _ := got.Float64(); err != nil || got.Prec() != DefaultPrec || f != 0.3 {
+		t.Errorf("0.1 + 0.2 = %v (prec %d), %v, want 0.3 at %d bits", got, got.Prec(), err, DefaultPrec)
+	}
+	for _, tt := range []struct {
+		src  string
+		want float64
+	}{
+		{"7 % 2.5", 2},
+		{"-7 % 2", -1},
+		{"7.5 % -2", 1.5},
+		{"1 / 4 - 1", -0.75},
+	} {
+		got, err := parse(t, tt.src).EvalBigFloat(0)
+		if f, _ := got.Float64(); err != nil || f != tt.want {
+			t.Errorf("%s = %v, %v, want %v", tt.src, got, err, tt.want)
+		}
+	}
+	if _, err := parse(t, "1 % (0.5 - .5)").EvalBigFloat(0); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("1 %% 0: %v, want ErrDivisionByZero", err)
+	}
+}
+
+// TestFloat64VsRat lists where float64 and big.Rat disagree, and a few
+// expressions where float64 happens to be exact.
+func TestFloat64VsRat(t *testing.T) {
+	for _, tt := range []struct {
+		src     string
+		float   float64 // what float64 gives
+		rat     string  // the exact result
+		diverge bool    // float64 is not the nearest float to the exact result
+	}{
+		{"0.1 + 0.2", 0.30000000000000004, "3/10", true},
+		{"0.1 * 3", 0.30000000000000004, "3/10", true},
+		{"1 - 0.9", 0.09999999999999998, "1/10", true},
+		{"1.1 * 1.1", 1.2100000000000002, "121/100", true},
+		{"10000000000000000 + 1 - 10000000000000000", 0, "1", true},
+		{"9007199254740993 - 9007199254740992", 0, "1", true},
+		{"0.3 - 0.1 - 0.2", -2.7755575615628914e-17, "0", true},
+		{"1 / 3 * 3", 1, "1", false}, // rounding errors cancel
+		{"0.5 + 0.25", 0.75, "3/4", false},
+		{"1 / 10", 0.1, "1/10", false}, // one rounding is the nearest float
+	} {
+		e := parse(t, tt.src)
+		f, err := e.EvalFloat64()
+		if err != nil || f != tt.float {
+			t.Errorf("%s in float64 = %v, %v, want %v", tt.src, f, err, tt.float)
+		}
+		r, err := e.EvalRat()
+		if err != nil || r.RatString() != tt.rat {
+			t.Errorf("%s in big.Rat = %v, %v, want %s", tt.src, r, err, tt.rat)
+			continue
+		}
+		if nearest, _ := r.Float64(); (f != nearest) != tt.diverge {
+			t.Errorf("%s: float64 %v, nearest float to %s is %v; diverge = %v", tt.src, f, tt.rat, nearest, tt.diverge)
+		}
+	}
+	if _, err := parse(t, "1 / 0").EvalFloat64(); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("1 / 0 in float64: %v, want ErrDivisionByZero, not +Inf", err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	for _, tt := range []struct {
+		src   string
+		mode  Mode
+		int   string
+		float string
+		exact string
+	}{
+		{" 7 / 2 ", Rat, "3", "3.5", "7/2 (≈ 3.50000000000000000000)"},
+		{"6 / 3", Rat, "2", "2", "2"},
+		{"7 / 2", Int, "3", "3.5", "3"},
+		{"1 / 3", Float, "0", "0.3333333333333333", ""},
+		{"1.5 * 2", Int, "error: " + ErrNotInteger.Error() + ": 1.5", "3", "error: " + ErrNotInteger.Error() + ": 1.5"},
+		{"1 % 0", Rat, "error: " + ErrDivisionByZero.Error(), "error: " + ErrDivisionByZero.Error(), "error: " + ErrDivisionByZero.Error()},
+	} {
+		res, err := Evaluate(tt.src, tt.mode, 0)
+		if err != nil {
+			t.Errorf("%s: %v", tt.src, err)
+			continue
+		}
+		if res.Expr != strings.TrimSpace(tt.src) || res.Int != tt.int || res.Float64 != tt.float ||
+			tt.exact != "" && res.Exact != tt.exact {
+			t.Errorf("Evaluate(%q, %v) = %+v", tt.src, tt.mode, res)
+		}
+	}
+	res, _ := Evaluate("1 / 3", Float, 0)
+	if !strings.HasPrefix(res.Exact, "0.33333333333333333333333333333333333333") {
+		t.Errorf("1 / 3 at %d bits = %s, want far more digits than float64", DefaultPrec, res.Exact)
+	}
+	if _, err := Evaluate("1 +", Rat, 0); err == nil {
+		t.Error("Evaluate of a syntax error succeeded")
+	}
+	if _, err := parse(t, "1").Format(Mode(9), 0); err == nil {
+		t.Error("Format with an unknown mode succeeded")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want Mode
+	}{
+		{"rat", Rat}, {" Exact ", Rat}, {"int", Int}, {"BIGINT", Int}, {"float", Float}, {"bigfloat", Float},
+	} {
+		if got, err := ParseMode(tt.in); err != nil || got != tt.want {
+			t.Errorf("ParseMode(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseMode("decimal"); err == nil {
+		t.Error("ParseMode(decimal) succeeded")
+	}
+	if Float.String() != "float" || Mode(9).String() != "Mode(9)" {
+		t.Errorf("String = %s, %s", Float, Mode(9))
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ViKing-py/lets-go-in-go/03_basic_types/bigcalc"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Exact Arithmetic REPL (math/big)
+// ---------------------------------------------------------
+// Usage:
+//   go run ./03_basic_types/bigcalc/cmd/bigcalc              (interactive)
+//   go run ./03_basic_types/bigcalc/cmd/bigcalc -mode float -prec 64 "1 / 3"
+//
+// Every line is evaluated three times so you can see where int truncation
+// and float64 rounding differ from the exact answer:
+//
+//   > 3 / 2
+//   int:      1
+//   float64:  1.5
+//   rat:      3/2 (≈ 1.50000000000000000000)
+
+func main() {
+	modeFlag := flag.String("mode", "rat", "exact arithmetic: rat, int (big.Int) or float (big.Float)")
+	prec := flag.Uint("prec", bigcalc.DefaultPrec, "big.Float precision in bits (only for -mode float)")
+	flag.Parse()
+
+	mode, err := bigcalc.ParseMode(*modeFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// One-shot mode: the expression is given on the command line.
+	if flag.NArg() > 0 {
+		if !run(strings.Join(flag.Args(), " "), mode, *prec) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Printf("bigcalc (%s mode). Type an expression, or 'quit' to exit.\n", mode)
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			return
+		}
+		line := strings.TrimSpace(scanner.Text())
+		switch line {
+		case "":
+			continue
+		case "quit", "exit":
+			return
+		}
+		run(line, mode, *prec)
+	}
+}
+
+// run evaluates one expression and prints the three columns.
+// It reports false if the expression could not be parsed.
+func run(src string, mode bigcalc.Mode, prec uint) bool {
+	res, err := bigcalc.Evaluate(src, mode, prec)
+	if err != nil {
+		fmt.Println(err)
+		return false
+	}
+	fmt.Printf("  int:      %s\n", res.Int)
+	fmt.Printf("  float64:  %s\n", res.Float64)
+	fmt.Printf("  %-8s  %s\n", res.Mode.String()+":", res.Exact)
+	return true
+}
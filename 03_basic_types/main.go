@@ -86,5 +86,8 @@ func main() {
 //    Fix:
 //    res := 3.0 / 2.0 -> Result is 1.5
 //
+//    To compare int, float64 and exact (math/big) results side by side:
+//    go run ./03_basic_types/bigcalc/cmd/bigcalc "3 / 2"
+//
 // 3. Unused Variables:
 //    If you declare 'var x int' and don't use 'x', code won't compile.
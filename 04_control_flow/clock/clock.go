@@ -0,0 +1,207 @@
+// Package clock hides time.Now behind an interface so code that depends on
+// the current time (like the tagless switch in 04_control_flow) can be
+// driven by a fake clock in tests and demos.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Ticker is the part of *time.Ticker that callers use.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// TickerClock is a Clock that can also create tickers. Components that
+// need to wake up periodically (e.g. a countdown) depend on this instead
+// of calling time.NewTicker directly.
+type TickerClock interface {
+	Clock
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is the part of *time.Timer that AfterFunc callers use.
+type Timer interface {
+	// Stop prevents the call and reports whether it did, false if the
+	// call already ran or the timer was stopped before.
+	Stop() bool
+}
+
+// TimerClock is a Clock that can run a function later. Components that
+// delay work (e.g. a debounce) depend on this instead of calling
+// time.AfterFunc directly.
+type TimerClock interface {
+	Clock
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Real is the Clock backed by the system time.
+type Real struct{}
+
+// Now returns time.Now().
+func (Real) Now() time.Time { return time.Now() }
+
+// NewTicker wraps time.NewTicker.
+func (Real) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// AfterFunc wraps time.AfterFunc: f runs in its own goroutine.
+func (Real) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+type realTicker struct{ t *time.Ticker }
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+func (r realTicker) Stop()               { r.t.Stop() }
+
+// Fake is a Clock that only moves when told to. It is safe for
+// concurrent use.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+	timers  []*fakeTimer
+}
+
+// NewFake returns a fake clock frozen at t.
+func NewFake(t time.Time) *Fake {
+	return &Fake{now: t}
+}
+
+// Now returns the fake's current time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set jumps the clock to t. Jumping forward fires tickers exactly like
+// Advance; jumping backwards fires nothing.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if t.After(f.now) {
+		f.advanceTo(t)
+		return
+	}
+	f.now = t
+}
+
+// Advance moves the clock forward by d, firing every ticker whose period
+// elapses on the way, in time order.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.advanceTo(f.now.Add(d))
+}
+
+// NewTicker returns a ticker driven by Advance and Set. Like
+// time.Ticker, its channel holds one tick and drops ticks nobody reads.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := &fakeTicker{f: f, c: make(chan time.Time, 1), period: d, next: f.now.Add(d)}
+	f.tickers = append(f.tickers, t)
+	return t
+}
+
+// AfterFunc calls fn once Advance or Set reaches d from now. Unlike
+// time.AfterFunc, fn runs in the goroutine that moves the clock, before
+// Advance returns, so a demo knows exactly what has run. A d <= 0 does
+// not run fn at once; it runs on the next Advance or Set, even by 0.
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := &fakeTimer{f: f, when: f.now.Add(max(d, 0)), fn: fn}
+	f.timers = append(f.timers, t)
+	return t
+}
+
+// advanceTo must be called with f.mu held. It releases the lock while a
+// timer function runs, so the function may use the clock, including
+// starting timers that are due before target.
+func (f *Fake) advanceTo(target time.Time) {
+	for {
+		var due *fakeTicker
+		for _, t := range f.tickers {
+			if !t.next.After(target) && (due == nil || t.next.Before(due.next)) {
+				due = t
+			}
+		}
+		timer := -1
+		for i, t := range f.timers {
+			if !t.when.After(target) && (timer < 0 || t.when.Before(f.timers[timer].when)) {
+				timer = i
+			}
+		}
+		if timer >= 0 && (due == nil || !due.next.Before(f.timers[timer].when)) {
+			t := f.timers[timer]
+			f.timers = append(f.timers[:timer], f.timers[timer+1:]...)
+			f.now = t.when
+			f.mu.Unlock()
+			t.fn()
+			f.mu.Lock()
+			continue
+		}
+		if due == nil {
+			break
+		}
+		f.now = due.next
+		select {
+		case due.c <- f.now:
+		default: // reader is behind; drop the tick like time.Ticker does
+		}
+		due.next = due.next.Add(due.period)
+	}
+	f.now = target
+}
+
+type fakeTimer struct {
+	f    *Fake
+	when time.Time
+	fn   func()
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	for i, other := range t.f.timers {
+		if other == t {
+			t.f.timers = append(t.f.timers[:i], t.f.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTicker struct {
+	f      *Fake
+	c      chan time.Time
+	period time.Duration
+	next   time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+
+func (t *fakeTicker) Stop() {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	for i, other := range t.f.tickers {
+		if other == t {
+			t.f.tickers = append(t.f.tickers[:i], t.f.tickers[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/grading"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Grading Bands Without 'fallthrough'
+// ---------------------------------------------------------
+// Usage:
+//   printf 'alice,92\nbob,50\ncarol,31\n' | go run ./04_control_flow/grading/cmd/grade
+//   go run ./04_control_flow/grading/cmd/grade -format json -config bands.json scores.txt
+//
+// Input is one "student,score" pair per line. Blank lines and lines
+// starting with '#' are ignored.
+
+func main() {
+	format := flag.String("format", "text", "output format: text or json")
+	config := flag.String("config", "", "JSON band/badge config (default: 0-100 lesson scale)")
+	flag.Parse()
+
+	cfg := grading.DefaultConfig()
+	if *config != "" {
+		f, err := os.Open(*config)
+		if err != nil {
+			fail(err)
+		}
+		cfg, err = grading.LoadConfig(f)
+		f.Close()
+		if err != nil {
+			fail(err)
+		}
+	}
+	grader, err := grading.New(cfg)
+	if err != nil {
+		fail(err)
+	}
+
+	in := io.Reader(os.Stdin)
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		in = f
+	}
+	scores, err := readScores(in)
+	if err != nil {
+		fail(err)
+	}
+
+	report, err := grader.Evaluate(scores)
+	if err != nil {
+		fail(err)
+	}
+	switch *format {
+	case "json":
+		err = report.WriteJSON(os.Stdout)
+	case "text":
+		err = report.WriteText(os.Stdout, grader.Config().Bands)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func readScores(r io.Reader) ([]grading.Score, error) {
+	var scores []grading.Score
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(text, ",")
+		if !ok {
+			return nil, fmt.Errorf("line %d: want student,score", line)
+		}
+		score, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		scores = append(scores, grading.Score{Student: strings.TrimSpace(name), Score: score})
+	}
+	return scores, sc.Err()
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
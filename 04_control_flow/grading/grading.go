@@ -0,0 +1,311 @@
+// Package grading replaces the `score` switch with `fallthrough` from
+// 04_control_flow. Bands (fail, pass, merit, distinction) give every score
+// exactly one grade; badges are cumulative, so a distinction also earns the
+// pass and merit badges — which is what the fallthrough was trying to say.
+package grading
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// ---------------------------------------------------------
+// 1. CONFIG
+// ---------------------------------------------------------
+
+// Band is a half-open score interval [Min, Max). The band whose Max equals
+// Config.MaxScore also includes MaxScore itself.
+type Band struct {
+	Name string  `json:"name"`
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+}
+
+// Badge is awarded to every score >= Min. Badges stack.
+type Badge struct {
+	Name string  `json:"name"`
+	Min  float64 `json:"min"`
+}
+
+// Config describes the scale and its bands and badges.
+type Config struct {
+	MinScore float64 `json:"min_score"`
+	MaxScore float64 `json:"max_score"`
+	Bands    []Band  `json:"bands"`
+	Badges   []Badge `json:"badges"`
+}
+
+// DefaultConfig is the 0–100 scale used in the lesson.
+func DefaultConfig() Config {
+	return Config{
+		MinScore: 0,
+		MaxScore: 100,
+		Bands: []Band{
+			{Name: "fail", Min: 0, Max: 50},
+			{Name: "pass", Min: 50, Max: 65},
+			{Name: "merit", Min: 65, Max: 80},
+			{Name: "distinction", Min: 80, Max: 100},
+		},
+		Badges: []Badge{
+			{Name: "passed", Min: 50},
+			{Name: "merit", Min: 65},
+			{Name: "distinction", Min: 80},
+			{Name: "perfect", Min: 100},
+		},
+	}
+}
+
+// LoadConfig decodes a Config from JSON and validates it.
+func LoadConfig(r io.Reader) (Config, error) {
+	var cfg Config
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("grading: decode config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Validate checks that the bands tile [MinScore, MaxScore] exactly: no
+// overlaps, no gaps, nothing outside the scale. It sorts Bands and Badges
+// in place by Min.
+func (c *Config) Validate() error {
+	if !(c.MinScore < c.MaxScore) {
+		return fmt.Errorf("grading: min_score %v must be below max_score %v", c.MinScore, c.MaxScore)
+	}
+	if len(c.Bands) == 0 {
+		return fmt.Errorf("grading: no bands configured")
+	}
+	sort.SliceStable(c.Bands, func(i, j int) bool { return c.Bands[i].Min < c.Bands[j].Min })
+	sort.SliceStable(c.Badges, func(i, j int) bool { return c.Badges[i].Min < c.Badges[j].Min })
+
+	names := make(map[string]bool)
+	for i, b := range c.Bands {
+		if b.Name == "" {
+			return fmt.Errorf("grading: band [%v, %v) has no name", b.Min, b.Max)
+		}
+		if names[b.Name] {
+			return fmt.Errorf("grading: duplicate band %q", b.Name)
+		}
+		names[b.Name] = true
+		if !(b.Min < b.Max) {
+			return fmt.Errorf("grading: band %q is empty: min %v >= max %v", b.Name, b.Min, b.Max)
+		}
+		if i == 0 {
+			if b.Min != c.MinScore {
+				return fmt.Errorf("grading: gap [%v, %v) before band %q", c.MinScore, b.Min, b.Name)
+			}
+			continue
+		}
+		prev := c.Bands[i-1]
+		switch {
+		case b.Min < prev.Max:
+			return fmt.Errorf("grading: bands %q and %q overlap on [%v, %v)", prev.Name, b.Name, b.Min, prev.Max)
+		case b.Min > prev.Max:
+			return fmt.Errorf("grading: gap [%v, %v) between bands %q and %q", prev.Max, b.Min, prev.Name, b.Name)
+		}
+	}
+	if last := c.Bands[len(c.Bands)-1]; last.Max != c.MaxScore {
+		if last.Max > c.MaxScore {
+			return fmt.Errorf("grading: band %q ends at %v, beyond max_score %v", last.Name, last.Max, c.MaxScore)
+		}
+		return fmt.Errorf("grading: gap [%v, %v] after band %q", last.Max, c.MaxScore, last.Name)
+	}
+
+	badges := make(map[string]bool)
+	for _, b := range c.Badges {
+		if b.Name == "" || badges[b.Name] {
+			return fmt.Errorf("grading: badge names must be unique and non-empty (got %q)", b.Name)
+		}
+		badges[b.Name] = true
+		if b.Min < c.MinScore || b.Min > c.MaxScore {
+			return fmt.Errorf("grading: badge %q threshold %v is outside the scale", b.Name, b.Min)
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------
+// 2. EVALUATION
+// ---------------------------------------------------------
+
+// Score is one student's result.
+type Score struct {
+	Student string  `json:"student"`
+	Score   float64 `json:"score"`
+}
+
+// StudentReport is the grade and badges earned by one Score.
+type StudentReport struct {
+	Student string   `json:"student"`
+	Score   float64  `json:"score"`
+	Band    string   `json:"band"`
+	Badges  []string `json:"badges"`
+}
+
+// Summary holds statistics over all scores.
+type Summary struct {
+	Count      int            `json:"count"`
+	Mean       float64        `json:"mean"`
+	Median     float64        `json:"median"`
+	Min        float64        `json:"min"`
+	Max        float64        `json:"max"`
+	StdDev     float64        `json:"std_dev"`
+	BandCounts map[string]int `json:"band_counts"`
+}
+
+// Report is the result of Evaluate.
+type Report struct {
+	Students []StudentReport `json:"students"`
+	Summary  Summary         `json:"summary"`
+}
+
+// Grader evaluates scores against a validated Config.
+type Grader struct {
+	cfg Config
+}
+
+// New validates cfg and returns a Grader.
+func New(cfg Config) (*Grader, error) {
+	cfg.Bands = append([]Band(nil), cfg.Bands...)
+	cfg.Badges = append([]Badge(nil), cfg.Badges...)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Grader{cfg: cfg}, nil
+}
+
+// Band returns the band containing score.
+func (g *Grader) Band(score float64) (Band, error) {
+	if score < g.cfg.MinScore || score > g.cfg.MaxScore || math.IsNaN(score) {
+		return Band{}, fmt.Errorf("grading: score %v outside [%v, %v]", score, g.cfg.MinScore, g.cfg.MaxScore)
+	}
+	for _, b := range g.cfg.Bands {
+		if score < b.Max {
+			return b, nil
+		}
+	}
+	// score == MaxScore belongs to the last band.
+	return g.cfg.Bands[len(g.cfg.Bands)-1], nil
+}
+
+// Badges returns every badge earned by score, lowest threshold first.
+// Unlike fallthrough, each badge is checked against its own condition.
+func (g *Grader) Badges(score float64) []string {
+	earned := []string{}
+	for _, b := range g.cfg.Badges {
+		if score >= b.Min {
+			earned = append(earned, b.Name)
+		}
+	}
+	return earned
+}
+
+// Evaluate grades every score and computes summary statistics.
+func (g *Grader) Evaluate(scores []Score) (Report, error) {
+	rep := Report{
+		Students: make([]StudentReport, 0, len(scores)),
+		Summary:  Summary{BandCounts: make(map[string]int)},
+	}
+	for _, b := range g.cfg.Bands {
+		rep.Summary.BandCounts[b.Name] = 0
+	}
+
+	values := make([]float64, 0, len(scores))
+	for _, s := range scores {
+		band, err := g.Band(s.Score)
+		if err != nil {
+			return Report{}, fmt.Errorf("%s: %w", s.Student, err)
+		}
+		rep.Students = append(rep.Students, StudentReport{
+			Student: s.Student,
+			Score:   s.Score,
+			Band:    band.Name,
+			Badges:  g.Badges(s.Score),
+		})
+		rep.Summary.BandCounts[band.Name]++
+		values = append(values, s.Score)
+	}
+	rep.Summary.fill(values)
+	return rep, nil
+}
+
+func (s *Summary) fill(values []float64) {
+	s.Count = len(values)
+	if s.Count == 0 {
+		return
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	s.Min, s.Max = sorted[0], sorted[len(sorted)-1]
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	s.Mean = sum / float64(s.Count)
+
+	mid := s.Count / 2
+	if s.Count%2 == 1 {
+		s.Median = sorted[mid]
+	} else {
+		s.Median = (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	// Population standard deviation.
+	sq := 0.0
+	for _, v := range sorted {
+		sq += (v - s.Mean) * (v - s.Mean)
+	}
+	s.StdDev = math.Sqrt(sq / float64(s.Count))
+}
+
+// ---------------------------------------------------------
+// 3. OUTPUT
+// ---------------------------------------------------------
+
+// WriteJSON writes the report as indented JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteText writes the report as an aligned table followed by the summary.
+// bands fixes the order of the per-band counts.
+func (r Report) WriteText(w io.Writer, bands []Band) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STUDENT\tSCORE\tBAND\tBADGES")
+	for _, s := range r.Students {
+		fmt.Fprintf(tw, "%s\t%g\t%s\t%s\n", s.Student, s.Score, s.Band, strings.Join(s.Badges, ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	sm := r.Summary
+	fmt.Fprintf(w, "\nCount: %d  Mean: %.2f  Median: %.2f  Min: %g  Max: %g  StdDev: %.2f\n",
+		sm.Count, sm.Mean, sm.Median, sm.Min, sm.Max, sm.StdDev)
+	for _, b := range bands {
+		fmt.Fprintf(w, "  %-12s %d\n", b.Name+":", sm.BandCounts[b.Name])
+	}
+	return nil
+}
+
+// Config returns a copy of the grader's (sorted) configuration; changing
+// it does not affect the grader.
+func (g *Grader) Config() Config {
+	cfg := g.cfg
+	cfg.Bands = slices.Clone(cfg.Bands)
+	cfg.Badges = slices.Clone(cfg.Badges)
+	return cfg
+}
@@ -0,0 +1,117 @@
+package grading
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	bands := func(b ...Band) Config {
+		return Config{MinScore: 0, MaxScore: 100, Bands: b}
+	}
+	for _, tt := range []struct {
+		name string
+		cfg  Config
+		want string // "" for a valid config
+	}{
+		{"default", DefaultConfig(), ""},
+		{"unsorted", bands(Band{"b", 50, 100}, Band{"a", 0, 50}), ""},
+		{"one band", bands(Band{"all", 0, 100}), ""},
+		{"empty scale", Config{MinScore: 10, MaxScore: 10, Bands: []Band{{"a", 10, 10}}}, "must be below max_score"},
+		{"no bands", bands(), "no bands"},
+		{"unnamed", bands(Band{"", 0, 100}), "has no name"},
+		{"duplicate", bands(Band{"a", 0, 50}, Band{"a", 50, 100}), `duplicate band "a"`},
+		{"empty band", bands(Band{"a", 0, 50}, Band{"b", 50, 50}, Band{"c", 50, 100}), `band "b" is empty`},
+		{"gap before", bands(Band{"a", 10, 100}), "gap [0, 10) before band"},
+		{"gap between", bands(Band{"a", 0, 40}, Band{"b", 50, 100}), `gap [40, 50) between bands "a" and "b"`},
+		{"overlap", bands(Band{"a", 0, 60}, Band{"b", 50, 100}), `bands "a" and "b" overlap on [50, 60)`},
+		{"nested", bands(Band{"a", 0, 100}, Band{"b", 20, 30}), "overlap"},
+		{"gap after", bands(Band{"a", 0, 90}), "gap [90, 100] after band"},
+		{"beyond max", bands(Band{"a", 0, 110}), "beyond max_score"},
+		{"badge outside", Config{MaxScore: 100, Bands: []Band{{"a", 0, 100}}, Badges: []Badge{{"x", 101}}}, "outside the scale"},
+		{"badge twice", Config{MaxScore: 100, Bands: []Band{{"a", 0, 100}}, Badges: []Badge{{"x", 1}, {"x", 2}}}, "badge names must be unique"},
+	} {
+		err := tt.cfg.Validate()
+		if tt.want == "" && err != nil || tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)) {
+			t.Errorf("%s: Validate = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestBand(t *testing.T) {
+	g, err := New(DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		score float64
+		want  string
+	}{
+		{0, "fail"},
+		{49.99, "fail"},
+		{50, "pass"},
+		{64.5, "pass"},
+		{65, "merit"},
+		{80, "distinction"},
+		{100, "distinction"}, // MaxScore belongs to the last band
+	} {
+		if b, err := g.Band(tt.score); err != nil || b.Name != tt.want {
+			t.Errorf("Band(%v) = %q, %v, want %q", tt.score, b.Name, err, tt.want)
+		}
+	}
+	for _, score := range []float64{-1, 100.01, math.NaN(), math.Inf(1)} {
+		if _, err := g.Band(score); err == nil {
+			t.Errorf("Band(%v) succeeded", score)
+		}
+	}
+	if got := g.Badges(80); !slices.Equal(got, []string{"passed", "merit", "distinction"}) {
+		t.Errorf("Badges(80) = %v", got)
+	}
+	if got := g.Badges(10); got == nil || len(got) != 0 {
+		t.Errorf("Badges(10) = %#v, want an empty, non-nil slice", got)
+	}
+}
+
+func TestConfigIsCopy(t *testing.T) {
+	cfg := DefaultConfig()
+	slices.Reverse(cfg.Bands)
+	g, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Bands[0].Name != "distinction" {
+		t.Error("New sorted the caller's bands")
+	}
+
+	got := g.Config()
+	if got.Bands[0].Name != "fail" {
+		t.Errorf("Config().Bands[0] = %q, want the sorted fail", got.Bands[0].Name)
+	}
+	got.Bands[0].Max = 90
+	got.Badges[0].Min = 0
+	if b, _ := g.Band(60); b.Name != "pass" {
+		t.Errorf("changing Config() moved 60 into %q", b.Name)
+	}
+	if badges := g.Badges(10); len(badges) != 0 {
+		t.Errorf("changing Config() awarded %v to 10", badges)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	in := `{"min_score":0,"max_score":10,"bands":[{"name":"hi","min":5,"max":10},{"name":"lo","min":0,"max":5}]}`
+	cfg, err := LoadConfig(strings.NewReader(in))
+	if err != nil || cfg.Bands[0].Name != "lo" {
+		t.Errorf("LoadConfig = %+v, %v", cfg, err)
+	}
+	for _, in := range []string{
+		`{"max_score":10,"bands":[{"name":"a","min":0,"max":10}],"extra":1}`,
+		`{"max_score":10,"bands":[{"name":"a","min":0,"max":9}]}`,
+		`{`,
+	} {
+		if _, err := LoadConfig(strings.NewReader(in)); err == nil {
+			t.Errorf("LoadConfig(%s) succeeded", in)
+		}
+	}
+}
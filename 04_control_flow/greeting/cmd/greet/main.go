@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/greeting"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Time-Zone-Aware Greetings
+// ---------------------------------------------------------
+// Usage:
+//   go run ./04_control_flow/greeting/cmd/greet -tz Europe/Kyiv
+//   go run ./04_control_flow/greeting/cmd/greet -tz Europe/Kyiv -at 2026-03-29T01:30:00Z
+//   go run ./04_control_flow/greeting/cmd/greet -config policy.json
+//   go run ./04_control_flow/greeting/cmd/greet -tz Europe/Kyiv -dst 2026
+//
+// -at freezes a fake clock instead of reading time.Now().
+// -dst prints the greeting hour by hour around the spring and autumn
+// clock changes of that year, so you can watch the boundaries move.
+
+func main() {
+	tz := flag.String("tz", "Local", "IANA time zone, e.g. Europe/Kyiv")
+	at := flag.String("at", "", "fixed instant (RFC 3339) instead of the real clock")
+	config := flag.String("config", "", "JSON policy file ({\"time_zone\": ..., \"dayparts\": [...]})")
+	dst := flag.Int("dst", 0, "year whose DST transitions to walk through")
+	flag.Parse()
+
+	policy, err := loadPolicy(*tz, *config)
+	if err != nil {
+		fail(err)
+	}
+
+	if *dst != 0 {
+		walkTransitions(policy, *dst)
+		return
+	}
+
+	var clk clock.Clock = clock.Real{}
+	if *at != "" {
+		t, err := time.Parse(time.RFC3339, *at)
+		if err != nil {
+			fail(err)
+		}
+		clk = clock.NewFake(t)
+	}
+
+	g := greeting.New(policy, clk)
+	now := clk.Now().In(policy.Location())
+	fmt.Printf("%s (%s): %s\n", now.Format("15:04 MST"), policy.Location(), g.Greet())
+}
+
+func loadPolicy(tz, config string) (*greeting.Policy, error) {
+	if config != "" {
+		f, err := os.Open(config)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return greeting.LoadPolicy(f)
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+	return greeting.NewPolicy(loc, greeting.DefaultDayparts())
+}
+
+// walkTransitions finds the zone offset changes in year and prints each
+// hour around them, driving the greeter with a fake clock.
+func walkTransitions(policy *greeting.Policy, year int) {
+	loc := policy.Location()
+	fake := clock.NewFake(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC))
+	g := greeting.New(policy, fake)
+
+	end := time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, prevOffset := fake.Now().In(loc).Zone()
+	found := false
+	for t := fake.Now(); t.Before(end); t = t.Add(time.Hour) {
+		if _, off := t.In(loc).Zone(); off != prevOffset {
+			found = true
+			fmt.Printf("--- offset change near %s ---\n", t.Format(time.RFC3339))
+			for h := -3; h <= 3; h++ {
+				fake.Set(t.Add(time.Duration(h) * time.Hour))
+				local := fake.Now().In(loc)
+				fmt.Printf("  %s UTC = %s  %s\n", fake.Now().UTC().Format("15:04"), local.Format("15:04 MST"), g.Greet())
+			}
+			prevOffset = off
+		}
+	}
+	if !found {
+		fmt.Printf("%s has no offset changes in %d\n", loc, year)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
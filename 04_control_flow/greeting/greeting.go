@@ -0,0 +1,195 @@
+// Package greeting turns the "Good morning / afternoon / evening" tagless
+// switch from 04_control_flow into a policy: the caller chooses the time
+// zone, the daypart boundaries and the clock.
+package greeting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+
+	// Embed the IANA database so zones like Europe/Kyiv resolve even on
+	// machines (or containers) without /usr/share/zoneinfo.
+	_ "time/tzdata"
+)
+
+// ---------------------------------------------------------
+// 1. TIME OF DAY
+// ---------------------------------------------------------
+
+// TimeOfDay is a wall-clock time expressed as minutes since midnight.
+// It is written as "HH:MM" in JSON.
+type TimeOfDay int
+
+// ParseTimeOfDay parses "HH:MM" (24-hour clock).
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("greeting: bad time of day %q: want HH:MM", s)
+	}
+	return TimeOfDay(t.Hour()*60 + t.Minute()), nil
+}
+
+// Of returns the wall-clock time of t in t's own location.
+func Of(t time.Time) TimeOfDay {
+	return TimeOfDay(t.Hour()*60 + t.Minute())
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(t)/60, int(t)%60)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TimeOfDay) UnmarshalText(b []byte) error {
+	v, err := ParseTimeOfDay(string(b))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// ---------------------------------------------------------
+// 2. DAYPARTS
+// ---------------------------------------------------------
+
+// Daypart is one row of the boundary table: it starts at Start and lasts
+// until the next daypart starts (the last one wraps past midnight).
+type Daypart struct {
+	Name     string    `json:"name"`
+	Start    TimeOfDay `json:"start"`
+	Greeting string    `json:"greeting"`
+}
+
+// DefaultDayparts is a sensible table for an office day.
+func DefaultDayparts() []Daypart {
+	return []Daypart{
+		{Name: "night", Start: 0, Greeting: "Good night!"},
+		{Name: "morning", Start: 5 * 60, Greeting: "Good morning!"},
+		{Name: "afternoon", Start: 12 * 60, Greeting: "Good afternoon!"},
+		{Name: "evening", Start: 17 * 60, Greeting: "Good evening!"},
+		{Name: "night", Start: 22 * 60, Greeting: "Good night!"},
+	}
+}
+
+// ErrNoDayparts is returned when a policy has an empty table.
+var ErrNoDayparts = errors.New("greeting: no dayparts configured")
+
+// ---------------------------------------------------------
+// 3. POLICY
+// ---------------------------------------------------------
+
+// Policy picks a daypart for an instant, as seen in Location.
+type Policy struct {
+	loc   *time.Location
+	parts []Daypart // sorted by Start, validated
+}
+
+// NewPolicy validates parts (non-empty, times within a day, no duplicate
+// start times) and returns a policy for loc. A nil loc means UTC.
+func NewPolicy(loc *time.Location, parts []Daypart) (*Policy, error) {
+	if len(parts) == 0 {
+		return nil, ErrNoDayparts
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	sorted := append([]Daypart(nil), parts...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	for i, p := range sorted {
+		if p.Name == "" {
+			return nil, fmt.Errorf("greeting: daypart at %s has no name", p.Start)
+		}
+		if p.Start < 0 || p.Start >= 24*60 {
+			return nil, fmt.Errorf("greeting: daypart %q starts outside the day (%d minutes)", p.Name, int(p.Start))
+		}
+		if i > 0 && sorted[i-1].Start == p.Start {
+			return nil, fmt.Errorf("greeting: dayparts %q and %q both start at %s", sorted[i-1].Name, p.Name, p.Start)
+		}
+	}
+	return &Policy{loc: loc, parts: sorted}, nil
+}
+
+// Config is the JSON form of a policy.
+type Config struct {
+	TimeZone string    `json:"time_zone"`
+	Dayparts []Daypart `json:"dayparts"`
+}
+
+// LoadPolicy reads a Config from r. An empty time_zone means UTC and an
+// empty dayparts list means DefaultDayparts.
+func LoadPolicy(r io.Reader) (*Policy, error) {
+	var cfg Config
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("greeting: decode config: %w", err)
+	}
+	loc, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("greeting: %w", err)
+	}
+	if len(cfg.Dayparts) == 0 {
+		cfg.Dayparts = DefaultDayparts()
+	}
+	return NewPolicy(loc, cfg.Dayparts)
+}
+
+// Location returns the policy's time zone.
+func (p *Policy) Location() *time.Location { return p.loc }
+
+// Dayparts returns a copy of the sorted boundary table.
+func (p *Policy) Dayparts() []Daypart {
+	return append([]Daypart(nil), p.parts...)
+}
+
+// Daypart returns the daypart that contains t in the policy's time zone.
+// The comparison uses the local wall clock, so DST shifts move the
+// boundaries together with the clocks on the wall.
+func (p *Policy) Daypart(t time.Time) Daypart {
+	tod := Of(t.In(p.loc))
+	// Like the tagless switch: walk the boundaries and keep the last one
+	// we have passed. Before the first boundary we are still in the last
+	// daypart of the previous day.
+	current := p.parts[len(p.parts)-1]
+	for _, part := range p.parts {
+		if tod < part.Start {
+			break
+		}
+		current = part
+	}
+	return current
+}
+
+// ---------------------------------------------------------
+// 4. GREETER
+// ---------------------------------------------------------
+
+// Greeter combines a Policy with a Clock.
+type Greeter struct {
+	Policy *Policy
+	Clock  clock.Clock
+}
+
+// New returns a Greeter; a nil clk means the real clock.
+func New(p *Policy, clk clock.Clock) *Greeter {
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &Greeter{Policy: p, Clock: clk}
+}
+
+// Greet returns the greeting for the clock's current time.
+func (g *Greeter) Greet() string {
+	return g.Policy.Daypart(g.Clock.Now()).Greeting
+}
@@ -0,0 +1,102 @@
+package greeting
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // Europe/Kyiv even where the system has no zoneinfo
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+)
+
+// TestKyivDST checks the default dayparts on both sides of the 2026
+// transitions in Europe/Kyiv. Clocks go from 03:00 EET to 04:00 EEST on
+// 29 March and from 04:00 EEST back to 03:00 EET on 25 October, both at
+// 01:00 UTC. Morning starts at 05:00 local, so the same UTC instant can
+// be night on one side of a transition and morning on the other.
+func TestKyivDST(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewPolicy(kyiv, DefaultDayparts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, time.UTC)
+	}
+	for _, tc := range []struct {
+		at    time.Time
+		local string // wall clock in Kyiv
+		part  string
+	}{
+		// Spring forward: 03:00 EET becomes 04:00 EEST.
+		{utc(time.March, 28, 2, 0), "04:00 EET", "night"},
+		{utc(time.March, 28, 3, 0), "05:00 EET", "morning"},
+		{utc(time.March, 29, 0, 59), "02:59 EET", "night"},
+		{utc(time.March, 29, 1, 0), "04:00 EEST", "night"},
+		{utc(time.March, 29, 1, 59), "04:59 EEST", "night"},
+		{utc(time.March, 29, 2, 0), "05:00 EEST", "morning"}, // an hour earlier in UTC than the day before
+		{utc(time.March, 29, 9, 0), "12:00 EEST", "afternoon"},
+
+		// Fall back: 04:00 EEST becomes 03:00 EET.
+		{utc(time.October, 24, 2, 0), "05:00 EEST", "morning"},
+		{utc(time.October, 25, 0, 59), "03:59 EEST", "night"},
+		{utc(time.October, 25, 1, 0), "03:00 EET", "night"},
+		{utc(time.October, 25, 2, 0), "04:00 EET", "night"}, // morning the day before
+		{utc(time.October, 25, 2, 59), "04:59 EET", "night"},
+		{utc(time.October, 25, 3, 0), "05:00 EET", "morning"},
+		{utc(time.October, 25, 15, 0), "17:00 EET", "evening"},
+		{utc(time.October, 25, 20, 0), "22:00 EET", "night"},
+	} {
+		fake := clock.NewFake(tc.at)
+		g := New(p, fake)
+		if got := fake.Now().In(kyiv).Format("15:04 MST"); got != tc.local {
+			t.Errorf("%v: local time %s, want %s", tc.at, got, tc.local)
+		}
+		if got := p.Daypart(fake.Now()).Name; got != tc.part {
+			t.Errorf("%v (%s): daypart %q, want %q", tc.at, tc.local, got, tc.part)
+		}
+		if got, want := g.Greet(), greetingOf(tc.part); got != want {
+			t.Errorf("%v (%s): Greet() = %q, want %q", tc.at, tc.local, got, want)
+		}
+	}
+}
+
+// TestKyivDSTAdvance moves one fake clock minute by minute across the
+// spring transition. The greeting must change at 05:00 EEST, only 90
+// minutes after the 02:30 EET start, because the hour 03:00-04:00 is
+// skipped.
+func TestKyivDSTAdvance(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewPolicy(kyiv, DefaultDayparts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := clock.NewFake(time.Date(2026, time.March, 29, 0, 30, 0, 0, time.UTC)) // 02:30 EET
+	g := New(p, fake)
+	var changed time.Time
+	for range 4 * 60 {
+		before := g.Greet()
+		fake.Advance(time.Minute)
+		if g.Greet() != before {
+			changed = fake.Now()
+			break
+		}
+	}
+	if want := time.Date(2026, time.March, 29, 2, 0, 0, 0, time.UTC); !changed.Equal(want) {
+		t.Errorf("greeting changed at %v, want %v (05:00 EEST)", changed, want)
+	}
+}
+
+func greetingOf(name string) string {
+	for _, d := range DefaultDayparts() {
+		if d.Name == name {
+			return d.Greeting
+		}
+	}
+	return ""
+}
@@ -60,6 +60,8 @@ func main() {
 
 	// Switch without a variable acts like a long chain of if-else.
 	// It's often cleaner to read than many if-else statements.
+	// (Reading time.Now() directly makes this hard to test and ignores the
+	// reader's time zone. See ./greeting and ./clock for the injectable version.)
 	hour := time.Now().Hour()
 
 	switch {
@@ -103,6 +105,8 @@ func main() {
 //    When using 'fallthrough', Go executes the next case explicitly
 //    WITHOUT checking if the next case matches the condition.
 //    Use it very rarely!
+//    For cumulative rewards (a distinction also counts as a pass), check
+//    each threshold on its own instead. See ./grading for a worked example.
 //
 // 3. Opening Brace Placement:
 //    Just like functions, the '{' for if/switch must be on the same line.
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/rules"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Rules Instead of If/Else Chains
+// ---------------------------------------------------------
+// Usage:
+//   go run ./04_control_flow/rules/cmd/rules age=18 day=Monday
+//   go run ./04_control_flow/rules/cmd/rules -all age=30 day=Sunday
+//   go run ./04_control_flow/rules/cmd/rules -rules my_rules.json score=72
+//
+// Facts are key=value pairs. Values that parse as numbers or bools are
+// typed accordingly; everything else is a string.
+// Without -rules, the embedded lesson.json reproduces the age and day
+// checks from 04_control_flow/main.go.
+
+//go:embed lesson.json
+var lessonRules []byte
+
+func main() {
+	path := flag.String("rules", "", "JSON rule file (default: embedded lesson rules)")
+	all := flag.Bool("all", false, "report every matching rule instead of the first one")
+	flag.Parse()
+
+	src := lessonRules
+	if *path != "" {
+		b, err := os.ReadFile(*path)
+		if err != nil {
+			fail(err)
+		}
+		src = b
+	}
+	engine, err := rules.Load(bytes.NewReader(src))
+	if err != nil {
+		fail(err)
+	}
+
+	facts := rules.Facts{}
+	for _, arg := range flag.Args() {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			fail(fmt.Errorf("fact %q: want key=value", arg))
+		}
+		facts[key] = typed(value)
+	}
+
+	mode := rules.FirstMatch
+	if *all {
+		mode = rules.AllMatch
+	}
+	res, err := engine.Evaluate(facts, mode)
+	if err != nil {
+		fail(err)
+	}
+
+	fmt.Println("--- Trace ---")
+	fmt.Print(res.Explain())
+	fmt.Println("--- Outcome ---")
+	if len(res.Outcomes) == 0 {
+		fmt.Println("(no rule fired)")
+	}
+	for i, out := range res.Outcomes {
+		fmt.Printf("%s: %v\n", res.Fired[i], out)
+	}
+}
+
+func typed(s string) any {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	return s
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
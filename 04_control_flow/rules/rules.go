@@ -0,0 +1,482 @@
+// Package rules is a small declarative rules engine. It replaces the
+// hand-written if/else and switch chains from 04_control_flow (adulthood
+// from age, weekend from day) with rules loaded from JSON.
+//
+// A rule set looks like:
+//
+//	{"rules": [
+//	  {"name": "adult", "priority": 10,
+//	   "when": {"fact": "age", "op": ">=", "value": 18},
+//	   "then": "You are an adult."},
+//	  {"name": "weekend",
+//	   "when": {"fact": "day", "op": "in", "values": ["Saturday", "Sunday"]},
+//	   "then": "It's the weekend!"}
+//	]}
+//
+// Conditions can be combined with {"all": [...]}, {"any": [...]} and
+// {"not": {...}}.
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------
+// 1. FACTS
+// ---------------------------------------------------------
+
+// Facts are the typed inputs rules are evaluated against. Values must be
+// numbers (any Go int/float type or json.Number), strings or bools.
+type Facts map[string]any
+
+// ErrTypeMismatch is returned when a fact and a rule value have kinds that
+// cannot be compared (e.g. "age" >= "eighteen").
+var ErrTypeMismatch = errors.New("rules: type mismatch")
+
+// kind classifies a value for comparison purposes.
+type kind int
+
+const (
+	kindInvalid kind = iota
+	kindNumber
+	kindString
+	kindBool
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindNumber:
+		return "number"
+	case kindString:
+		return "string"
+	case kindBool:
+		return "bool"
+	}
+	return "invalid"
+}
+
+// normalize converts v to string, bool or a number. Integers become
+// int64 or uint64 and keep their exact value; only floats become float64.
+func normalize(v any) (any, kind) {
+	switch x := v.(type) {
+	case string:
+		return x, kindString
+	case bool:
+		return x, kindBool
+	case int:
+		return int64(x), kindNumber
+	case int8:
+		return int64(x), kindNumber
+	case int16:
+		return int64(x), kindNumber
+	case int32:
+		return int64(x), kindNumber
+	case int64:
+		return x, kindNumber
+	case uint:
+		return uint64(x), kindNumber
+	case uint8:
+		return uint64(x), kindNumber
+	case uint16:
+		return uint64(x), kindNumber
+	case uint32:
+		return uint64(x), kindNumber
+	case uint64:
+		return x, kindNumber
+	case float32:
+		return float64(x), kindNumber
+	case float64:
+		return x, kindNumber
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			return n, kindNumber
+		}
+		if n, err := strconv.ParseUint(string(x), 10, 64); err == nil {
+			return n, kindNumber
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return nil, kindInvalid
+		}
+		return f, kindNumber
+	}
+	return nil, kindInvalid
+}
+
+// compareNumbers compares two normalized numbers exactly, so that
+// 9007199254740993 > 9007199254740992 holds even though both round to
+// the same float64. ok is false when either side is NaN, which is
+// unordered: like float64, it is neither equal to, less than nor greater
+// than anything, itself included.
+func compareNumbers(a, b any) (cmp int, ok bool) {
+	x, y := exact(a), exact(b)
+	if x == nil || y == nil {
+		return 0, false
+	}
+	return x.Cmp(y), true
+}
+
+// exact returns a normalized number as a big.Float without rounding, or
+// nil for NaN.
+func exact(v any) *big.Float {
+	switch x := v.(type) {
+	case int64:
+		return new(big.Float).SetInt64(x)
+	case uint64:
+		return new(big.Float).SetUint64(x)
+	case float64:
+		if math.IsNaN(x) {
+			return nil
+		}
+		return new(big.Float).SetFloat64(x)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------
+// 2. CONDITIONS
+// ---------------------------------------------------------
+
+// Op is a comparison operator.
+type Op string
+
+const (
+	Eq    Op = "=="
+	Ne    Op = "!="
+	Lt    Op = "<"
+	Le    Op = "<="
+	Gt    Op = ">"
+	Ge    Op = ">="
+	In    Op = "in"
+	NotIn Op = "not_in"
+)
+
+// Condition is either a comparison (Fact/Op/Value or Values) or one of the
+// combinators All, Any and Not. Exactly one form must be used.
+type Condition struct {
+	Fact   string `json:"fact,omitempty"`
+	Op     Op     `json:"op,omitempty"`
+	Value  any    `json:"value,omitempty"`
+	Values []any  `json:"values,omitempty"`
+
+	All []Condition `json:"all,omitempty"`
+	Any []Condition `json:"any,omitempty"`
+	Not *Condition  `json:"not,omitempty"`
+}
+
+// validate checks the shape of c; path locates it in error messages.
+func (c *Condition) validate(path string) error {
+	forms := 0
+	if c.Fact != "" {
+		forms++
+	}
+	if c.All != nil {
+		forms++
+	}
+	if c.Any != nil {
+		forms++
+	}
+	if c.Not != nil {
+		forms++
+	}
+	if forms != 1 {
+		return fmt.Errorf("rules: %s: want exactly one of fact, all, any, not", path)
+	}
+
+	switch {
+	case c.Fact != "":
+		switch c.Op {
+		case Eq, Ne, Lt, Le, Gt, Ge:
+			if c.Value == nil {
+				return fmt.Errorf("rules: %s: %q needs a value", path, c.Op)
+			}
+			if _, k := normalize(c.Value); k == kindInvalid {
+				return fmt.Errorf("rules: %s: unsupported value %v", path, c.Value)
+			}
+		case In, NotIn:
+			if len(c.Values) == 0 {
+				return fmt.Errorf("rules: %s: %q needs a non-empty values list", path, c.Op)
+			}
+			for _, v := range c.Values {
+				if _, k := normalize(v); k == kindInvalid {
+					return fmt.Errorf("rules: %s: unsupported value %v", path, v)
+				}
+			}
+		default:
+			return fmt.Errorf("rules: %s: unknown operator %q", path, c.Op)
+		}
+	case c.Not != nil:
+		return c.Not.validate(path + ".not")
+	default:
+		list, name := c.All, "all"
+		if c.Any != nil {
+			list, name = c.Any, "any"
+		}
+		if len(list) == 0 {
+			return fmt.Errorf("rules: %s: empty %q", path, name)
+		}
+		for i := range list {
+			if err := list[i].validate(fmt.Sprintf("%s.%s[%d]", path, name, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// String renders the condition the way it reads in the trace.
+func (c *Condition) String() string {
+	switch {
+	case c.Not != nil:
+		return "not (" + c.Not.String() + ")"
+	case c.All != nil || c.Any != nil:
+		list, sep := c.All, " and "
+		if c.Any != nil {
+			list, sep = c.Any, " or "
+		}
+		parts := make([]string, len(list))
+		for i := range list {
+			parts[i] = list[i].String()
+		}
+		return "(" + strings.Join(parts, sep) + ")"
+	case c.Op == In || c.Op == NotIn:
+		return fmt.Sprintf("%s %s %v", c.Fact, c.Op, c.Values)
+	default:
+		return fmt.Sprintf("%s %s %v", c.Fact, c.Op, c.Value)
+	}
+}
+
+// eval evaluates c and appends one human-readable line per comparison to
+// notes. Combinators short-circuit like && and ||.
+func (c *Condition) eval(facts Facts, notes *[]string) (bool, error) {
+	switch {
+	case c.Not != nil:
+		ok, err := c.Not.eval(facts, notes)
+		return !ok, err
+	case c.All != nil:
+		for i := range c.All {
+			ok, err := c.All[i].eval(facts, notes)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case c.Any != nil:
+		for i := range c.Any {
+			ok, err := c.Any[i].eval(facts, notes)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+
+	raw, present := facts[c.Fact]
+	if !present {
+		*notes = append(*notes, fmt.Sprintf("%s: fact missing -> false", c))
+		return false, nil
+	}
+	fact, fk := normalize(raw)
+	if fk == kindInvalid {
+		return false, fmt.Errorf("%w: fact %q has unsupported type %T", ErrTypeMismatch, c.Fact, raw)
+	}
+
+	var ok bool
+	var err error
+	switch c.Op {
+	case In, NotIn:
+		for _, v := range c.Values {
+			var eq bool
+			eq, err = compare(Eq, fact, fk, v)
+			if err != nil || eq {
+				ok = eq
+				break
+			}
+		}
+		if c.Op == NotIn {
+			ok = !ok
+		}
+	default:
+		ok, err = compare(c.Op, fact, fk, c.Value)
+	}
+	if err != nil {
+		return false, fmt.Errorf("fact %q: %w", c.Fact, err)
+	}
+	*notes = append(*notes, fmt.Sprintf("%s: %s=%v -> %t", c, c.Fact, raw, ok))
+	return ok, nil
+}
+
+func compare(op Op, fact any, fk kind, ruleValue any) (bool, error) {
+	v, vk := normalize(ruleValue)
+	if fk != vk {
+		return false, fmt.Errorf("%w: %s vs %s", ErrTypeMismatch, fk, vk)
+	}
+	switch fk {
+	case kindBool:
+		switch op {
+		case Eq:
+			return fact.(bool) == v.(bool), nil
+		case Ne:
+			return fact.(bool) != v.(bool), nil
+		}
+		return false, fmt.Errorf("%w: %q is not defined for bools", ErrTypeMismatch, op)
+	case kindString:
+		return ordered(op, strings.Compare(fact.(string), v.(string))), nil
+	default:
+		cmp, ok := compareNumbers(fact, v)
+		if !ok {
+			return op == Ne, nil // NaN: only != holds
+		}
+		return ordered(op, cmp), nil
+	}
+}
+
+func ordered(op Op, cmp int) bool {
+	switch op {
+	case Eq:
+		return cmp == 0
+	case Ne:
+		return cmp != 0
+	case Lt:
+		return cmp < 0
+	case Le:
+		return cmp <= 0
+	case Gt:
+		return cmp > 0
+	case Ge:
+		return cmp >= 0
+	}
+	return false
+}
+
+// ---------------------------------------------------------
+// 3. RULES & ENGINE
+// ---------------------------------------------------------
+
+// Rule fires its Then outcome when its When condition holds. Higher
+// Priority rules are evaluated first; ties keep file order.
+type Rule struct {
+	Name     string    `json:"name"`
+	Priority int       `json:"priority"`
+	When     Condition `json:"when"`
+	Then     any       `json:"then"`
+}
+
+// Mode controls how many rules may fire.
+type Mode int
+
+const (
+	// FirstMatch stops at the first rule that fires (like if/else if).
+	FirstMatch Mode = iota
+	// AllMatch evaluates every rule and reports all that fire.
+	AllMatch
+)
+
+// Engine holds a validated, priority-sorted rule set.
+type Engine struct {
+	rules []Rule
+}
+
+// NewEngine validates rules and sorts them by priority.
+func NewEngine(rules []Rule) (*Engine, error) {
+	seen := make(map[string]bool, len(rules))
+	for i := range rules {
+		r := &rules[i]
+		if r.Name == "" {
+			return nil, fmt.Errorf("rules: rule #%d has no name", i)
+		}
+		if seen[r.Name] {
+			return nil, fmt.Errorf("rules: duplicate rule name %q", r.Name)
+		}
+		seen[r.Name] = true
+		if err := r.When.validate(r.Name + ".when"); err != nil {
+			return nil, err
+		}
+	}
+	sorted := append([]Rule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Priority > sorted[j].Priority })
+	return &Engine{rules: sorted}, nil
+}
+
+// Load reads {"rules": [...]} from r. Numbers are kept as json.Number, and
+// integers among them are compared exactly, so a large ID or amount is
+// not rounded to the nearest float64 first.
+func Load(r io.Reader) (*Engine, error) {
+	var doc struct {
+		Rules []Rule `json:"rules"`
+	}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("rules: decode: %w", err)
+	}
+	return NewEngine(doc.Rules)
+}
+
+// Rules returns the rules in evaluation order.
+func (e *Engine) Rules() []Rule {
+	return append([]Rule(nil), e.rules...)
+}
+
+// Step is one entry in the explanation trace.
+type Step struct {
+	Rule    string   // rule name
+	Fired   bool     // did the condition hold?
+	Details []string // one line per comparison that was evaluated
+}
+
+// Result is what Evaluate returns: the outcomes of the fired rules (in
+// priority order) and a trace of every rule that was looked at.
+type Result struct {
+	Fired    []string // names of fired rules
+	Outcomes []any    // Then values of fired rules
+	Trace    []Step
+}
+
+// Evaluate runs the rules against facts.
+func (e *Engine) Evaluate(facts Facts, mode Mode) (Result, error) {
+	var res Result
+	for i := range e.rules {
+		r := &e.rules[i]
+		var notes []string
+		ok, err := r.When.eval(facts, &notes)
+		if err != nil {
+			return res, fmt.Errorf("rules: rule %q: %w", r.Name, err)
+		}
+		res.Trace = append(res.Trace, Step{Rule: r.Name, Fired: ok, Details: notes})
+		if !ok {
+			continue
+		}
+		res.Fired = append(res.Fired, r.Name)
+		res.Outcomes = append(res.Outcomes, r.Then)
+		if mode == FirstMatch {
+			break
+		}
+	}
+	return res, nil
+}
+
+// Explain formats the trace, one rule per line with its comparisons indented.
+func (r Result) Explain() string {
+	var b strings.Builder
+	for _, s := range r.Trace {
+		mark := "skip"
+		if s.Fired {
+			mark = "FIRE"
+		}
+		fmt.Fprintf(&b, "[%s] %s\n", mark, s.Rule)
+		for _, d := range s.Details {
+			fmt.Fprintf(&b, "       %s\n", d)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// fires loads a one-rule set with condition when and reports whether it
+// fires for facts.
+func fires(t *testing.T, when string, facts Facts) bool {
+	t.Helper()
+	e, err := Load(strings.NewReader(`{"rules": [{"name": "r", "when": ` + when + `, "then": true}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := e.Evaluate(facts, FirstMatch)
+	if err != nil {
+		t.Fatalf("%s with %v: %v", when, facts, err)
+	}
+	return len(res.Fired) == 1
+}
+
+func TestExactIntegers(t *testing.T) {
+	// 2^53+1 is the first integer a float64 cannot hold; it rounds to 2^53.
+	for _, tc := range []struct {
+		when  string
+		facts Facts
+		want  bool
+	}{
+		{`{"fact": "id", "op": "==", "value": 9007199254740993}`, Facts{"id": int64(9007199254740992)}, false},
+		{`{"fact": "id", "op": ">", "value": 9007199254740992}`, Facts{"id": int64(9007199254740993)}, true},
+		{`{"fact": "id", "op": "==", "value": 9007199254740993}`, Facts{"id": json.Number("9007199254740993")}, true},
+		{`{"fact": "id", "op": "in", "values": [1, 9007199254740993]}`, Facts{"id": uint64(9007199254740992)}, false},
+		{`{"fact": "n", "op": "<", "value": 18446744073709551615}`, Facts{"n": uint64(math.MaxUint64 - 1)}, true},
+		{`{"fact": "n", "op": ">", "value": -1}`, Facts{"n": uint64(math.MaxUint64)}, true},
+		{`{"fact": "n", "op": "<", "value": 9223372036854775807}`, Facts{"n": int64(math.MaxInt64)}, false},
+		// Integers and floats still compare by value.
+		{`{"fact": "age", "op": ">=", "value": 18}`, Facts{"age": 18.0}, true},
+		{`{"fact": "age", "op": ">=", "value": 18}`, Facts{"age": 17.5}, false},
+		{`{"fact": "age", "op": "==", "value": 1e3}`, Facts{"age": 1000}, true},
+		{`{"fact": "score", "op": "<", "value": 0.5}`, Facts{"score": int8(0)}, true},
+		{`{"fact": "big", "op": "<", "value": 1e300}`, Facts{"big": uint64(math.MaxUint64)}, true},
+		{`{"fact": "x", "op": "<", "value": 9007199254740993}`, Facts{"x": 9007199254740992.0}, true},
+	} {
+		if got := fires(t, tc.when, tc.facts); got != tc.want {
+			t.Errorf("%s with %v = %t, want %t", tc.when, tc.facts, got, tc.want)
+		}
+	}
+}
+
+func TestNaN(t *testing.T) {
+	// NaN is unordered: every comparison is false except !=.
+	nan := Facts{"x": math.NaN()}
+	for _, tc := range []struct {
+		op   string
+		want bool
+	}{
+		{"==", false}, {"!=", true}, {"<", false}, {"<=", false}, {">", false}, {">=", false},
+	} {
+		when := `{"fact": "x", "op": "` + tc.op + `", "value": 1}`
+		if got := fires(t, when, nan); got != tc.want {
+			t.Errorf("NaN %s 1 = %t, want %t", tc.op, got, tc.want)
+		}
+	}
+	if fires(t, `{"fact": "x", "op": "in", "values": [1, 2]}`, nan) {
+		t.Error("NaN in [1, 2] fired")
+	}
+	if !fires(t, `{"fact": "x", "op": "not_in", "values": [1, 2]}`, nan) {
+		t.Error("NaN not_in [1, 2] did not fire")
+	}
+}
+
+func TestTypeMismatch(t *testing.T) {
+	e, err := Load(strings.NewReader(`{"rules": [{"name": "adult", "when": {"fact": "age", "op": ">=", "value": 18}, "then": "adult"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Evaluate(Facts{"age": "eighteen"}, FirstMatch); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("string fact against a number: %v, want ErrTypeMismatch", err)
+	}
+}
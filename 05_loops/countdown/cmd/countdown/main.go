@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+	"github.com/ViKing-py/lets-go-in-go/05_loops/countdown"
+)
+
+// ---------------------------------------------------------
+// TOPIC: A Live, Cancellable Countdown
+// ---------------------------------------------------------
+// Usage:
+//   go run ./05_loops/countdown/cmd/countdown -d 10s
+//   go run ./05_loops/countdown/cmd/countdown -demo
+//
+// Press Enter to pause / resume, Ctrl+C to cancel.
+// -demo replays a scripted run on a fake clock: a whole minute of
+// countdown finishes instantly because nothing actually sleeps.
+
+func main() {
+	total := flag.Duration("d", 10*time.Second, "countdown length")
+	every := flag.Duration("every", time.Second, "tick interval")
+	demo := flag.Bool("demo", false, "run a scripted countdown on a fake clock")
+	flag.Parse()
+
+	if *demo {
+		runDemo()
+		return
+	}
+
+	// Ctrl+C cancels the context, which the timer turns into a Cancelled event.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	timer, err := countdown.New(*total, *every, nil)
+	if err != nil {
+		fail(err)
+	}
+	events := timer.Start(ctx)
+
+	// Enter toggles pause. Reading stdin blocks, so it gets its own goroutine.
+	go func() {
+		paused := false
+		sc := bufio.NewScanner(os.Stdin)
+		for sc.Scan() {
+			var err error
+			if paused {
+				err = timer.Resume()
+			} else {
+				err = timer.Pause()
+			}
+			if err != nil {
+				return
+			}
+			paused = !paused
+		}
+	}()
+
+	for ev := range events {
+		// \r returns the cursor to the start of the line so the countdown
+		// redraws in place instead of scrolling.
+		switch ev.Kind {
+		case countdown.Paused:
+			fmt.Printf("\r%s  [paused - Enter to resume]   ", format(ev.Remaining))
+		case countdown.Done:
+			fmt.Printf("\r%s  done!                          \n", format(ev.Remaining))
+		case countdown.Cancelled:
+			fmt.Printf("\r%s  cancelled                      \n", format(ev.Remaining))
+		default:
+			fmt.Printf("\r%s                                 ", format(ev.Remaining))
+		}
+	}
+}
+
+// format renders a duration as MM:SS, rounding up so the display shows
+// 00:01 until the very end rather than 00:00 for most of the last second.
+func format(d time.Duration) string {
+	secs := int((d + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
+// runDemo drives a one-minute countdown with a fake clock: advance 20s,
+// pause, let 30s pass (ignored), resume, then run to the end.
+func runDemo() {
+	fake := clock.NewFake(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	timer, err := countdown.New(time.Minute, 10*time.Second, fake)
+	if err != nil {
+		fail(err)
+	}
+	events := timer.Start(context.Background())
+
+	show := func(ev countdown.Event) {
+		fmt.Printf("%s  %-9s remaining %s\n", ev.At.Format("15:04:05"), ev.Kind, format(ev.Remaining))
+	}
+	// step advances the fake clock one tick at a time and prints the
+	// resulting event, so the demo stays in lockstep with the timer.
+	step := func(n int) {
+		for range n {
+			fake.Advance(10 * time.Second)
+			show(<-events)
+		}
+	}
+
+	show(<-events) // Started
+	step(2)
+
+	timer.Pause()
+	show(<-events)
+	fake.Advance(30 * time.Second) // paused: the ticker is stopped, nothing fires
+	timer.Resume()
+	show(<-events)
+
+	for {
+		fake.Advance(10 * time.Second)
+		ev := <-events
+		show(ev)
+		if ev.Kind == countdown.Done {
+			return
+		}
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
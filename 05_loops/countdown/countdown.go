@@ -0,0 +1,260 @@
+// Package countdown turns the `for counter > 0` countdown from 05_loops
+// into a real timer. It is the "infinite loop listening to channels" from
+// that lesson: one goroutine selects on a ticker, a context and a channel
+// that wakes it for Pause and Resume, and reports what happened as events
+// on another channel.
+//
+// Time comes from a clock.TickerClock, so a clock.Fake can drive a
+// countdown in tests and demos without sleeping.
+package countdown
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+)
+
+// ---------------------------------------------------------
+// 1. EVENTS
+// ---------------------------------------------------------
+
+// Kind says what an Event reports.
+type Kind int
+
+const (
+	Started Kind = iota
+	Tick
+	Paused
+	Resumed
+	Done      // the countdown reached zero
+	Cancelled // the context was cancelled first
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Started:
+		return "started"
+	case Tick:
+		return "tick"
+	case Paused:
+		return "paused"
+	case Resumed:
+		return "resumed"
+	case Done:
+		return "done"
+	case Cancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
+
+// Event is sent on the channel returned by Start. Remaining never goes
+// below zero. The last event is always Done or Cancelled, after which the
+// channel is closed, so readers must drain the channel until it closes.
+// A reader that stops early must cancel the context instead: the
+// goroutine then leaves the last event in the channel's buffer, in place
+// of an unread one if need be, and exits.
+type Event struct {
+	Kind      Kind
+	At        time.Time
+	Remaining time.Duration
+}
+
+// ---------------------------------------------------------
+// 2. TIMER
+// ---------------------------------------------------------
+
+var (
+	// ErrNotRunning is returned by Pause and Resume before Start or after
+	// the countdown has finished.
+	ErrNotRunning = errors.New("countdown: not running")
+	// ErrInterval is returned by New for a tick interval of zero or less,
+	// which a ticker cannot have.
+	ErrInterval = errors.New("countdown: tick interval must be positive")
+)
+
+// Timer counts down from a total duration, ticking every interval.
+// A Timer can be started only once.
+type Timer struct {
+	total    time.Duration
+	interval time.Duration
+	clk      clock.TickerClock
+
+	started atomic.Bool
+	paused  atomic.Bool   // the state Pause and Resume last asked for
+	wake    chan struct{} // tells run to look at paused; buffered, never blocks
+	done    chan struct{}
+}
+
+// New returns a countdown of total, emitting a Tick every interval. A nil
+// clk means the real clock. An interval of zero or less is ErrInterval.
+func New(total, interval time.Duration, clk clock.TickerClock) (*Timer, error) {
+	if interval <= 0 {
+		return nil, ErrInterval
+	}
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &Timer{
+		total:    total,
+		interval: interval,
+		clk:      clk,
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Start launches the countdown goroutine and returns its event channel.
+// Cancelling ctx stops the countdown with a Cancelled event. Done is
+// reported on the first tick at or after the deadline.
+// Calling Start twice panics.
+func (t *Timer) Start(ctx context.Context) <-chan Event {
+	if t.started.Swap(true) {
+		panic("countdown: Start called twice")
+	}
+	events := make(chan Event, 1)
+	now := t.clk.Now() // read with the ticker, so both start together
+	ticker := t.clk.NewTicker(t.interval)
+	go t.run(ctx, now, ticker, events)
+	return events
+}
+
+// Pause freezes the remaining time. Pausing a paused timer is a no-op.
+//
+// Pause and Resume never block, so they are safe to call from the
+// goroutine reading the events, even while the countdown waits for it to
+// read. They only record the wanted state: the countdown acts on the
+// latest request, so a Pause followed at once by a Resume may report
+// neither.
+func (t *Timer) Pause() error { return t.request(true) }
+
+// Resume continues a paused countdown. Resuming a running timer is a no-op.
+func (t *Timer) Resume() error { return t.request(false) }
+
+func (t *Timer) request(pause bool) error {
+	if !t.started.Load() {
+		return ErrNotRunning
+	}
+	select {
+	case <-t.done:
+		return ErrNotRunning
+	default:
+	}
+	t.paused.Store(pause)
+	select {
+	case t.wake <- struct{}{}:
+	default: // a wake-up is already pending; run will read the new state
+	}
+	return nil
+}
+
+// run is the event loop. While running, remaining time is derived from
+// the deadline; while paused, it is frozen in 'remaining', the ticker is
+// stopped (so no stale ticks arrive later) and the deadline is recomputed
+// on resume.
+func (t *Timer) run(ctx context.Context, now time.Time, ticker clock.Ticker, events chan Event) {
+	defer close(events)
+	defer close(t.done)
+	defer func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+	}()
+
+	deadline := now.Add(t.total)
+	remaining := t.total
+
+	// emit blocks until the event is read or ctx is cancelled, so a slow
+	// reader delays the loop instead of losing events.
+	emit := func(k Kind, at time.Time) bool {
+		select {
+		case events <- Event{Kind: k, At: at, Remaining: max(remaining, 0)}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	// finish sends the final event. Once ctx is cancelled the reader may
+	// be gone, so instead of waiting, finish puts the event in the buffer,
+	// dropping an unread one to make room. Only run sends on events, so
+	// the send after the drop cannot block.
+	finish := func(k Kind, at time.Time) {
+		ev := Event{Kind: k, At: at, Remaining: max(remaining, 0)}
+		select {
+		case events <- ev:
+			return
+		case <-ctx.Done():
+		}
+		select {
+		case events <- ev:
+		default:
+			select {
+			case <-events:
+			default: // the reader took it meanwhile
+			}
+			events <- ev
+		}
+	}
+	cancelled := func() { finish(Cancelled, t.clk.Now()) }
+
+	if !emit(Started, now) {
+		cancelled()
+		return
+	}
+	if remaining <= 0 {
+		finish(Done, now)
+		return
+	}
+
+	for {
+		// A nil channel blocks forever, which disables this case while paused.
+		var tick <-chan time.Time
+		if ticker != nil {
+			tick = ticker.C()
+		}
+
+		select {
+		case <-ctx.Done():
+			if ticker != nil {
+				remaining = deadline.Sub(t.clk.Now())
+			}
+			cancelled()
+			return
+
+		case <-t.wake:
+			now := t.clk.Now()
+			pause := t.paused.Load()
+			switch {
+			case pause && ticker != nil:
+				ticker.Stop()
+				ticker = nil
+				remaining = deadline.Sub(now)
+				if !emit(Paused, now) {
+					cancelled()
+					return
+				}
+			case !pause && ticker == nil:
+				ticker = t.clk.NewTicker(t.interval)
+				deadline = now.Add(remaining)
+				if !emit(Resumed, now) {
+					cancelled()
+					return
+				}
+			}
+
+		case now := <-tick:
+			remaining = deadline.Sub(now)
+			if remaining <= 0 {
+				finish(Done, now)
+				return
+			}
+			if !emit(Tick, now) {
+				cancelled()
+				return
+			}
+		}
+	}
+}
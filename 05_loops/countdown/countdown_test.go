@@ -0,0 +1,190 @@
+package countdown
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+)
+
+var start = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// want is an expected event: its kind, seconds after start and seconds
+// remaining.
+type want struct {
+	kind     Kind
+	at, left int
+}
+
+func expect(t *testing.T, events <-chan Event, w want) {
+	t.Helper()
+	ev, ok := <-events
+	if !ok {
+		t.Fatalf("channel closed, want %v", w.kind)
+	}
+	got := want{ev.Kind, int(ev.At.Sub(start) / time.Second), int(ev.Remaining / time.Second)}
+	if got != w {
+		t.Fatalf("event = %v at +%ds with %ds left, want %v at +%ds with %ds left",
+			got.kind, got.at, got.left, w.kind, w.at, w.left)
+	}
+}
+
+func expectClosed(t *testing.T, events <-chan Event) {
+	t.Helper()
+	if ev, ok := <-events; ok {
+		t.Fatalf("got %v after the last event, want the channel closed", ev.Kind)
+	}
+}
+
+func TestNewInterval(t *testing.T) {
+	for _, every := range []time.Duration{0, -time.Second} {
+		if _, err := New(time.Minute, every, clock.NewFake(start)); !errors.Is(err, ErrInterval) {
+			t.Errorf("New(1m, %v) error = %v, want ErrInterval", every, err)
+		}
+	}
+}
+
+func TestTicks(t *testing.T) {
+	fake := clock.NewFake(start)
+	timer, err := New(35*time.Second, 10*time.Second, fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := timer.Start(context.Background())
+	expect(t, events, want{Started, 0, 35})
+	for _, w := range []want{{Tick, 10, 25}, {Tick, 20, 15}, {Tick, 30, 5}, {Done, 40, 0}} {
+		fake.Advance(10 * time.Second)
+		expect(t, events, w)
+	}
+	expectClosed(t, events)
+	if err := timer.Pause(); err != ErrNotRunning {
+		t.Errorf("Pause after Done = %v, want ErrNotRunning", err)
+	}
+}
+
+func TestZeroTotal(t *testing.T) {
+	timer, err := New(0, time.Second, clock.NewFake(start))
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := timer.Start(context.Background())
+	expect(t, events, want{Started, 0, 0})
+	expect(t, events, want{Done, 0, 0})
+	expectClosed(t, events)
+}
+
+func TestPauseResume(t *testing.T) {
+	fake := clock.NewFake(start)
+	timer, err := New(time.Minute, 10*time.Second, fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := timer.Pause(); err != ErrNotRunning {
+		t.Errorf("Pause before Start = %v, want ErrNotRunning", err)
+	}
+	events := timer.Start(context.Background())
+	expect(t, events, want{Started, 0, 60})
+	fake.Advance(10 * time.Second)
+	expect(t, events, want{Tick, 10, 50})
+
+	fake.Advance(5 * time.Second)
+	if err := timer.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, events, want{Paused, 15, 45})
+	fake.Advance(time.Minute) // no ticks while paused, and the time is not counted
+	select {
+	case ev := <-events:
+		t.Fatalf("got %v while paused", ev.Kind)
+	default:
+	}
+	if err := timer.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, events, want{Resumed, 75, 45})
+	for _, w := range []want{{Tick, 85, 35}, {Tick, 95, 25}, {Tick, 105, 15}, {Tick, 115, 5}, {Done, 125, 0}} {
+		fake.Advance(10 * time.Second)
+		expect(t, events, w)
+	}
+	expectClosed(t, events)
+}
+
+// TestPauseFromReader pauses from the reading goroutine while the
+// countdown is blocked sending a Tick behind an unread Started. Pause must
+// not wait for the countdown, which waits for the reader.
+func TestPauseFromReader(t *testing.T) {
+	fake := clock.NewFake(start)
+	timer, err := New(time.Minute, 10*time.Second, fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := timer.Start(context.Background())
+	fake.Advance(10 * time.Second)
+	paused := make(chan error, 1)
+	go func() { paused <- timer.Pause() }()
+	select {
+	case err := <-paused:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Pause blocked while the events buffer was full")
+	}
+	expect(t, events, want{Started, 0, 60})
+	// The countdown may see the pause before the tick, dropping the tick.
+	ev := <-events
+	if ev.Kind == Tick {
+		ev = <-events
+	}
+	if ev.Kind != Paused || !ev.At.Equal(start.Add(10*time.Second)) || ev.Remaining != 50*time.Second {
+		t.Fatalf("got %v at %v with %v left, want paused at +10s with 50s left", ev.Kind, ev.At, ev.Remaining)
+	}
+
+	// Repeated requests for the current state report nothing.
+	timer.Pause()
+	fake.Advance(time.Minute)
+	timer.Resume()
+	timer.Resume()
+	expect(t, events, want{Resumed, 70, 50})
+	fake.Advance(10 * time.Second)
+	expect(t, events, want{Tick, 80, 40})
+}
+
+func TestCancel(t *testing.T) {
+	fake := clock.NewFake(start)
+	timer, err := New(time.Minute, 10*time.Second, fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	events := timer.Start(ctx)
+	expect(t, events, want{Started, 0, 60})
+	fake.Advance(10 * time.Second)
+	expect(t, events, want{Tick, 10, 50})
+	fake.Advance(5 * time.Second)
+	cancel()
+	expect(t, events, want{Cancelled, 15, 45})
+	expectClosed(t, events)
+}
+
+// TestAbandoned stops reading after Start. Cancelling the context must
+// still end the goroutine, with Cancelled left as the only event.
+func TestAbandoned(t *testing.T) {
+	fake := clock.NewFake(start)
+	timer, err := New(time.Minute, 10*time.Second, fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	events := timer.Start(ctx)
+	fake.Advance(10 * time.Second) // the Tick waits behind the unread Started
+	cancel()
+	<-timer.done // closed when run returns
+	ev, ok := <-events
+	if !ok || ev.Kind != Cancelled {
+		t.Fatalf("left in the channel: %v (open %t), want cancelled", ev.Kind, ok)
+	}
+	expectClosed(t, events)
+}
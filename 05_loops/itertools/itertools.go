@@ -0,0 +1,233 @@
+// Package itertools is a small toolkit for Go 1.23 range-over-func
+// iterators (iter.Seq and iter.Seq2). Every adapter is lazy: nothing runs
+// until the result is ranged over, and stopping the range early (break)
+// stops the whole pipeline.
+//
+//	fruits := []string{"Apple", "Banana", "Cherry"}
+//	long := itertools.Filter(slices.Values(fruits), func(s string) bool { return len(s) > 5 })
+//	for i, f := range itertools.Enumerate(long) {
+//		fmt.Println(i, f) // 0 Banana, 1 Cherry
+//	}
+package itertools
+
+import "iter"
+
+// ---------------------------------------------------------
+// 1. TRANSFORMING
+// ---------------------------------------------------------
+
+// Map yields f(v) for every v in seq.
+func Map[T, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter yields only the values for which keep returns true.
+func Filter[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate pairs every value with its 0-based position, like the
+// index returned by range over a slice.
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// ---------------------------------------------------------
+// 2. SLICING
+// ---------------------------------------------------------
+
+// Take yields at most the first n values. It stops pulling from seq as
+// soon as n values were yielded, so it is safe on infinite sequences.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip drops the first n values and yields the rest.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Window yields every run of size consecutive values (a sliding window).
+// Each yielded slice is a fresh copy the caller may keep. Nothing is
+// yielded if seq has fewer than size values or size < 1.
+func Window[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size < 1 {
+			return
+		}
+		buf := make([]T, 0, size)
+		for v := range seq {
+			if len(buf) == size {
+				copy(buf, buf[1:])
+				buf = buf[:size-1]
+			}
+			buf = append(buf, v)
+			if len(buf) == size && !yield(append([]T(nil), buf...)) {
+				return
+			}
+		}
+	}
+}
+
+// ---------------------------------------------------------
+// 3. COMBINING
+// ---------------------------------------------------------
+
+// Chain yields all values of each sequence in turn.
+func Chain[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Zip yields pairs from a and b in lockstep and stops at the shorter one.
+// Two push iterators cannot be ranged in lockstep directly, so b is
+// converted into a pull iterator with iter.Pull.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for va := range a {
+			vb, ok := next()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// ---------------------------------------------------------
+// 4. PULL-BASED ADAPTERS
+// ---------------------------------------------------------
+// A "pull" iterator is a next() function you call yourself, the way you
+// would read from a channel or a bufio.Scanner. iter.Pull converts a push
+// iterator (iter.Seq) into one; these helpers go the other way and give
+// pull-style access to common operations.
+
+// FromPull turns a next function into a push iterator. It stops when
+// next reports false.
+func FromPull[T any](next func() (T, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := next()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Puller wraps iter.Pull with a peek-ahead slot. Always call Stop when
+// done, typically with defer, or the underlying iterator stays suspended.
+type Puller[T any] struct {
+	next   func() (T, bool)
+	stop   func()
+	peeked bool
+	head   T
+	ok     bool
+}
+
+// NewPuller starts pulling from seq.
+func NewPuller[T any](seq iter.Seq[T]) *Puller[T] {
+	next, stop := iter.Pull(seq)
+	return &Puller[T]{next: next, stop: stop}
+}
+
+// Next returns the next value, or false when seq is exhausted.
+func (p *Puller[T]) Next() (T, bool) {
+	if p.peeked {
+		p.peeked = false
+		return p.head, p.ok
+	}
+	return p.next()
+}
+
+// Peek returns the next value without consuming it.
+func (p *Puller[T]) Peek() (T, bool) {
+	if !p.peeked {
+		p.head, p.ok = p.next()
+		p.peeked = true
+	}
+	return p.head, p.ok
+}
+
+// Stop releases the underlying iterator and drops any peeked value, so
+// Next and Peek report false afterwards. It is safe to call more than once.
+func (p *Puller[T]) Stop() {
+	p.stop()
+	var zero T
+	p.head, p.ok, p.peeked = zero, false, true
+}
+
+// ---------------------------------------------------------
+// 5. CONSUMING
+// ---------------------------------------------------------
+
+// Collect gathers all values into a new slice.
+func Collect[T any](seq iter.Seq[T]) []T {
+	var out []T
+	for v := range seq {
+		out = append(out, v)
+	}
+	return out
+}
+
+// Reduce folds seq into a single value, starting from init.
+func Reduce[T, A any](seq iter.Seq[T], init A, f func(A, T) A) A {
+	acc := init
+	for v := range seq {
+		acc = f(acc, v)
+	}
+	return acc
+}
@@ -0,0 +1,318 @@
+package itertools
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+// source is a sequence of 0, 1, 2, ... that records how far it was pulled
+// and whether it returned, so a test can check that an adapter stops
+// its input as soon as it can.
+type source struct {
+	pulled int
+	done   bool
+}
+
+// seq yields the first n values, or never ends if n < 0.
+func (s *source) seq(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		defer func() { s.done = true }()
+		for i := 0; n < 0 || i < n; i++ {
+			s.pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestTake(t *testing.T) {
+	var src source
+	if got := Collect(Take(src.seq(-1), 3)); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("Take(naturals, 3) = %v", got)
+	}
+	if src.pulled != 3 || !src.done {
+		t.Errorf("Take pulled %d values (done %v), want exactly 3 and a stopped source", src.pulled, src.done)
+	}
+	for _, n := range []int{0, -1} {
+		src = source{}
+		if got := Collect(Take(src.seq(-1), n)); got != nil || src.pulled != 0 {
+			t.Errorf("Take(%d) = %v after pulling %d", n, got, src.pulled)
+		}
+	}
+	if got := Collect(Take(slices.Values([]int{1, 2}), 5)); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Take past the end = %v", got)
+	}
+}
+
+func TestSkip(t *testing.T) {
+	in := []int{0, 1, 2, 3, 4}
+	for _, tt := range []struct {
+		n    int
+		want []int
+	}{
+		{0, in},
+		{-2, in},
+		{2, []int{2, 3, 4}},
+		{5, nil},
+		{9, nil},
+	} {
+		if got := Collect(Skip(slices.Values(in), tt.n)); !slices.Equal(got, tt.want) {
+			t.Errorf("Skip(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestWindow(t *testing.T) {
+	got := Collect(Window(slices.Values([]int{0, 1, 2, 3, 4}), 3))
+	want := [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("Window(3) = %v, want %v", got, want)
+	}
+	got[0][0] = 99
+	if got[1][0] != 1 {
+		t.Error("windows share a backing array")
+	}
+	for _, size := range []int{0, -1, 6} {
+		if got := Collect(Window(slices.Values([]int{0, 1, 2, 3, 4}), size)); got != nil {
+			t.Errorf("Window(%d) = %v, want nothing", size, got)
+		}
+	}
+}
+
+func TestZip(t *testing.T) {
+	zip := func(a, b []int) (pairs [][2]int) {
+		for x, y := range Zip(slices.Values(a), slices.Values(b)) {
+			pairs = append(pairs, [2]int{x, y})
+		}
+		return pairs
+	}
+	want := [][2]int{{1, 10}, {2, 20}}
+	if got := zip([]int{1, 2, 3}, []int{10, 20}); !slices.Equal(got, want) {
+		t.Errorf("Zip with a shorter b = %v, want %v", got, want)
+	}
+	if got := zip([]int{1, 2}, []int{10, 20, 30}); !slices.Equal(got, want) {
+		t.Errorf("Zip with a shorter a = %v, want %v", got, want)
+	}
+	if got := zip(nil, []int{1}); got != nil {
+		t.Errorf("Zip with an empty a = %v", got)
+	}
+
+	// b is pulled: it must be stopped when a runs out too.
+	var a, b source
+	for range Zip(a.seq(2), b.seq(-1)) {
+	}
+	if !a.done || !b.done {
+		t.Errorf("after Zip: a done %v, b done %v, want both stopped", a.done, b.done)
+	}
+}
+
+// TestBreak stops every adapter after its first value and checks that the
+// source was stopped too and was not pulled further than needed.
+func TestBreak(t *testing.T) {
+	id := func(v int) int { return v }
+	all := func(int) bool { return true }
+	for _, tt := range []struct {
+		name   string
+		pulled int
+		seq    func(iter.Seq[int]) iter.Seq[int]
+	}{
+		{"Map", 1, func(s iter.Seq[int]) iter.Seq[int] { return Map(s, id) }},
+		{"Filter", 1, func(s iter.Seq[int]) iter.Seq[int] { return Filter(s, all) }},
+		{"Take", 1, func(s iter.Seq[int]) iter.Seq[int] { return Take(s, 10) }},
+		{"Skip", 3, func(s iter.Seq[int]) iter.Seq[int] { return Skip(s, 2) }},
+		{"Chain", 1, func(s iter.Seq[int]) iter.Seq[int] { return Chain(s, s) }},
+		{"Window", 2, func(s iter.Seq[int]) iter.Seq[int] {
+			return Map(Window(s, 2), func(w []int) int { return w[0] })
+		}},
+		{"Enumerate", 1, func(s iter.Seq[int]) iter.Seq[int] {
+			return func(yield func(int) bool) {
+				for _, v := range Enumerate(s) {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		}},
+		{"Zip", 1, func(s iter.Seq[int]) iter.Seq[int] {
+			return func(yield func(int) bool) {
+				for v := range Zip(s, slices.Values([]int{0, 0, 0})) {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		}},
+		{"FromPull", 1, func(s iter.Seq[int]) iter.Seq[int] {
+			p := NewPuller(s)
+			return func(yield func(int) bool) {
+				defer p.Stop()
+				for v := range FromPull(p.Next) {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		}},
+	} {
+		var src source
+		n := 0
+		for range tt.seq(src.seq(-1)) {
+			n++
+			break
+		}
+		if n != 1 || src.pulled != tt.pulled || !src.done {
+			t.Errorf("%s: %d values, source pulled %d (done %v), want 1 value, %d pulled and a stopped source",
+				tt.name, n, src.pulled, src.done, tt.pulled)
+		}
+	}
+}
+
+func TestFromPull(t *testing.T) {
+	i := 0
+	next := func() (int, bool) {
+		i++
+		return i, i <= 3
+	}
+	if got := Collect(FromPull(next)); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("FromPull = %v, want [1 2 3]", got)
+	}
+	if i != 4 {
+		t.Errorf("next called %d times, want 4: it must stop at the first false", i)
+	}
+}
+
+func TestPuller(t *testing.T) {
+	var src source
+	p := NewPuller(src.seq(3))
+	defer p.Stop()
+	if src.pulled != 0 {
+		t.Errorf("NewPuller pulled %d values, want it lazy", src.pulled)
+	}
+	for range 2 {
+		if v, ok := p.Peek(); v != 0 || !ok {
+			t.Errorf("Peek = %d, %v, want 0, true", v, ok)
+		}
+	}
+	var got []int
+	for v, ok := p.Next(); ok; v, ok = p.Next() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{0, 1, 2}) || src.pulled != 3 {
+		t.Errorf("Next gave %v after pulling %d, want [0 1 2] after 3", got, src.pulled)
+	}
+	if _, ok := p.Peek(); ok {
+		t.Error("Peek past the end reported a value")
+	}
+	if _, ok := p.Next(); ok {
+		t.Error("Next past the end reported a value")
+	}
+}
+
+func TestPullerStop(t *testing.T) {
+	var src source
+	p := NewPuller(src.seq(-1))
+	p.Next()
+	p.Peek()
+	p.Stop()
+	if !src.done || src.pulled != 2 {
+		t.Errorf("after Stop: source done %v after pulling %d, want stopped after 2", src.done, src.pulled)
+	}
+	p.Stop()
+	if v, ok := p.Next(); ok || v != 0 {
+		t.Errorf("Next after Stop = %d, %v, want 0, false", v, ok)
+	}
+}
+
+// ---------------------------------------------------------
+// BENCHMARKS: What Do Iterators Cost?
+// ---------------------------------------------------------
+// go test -bench . -benchmem ./05_loops/itertools
+//
+// Each benchmark computes the same thing with a plain for loop and with
+// an itertools pipeline.
+
+const size = 10_000
+
+var (
+	data = func() []int {
+		s := make([]int, size)
+		for i := range s {
+			s[i] = i
+		}
+		return s
+	}()
+	reversed = func() []int {
+		s := slices.Clone(data)
+		slices.Reverse(s)
+		return s
+	}()
+	sink int
+)
+
+func square(v int) int     { return v * v }
+func isEven(v int) bool    { return v%2 == 0 }
+func plus(acc, v int) int  { return acc + v }
+func pairSum(a, b int) int { return a + b }
+
+func BenchmarkMapFilterSum(b *testing.B) {
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			total := 0
+			for _, v := range data {
+				if sq := square(v); isEven(sq) {
+					total += sq
+				}
+			}
+			sink = total
+		}
+	})
+	b.Run("itertools", func(b *testing.B) {
+		for range b.N {
+			seq := Filter(Map(slices.Values(data), square), isEven)
+			sink = Reduce(seq, 0, plus)
+		}
+	})
+}
+
+func BenchmarkTake100(b *testing.B) {
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			total := 0
+			for i, v := range data {
+				if i == 100 {
+					break
+				}
+				total += v
+			}
+			sink = total
+		}
+	})
+	b.Run("itertools", func(b *testing.B) {
+		for range b.N {
+			sink = Reduce(Take(slices.Values(data), 100), 0, plus)
+		}
+	})
+}
+
+func BenchmarkZip(b *testing.B) {
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			total := 0
+			for i := range data {
+				total += pairSum(data[i], reversed[i])
+			}
+			sink = total
+		}
+	})
+	b.Run("itertools", func(b *testing.B) { // Zip uses iter.Pull
+		for range b.N {
+			total := 0
+			for a, c := range Zip(slices.Values(data), slices.Values(reversed)) {
+				total += pairSum(a, c)
+			}
+			sink = total
+		}
+	})
+}
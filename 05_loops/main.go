@@ -1,6 +1,12 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ViKing-py/lets-go-in-go/05_loops/itertools"
+)
 
 // ---------------------------------------------------------
 // TOPIC: Loops (The 'for' keyword)
@@ -31,6 +37,8 @@ func main() {
 	// Structure: for { ... }
 	// This runs forever until you explicitly 'break' out of it.
 	// Commonly used for servers or listening to channels.
+	// (./countdown is a real example: a for { select { ... } } loop driven by
+	// a ticker, a context and pause/resume commands.)
 	fmt.Println("\n--- 3. Infinite Loop ---")
 	sum := 0
 	for {
@@ -56,6 +64,31 @@ func main() {
 	for _, value := range fruits {
 		fmt.Printf("Item: %s\n", value)
 	}
+
+	// 5. RANGE OVER FUNCTIONS (Go 1.23+ iterators)
+	// Since Go 1.23, range also accepts functions of type iter.Seq / iter.Seq2.
+	// slices.Values(fruits) is such a function: it "pushes" each value into the loop.
+	// The ./itertools package builds pipelines out of them (Map, Filter, Take, ...).
+	// Nothing runs until the final 'range', and 'break' stops the whole pipeline.
+	fmt.Println("\n--- 5. Range Over Func (Iterators) ---")
+
+	// Same as the index/value loop above:
+	for index, value := range itertools.Enumerate(slices.Values(fruits)) {
+		fmt.Printf("Index: %d, Value: %s\n", index, value)
+	}
+
+	// Transform and filter without building temporary slices:
+	upper := itertools.Map(slices.Values(fruits), strings.ToUpper)
+	withA := itertools.Filter(upper, func(s string) bool { return strings.Contains(s, "A") })
+	for value := range withA {
+		fmt.Printf("Contains 'A': %s\n", value)
+	}
+
+	// Walk two sequences side by side:
+	prices := []float64{1.20, 0.50, 3.75}
+	for fruit, price := range itertools.Zip(slices.Values(fruits), slices.Values(prices)) {
+		fmt.Printf("%s costs $%.2f\n", fruit, price)
+	}
 }
 
 // ---------------------------------------------------------
@@ -75,4 +108,10 @@ func main() {
 // 2. Braces are mandatory:
 //    Unlike C or Java, you cannot skip braces for a single-line loop.
 //    WRONG:   for i := 0; i < 3; i++ fmt.Println(i)
-//    CORRECT: for i := 0; i < 3; i++ { fmt.Println(i) }
\ No newline at end of file
+//    CORRECT: for i := 0; i < 3; i++ { fmt.Println(i) }
+//
+// 3. Iterators are lazy:
+//    itertools.Map(...) does NOT run anything by itself. It only describes
+//    the work; the 'for ... range' at the end actually pulls the values.
+//    Run 'go test -bench . -benchmem ./05_loops/itertools' to see what
+//    that costs compared with a plain loop.
\ No newline at end of file
@@ -51,6 +51,8 @@ func main() {
 	// len: How many elements are in the slice right now.
 	// cap: How many elements fit in the underlying array before Go needs to create a new, bigger one.
 	fmt.Printf("Len: %d | Cap: %d\n", len(slice), cap(slice))
+	// To watch len, cap and the backing array change step by step, run:
+	// go run ./06_arrays_and_slices/slicetrace/cmd/slicetrace
 
 	// ==========================================
 	// PART 3: SLICING (Creating a sub-slice)
@@ -81,6 +83,8 @@ func main() {
 
 	// FIX for Pitfall 1:
 	// If you need independent data, use 'copy()' or construct a new slice.
+	// The ./sliceutil package documents, function by function, whether the
+	// result shares memory with the input (./sliceutil/safe never does).
 
 	// PITFALL 2: Append return value
 	// Beginners often write: append(slice, 10)
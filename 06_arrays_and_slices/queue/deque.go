@@ -0,0 +1,180 @@
+package queue
+
+import "iter"
+
+const minDequeCap = 8
+
+// Deque is a growable double-ended queue backed by a circular buffer
+// whose length is always a power of two (so wrapping is a bit mask).
+// It grows by doubling, like append, and shrinks by half when it is
+// a quarter full, so memory is returned after a burst.
+// The zero value is an empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	n    int
+}
+
+// NewDeque returns an empty deque with room for at least capacity
+// elements before it needs to grow.
+func NewDeque[T any](capacity int) *Deque[T] {
+	c := minDequeCap
+	for c < capacity {
+		c <<= 1
+	}
+	return &Deque[T]{buf: make([]T, c)}
+}
+
+// Len returns the number of elements.
+func (d *Deque[T]) Len() int { return d.n }
+
+// Cap returns the current size of the backing buffer.
+func (d *Deque[T]) Cap() int { return len(d.buf) }
+
+func (d *Deque[T]) slot(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// resize moves the elements into a buffer of size c, front at index 0.
+func (d *Deque[T]) resize(c int) {
+	buf := make([]T, c)
+	if d.n > 0 {
+		if d.head+d.n <= len(d.buf) {
+			copy(buf, d.buf[d.head:d.head+d.n])
+		} else {
+			k := copy(buf, d.buf[d.head:])
+			copy(buf[k:], d.buf[:d.n-k])
+		}
+	}
+	d.buf, d.head = buf, 0
+}
+
+func (d *Deque[T]) grow() {
+	if len(d.buf) == 0 {
+		d.buf = make([]T, minDequeCap)
+		return
+	}
+	if d.n == len(d.buf) {
+		d.resize(len(d.buf) * 2)
+	}
+}
+
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minDequeCap && d.n <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// PushBack appends v.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.slot(d.n)] = v
+	d.n++
+}
+
+// PushFront prepends v.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1) & (len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront removes and returns the front element, or false if empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.slot(1)
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+// PopBack removes and returns the back element, or false if empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	i := d.slot(d.n - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+// Front returns the front element without removing it.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back element without removing it.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.slot(d.n-1)], true
+}
+
+// At returns the i-th element from the front. It panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.n {
+		panic("queue: index out of range")
+	}
+	return d.buf[d.slot(i)]
+}
+
+// Set replaces the i-th element from the front.
+func (d *Deque[T]) Set(i int, v T) {
+	if i < 0 || i >= d.n {
+		panic("queue: index out of range")
+	}
+	d.buf[d.slot(i)] = v
+}
+
+// Clear removes all elements and releases the buffer.
+func (d *Deque[T]) Clear() {
+	*d = Deque[T]{}
+}
+
+// All iterates front to back with logical indexes.
+// The deque must not be modified during iteration.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range d.n {
+			if !yield(i, d.buf[d.slot(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Values iterates the elements front to back.
+func (d *Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.n {
+			if !yield(d.buf[d.slot(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Slice copies the elements, front to back, into a new slice.
+func (d *Deque[T]) Slice() []T {
+	out := make([]T, d.n)
+	for i := range d.n {
+		out[i] = d.buf[d.slot(i)]
+	}
+	return out
+}
@@ -0,0 +1,381 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestRingOverwrite(t *testing.T) {
+	r := NewRing[int](3, Overwrite)
+	var evicted []int
+	for v := range 5 {
+		if old, ok, err := r.PushBack(v); err != nil {
+			t.Fatal(err)
+		} else if ok {
+			evicted = append(evicted, old)
+		}
+	}
+	if got := r.Slice(); !slices.Equal(got, []int{2, 3, 4}) || !slices.Equal(evicted, []int{0, 1}) {
+		t.Errorf("after pushing 0..4: %v, evicted %v; want [2 3 4], evicted [0 1]", got, evicted)
+	}
+	// PushFront on a full ring evicts from the other end.
+	if old, ok, _ := r.PushFront(9); !ok || old != 4 {
+		t.Errorf("PushFront evicted %d, %v, want 4, true", old, ok)
+	}
+	if got := r.Slice(); !slices.Equal(got, []int{9, 2, 3}) {
+		t.Errorf("after PushFront: %v, want [9 2 3]", got)
+	}
+	r.Clear()
+	if r.Len() != 0 || r.Cap() != 3 {
+		t.Errorf("after Clear: Len %d, Cap %d", r.Len(), r.Cap())
+	}
+}
+
+func TestRingReject(t *testing.T) {
+	r := NewRing[string](2, Reject)
+	r.PushBack("a")
+	r.PushBack("b")
+	if _, ok, err := r.PushBack("c"); ok || !errors.Is(err, ErrFull) {
+		t.Errorf("PushBack on a full ring = %v, %v, want ErrFull", ok, err)
+	}
+	if _, _, err := r.PushFront("c"); !errors.Is(err, ErrFull) {
+		t.Errorf("PushFront on a full ring = %v, want ErrFull", err)
+	}
+	r.PopFront()
+	if _, _, err := r.PushFront("z"); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Slice(); !slices.Equal(got, []string{"z", "b"}) {
+		t.Errorf("got %v, want [z b]", got)
+	}
+}
+
+func TestRingPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"NewRing(0)": func() { NewRing[int](0, Reject) },
+		"At(-1)":     func() { NewRing[int](1, Reject).At(-1) },
+		"At(Len)":    func() { NewRing[int](1, Reject).At(0) },
+		"Set(Len)":   func() { NewRing[int](1, Reject).Set(0, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestDequeWrap(t *testing.T) {
+	var d Deque[int]
+	for v := range 6 {
+		d.PushBack(v)
+	}
+	for range 4 {
+		d.PopFront()
+	}
+	// head is now at slot 4: pushing 6 more fills the buffer across its end.
+	for v := 6; v < 12; v++ {
+		d.PushBack(v)
+	}
+	if d.Cap() != 8 || !slices.Equal(d.Slice(), []int{4, 5, 6, 7, 8, 9, 10, 11}) {
+		t.Fatalf("wrapped deque: %v with Cap %d, want [4..11] with Cap 8", d.Slice(), d.Cap())
+	}
+	// Growing a wrapped buffer must keep the order.
+	d.PushBack(12)
+	d.PushFront(3)
+	if d.Cap() != 16 || !slices.Equal(d.Slice(), []int{3, 4, 5, 6, 7, 8, 9, 10, 11, 12}) {
+		t.Errorf("grown deque: %v with Cap %d, want [3..12] with Cap 16", d.Slice(), d.Cap())
+	}
+	// Shrinking halves the buffer once it is a quarter full.
+	for d.Len() > 4 {
+		d.PopBack()
+	}
+	if d.Cap() != 8 || !slices.Equal(d.Slice(), []int{3, 4, 5, 6}) {
+		t.Errorf("shrunk deque: %v with Cap %d, want [3 4 5 6] with Cap 8", d.Slice(), d.Cap())
+	}
+	d.Clear()
+	if d.Len() != 0 || d.Cap() != 0 {
+		t.Errorf("after Clear: Len %d, Cap %d, want 0, 0", d.Len(), d.Cap())
+	}
+	if NewDeque[int](9).Cap() != 16 {
+		t.Error("NewDeque(9) did not round up to 16")
+	}
+}
+
+// ---------------------------------------------------------
+// MODEL CHECK: Ring and Deque against a plain slice
+// ---------------------------------------------------------
+
+// container is the API Ring[int] and Deque[int] share.
+type container interface {
+	Len() int
+	At(i int) int
+	Set(i, v int)
+	Front() (int, bool)
+	Back() (int, bool)
+	PopFront() (int, bool)
+	PopBack() (int, bool)
+	Values() iter.Seq[int]
+	All() iter.Seq2[int, int]
+	Slice() []int
+}
+
+// pushFunc pushes v at the front or back, reporting what a Ring reports.
+type pushFunc func(front bool, v int) (old int, evicted bool, err error)
+
+// modelCheck runs random operations on c and on a slice that acts as the
+// reference, comparing them after every step. limit is the capacity of a
+// Ring, or -1 for a Deque. The push rate drifts up and down in blocks, so
+// a Deque both grows and shrinks.
+func modelCheck(t *testing.T, seed uint64, c container, push pushFunc, limit int, policy Policy, check func() error) {
+	t.Helper()
+	rng := rand.New(rand.NewPCG(seed, seed))
+	var model []int
+	for step := range 20_000 {
+		pushRate := 0.65
+		if step/500%2 == 1 {
+			pushRate = 0.35
+		}
+		var op string
+		switch x := rng.Float64(); {
+		case x < pushRate:
+			front := rng.IntN(2) == 0
+			v := step
+			old, evicted, err := push(front, v)
+			op = "PushBack"
+			if front {
+				op = "PushFront"
+			}
+			var wantOld int
+			wantEvicted, wantErr := false, error(nil)
+			if len(model) == limit {
+				if policy == Reject {
+					wantErr = ErrFull
+				} else if wantEvicted = true; front {
+					wantOld, model = model[len(model)-1], model[:len(model)-1]
+				} else {
+					wantOld, model = model[0], model[1:]
+				}
+			}
+			if wantErr == nil {
+				if front {
+					model = slices.Insert(model, 0, v)
+				} else {
+					model = append(model, v)
+				}
+			}
+			if old != wantOld || evicted != wantEvicted || err != wantErr {
+				t.Fatalf("step %d: %s = %d, %v, %v; want %d, %v, %v",
+					step, op, old, evicted, err, wantOld, wantEvicted, wantErr)
+			}
+		case x < pushRate+0.25:
+			front := rng.IntN(2) == 0
+			var v int
+			var ok bool
+			if op = "PopBack"; front {
+				op = "PopFront"
+				v, ok = c.PopFront()
+			} else {
+				v, ok = c.PopBack()
+			}
+			var want int
+			wantOK := len(model) > 0
+			if wantOK && front {
+				want, model = model[0], model[1:]
+			} else if wantOK {
+				want, model = model[len(model)-1], model[:len(model)-1]
+			}
+			if v != want || ok != wantOK {
+				t.Fatalf("step %d: %s = %d, %v; want %d, %v", step, op, v, ok, want, wantOK)
+			}
+		default:
+			op = "Set"
+			if len(model) > 0 {
+				i := rng.IntN(len(model))
+				c.Set(i, -step)
+				model[i] = -step
+			}
+		}
+		if err := compare(c, model); err != nil {
+			t.Fatalf("step %d, after %s: %v", step, op, err)
+		}
+		if check != nil {
+			if err := check(); err != nil {
+				t.Fatalf("step %d, after %s: %v", step, op, err)
+			}
+		}
+	}
+}
+
+// compare checks every accessor of c against the reference slice.
+func compare(c container, model []int) error {
+	if c.Len() != len(model) {
+		return fmt.Errorf("Len = %d, want %d", c.Len(), len(model))
+	}
+	if got := c.Slice(); !slices.Equal(got, model) {
+		return fmt.Errorf("Slice = %v, want %v", got, model)
+	}
+	if got := slices.Collect(c.Values()); !slices.Equal(got, model) {
+		return fmt.Errorf("Values = %v, want %v", got, model)
+	}
+	for i, v := range c.All() {
+		if v != model[i] || c.At(i) != v {
+			return fmt.Errorf("All/At at %d = %d, %d, want %d", i, v, c.At(i), model[i])
+		}
+	}
+	f, fok := c.Front()
+	b, bok := c.Back()
+	if len(model) == 0 {
+		if fok || bok {
+			return errors.New("Front or Back reported a value on an empty container")
+		}
+	} else if f != model[0] || b != model[len(model)-1] || !fok || !bok {
+		return fmt.Errorf("Front, Back = %d, %d, want %d, %d", f, b, model[0], model[len(model)-1])
+	}
+	return nil
+}
+
+func ringPush(r *Ring[int]) pushFunc {
+	return func(front bool, v int) (int, bool, error) {
+		if front {
+			return r.PushFront(v)
+		}
+		return r.PushBack(v)
+	}
+}
+
+func TestRingModel(t *testing.T) {
+	for _, policy := range []Policy{Overwrite, Reject} {
+		for _, capacity := range []int{1, 2, 7, 64} {
+			r := NewRing[int](capacity, policy)
+			modelCheck(t, uint64(capacity), r, ringPush(r), capacity, policy, nil)
+		}
+	}
+}
+
+func TestDequeModel(t *testing.T) {
+	var d Deque[int]
+	push := func(front bool, v int) (int, bool, error) {
+		if front {
+			d.PushFront(v)
+		} else {
+			d.PushBack(v)
+		}
+		return 0, false, nil
+	}
+	maxCap := 0
+	// The buffer is a power of two of at least minDequeCap, and after a
+	// pop it is more than a quarter full unless it is already minimal.
+	check := func() error {
+		c := d.Cap()
+		maxCap = max(maxCap, c)
+		if c == 0 {
+			return nil
+		}
+		if c < minDequeCap || c&(c-1) != 0 || d.Len() > c || c > minDequeCap && d.Len() <= c/4 {
+			return fmt.Errorf("Len %d with Cap %d", d.Len(), c)
+		}
+		return nil
+	}
+	modelCheck(t, 1, &d, push, -1, Overwrite, check)
+	if maxCap < 64 {
+		t.Errorf("the deque only grew to %d: the check did not exercise growth", maxCap)
+	}
+}
+
+// ---------------------------------------------------------
+// BENCHMARKS: Ring / Deque vs the s = s[1:] Queue
+// ---------------------------------------------------------
+// go test -bench . -benchmem ./06_arrays_and_slices/queue
+//
+// Popping with s = s[1:] is O(1), but the popped elements stay in the
+// backing array until append happens to reallocate it, and every
+// reallocation copies the whole live window. A circular buffer reuses
+// the same slots forever.
+
+const (
+	window = 1_000  // elements kept in the queue
+	ops    = 10_000 // push+pop pairs per benchmark iteration
+)
+
+var sink int
+
+func BenchmarkFIFO(b *testing.B) {
+	b.Run("slice", func(b *testing.B) { // s = s[1:]
+		b.ReportAllocs()
+		for range b.N {
+			var s []int
+			for i := range window {
+				s = append(s, i)
+			}
+			for i := range ops {
+				s = append(s, i)
+				sink += s[0]
+				s = s[1:]
+			}
+		}
+	})
+	b.Run("Deque", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			var d Deque[int]
+			for i := range window {
+				d.PushBack(i)
+			}
+			for i := range ops {
+				d.PushBack(i)
+				v, _ := d.PopFront()
+				sink += v
+			}
+		}
+	})
+	b.Run("Ring", func(b *testing.B) { // Reject
+		b.ReportAllocs()
+		for range b.N {
+			r := NewRing[int](window+1, Reject)
+			for i := range window {
+				r.PushBack(i)
+			}
+			for i := range ops {
+				r.PushBack(i)
+				v, _ := r.PopFront()
+				sink += v
+			}
+		}
+	})
+}
+
+// BenchmarkTail keeps the last window values of a stream.
+func BenchmarkTail(b *testing.B) {
+	b.Run("slice", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			var s []int
+			for i := range ops {
+				s = append(s, i)
+				if len(s) > window {
+					s = s[1:]
+				}
+			}
+			sink += s[0]
+		}
+	})
+	b.Run("Ring", func(b *testing.B) { // Overwrite
+		b.ReportAllocs()
+		for range b.N {
+			r := NewRing[int](window, Overwrite)
+			for i := range ops {
+				r.PushBack(i)
+			}
+			v, _ := r.Front()
+			sink += v
+		}
+	})
+}
@@ -0,0 +1,201 @@
+// Package queue has two circular-buffer containers that sit between the
+// fixed arrays and growable slices of 06_arrays_and_slices:
+//
+//   - Ring[T] has a fixed capacity, like an array. When full it either
+//     overwrites the oldest element or rejects the new one.
+//   - Deque[T] grows like a slice, but pops from the front without the
+//     s = s[1:] trick, which keeps popped elements in the backing array
+//     until the next reallocation.
+//
+// Both support push and pop at both ends, indexing and iteration.
+// Neither is safe for concurrent use.
+package queue
+
+import (
+	"errors"
+	"iter"
+)
+
+// ErrFull is returned by a Reject-policy Ring that has no free slot.
+var ErrFull = errors.New("queue: ring is full")
+
+// Policy decides what a full Ring does with a new element.
+type Policy int
+
+const (
+	// Overwrite drops the element at the opposite end to make room, so a
+	// Ring keeps the most recent N pushes (what a log tailer wants).
+	Overwrite Policy = iota
+	// Reject refuses the push with ErrFull.
+	Reject
+)
+
+// Ring is a fixed-capacity circular buffer. The zero value is not usable;
+// create one with NewRing.
+type Ring[T any] struct {
+	buf    []T
+	head   int // index in buf of the front element
+	n      int // number of elements
+	policy Policy
+}
+
+// NewRing returns an empty ring holding at most capacity elements.
+// It panics if capacity < 1.
+func NewRing[T any](capacity int, policy Policy) *Ring[T] {
+	if capacity < 1 {
+		panic("queue: ring capacity must be >= 1")
+	}
+	return &Ring[T]{buf: make([]T, capacity), policy: policy}
+}
+
+// Len returns the number of elements.
+func (r *Ring[T]) Len() int { return r.n }
+
+// Cap returns the fixed capacity.
+func (r *Ring[T]) Cap() int { return len(r.buf) }
+
+// Full reports whether Len() == Cap().
+func (r *Ring[T]) Full() bool { return r.n == len(r.buf) }
+
+// slot maps a logical index (0 = front) to an index in buf.
+// A compare-and-subtract is cheaper than %, and i < len(buf) always holds.
+func (r *Ring[T]) slot(i int) int {
+	j := r.head + i
+	if j >= len(r.buf) {
+		j -= len(r.buf)
+	}
+	return j
+}
+
+// PushBack appends v at the back. On a full ring, Overwrite evicts and
+// returns the front element (evicted == true); Reject returns ErrFull.
+func (r *Ring[T]) PushBack(v T) (old T, evicted bool, err error) {
+	if r.Full() {
+		if r.policy == Reject {
+			return old, false, ErrFull
+		}
+		old, _ = r.PopFront()
+		evicted = true
+	}
+	r.buf[r.slot(r.n)] = v
+	r.n++
+	return old, evicted, nil
+}
+
+// PushFront prepends v. On a full ring, Overwrite evicts and returns the
+// back element; Reject returns ErrFull.
+func (r *Ring[T]) PushFront(v T) (old T, evicted bool, err error) {
+	if r.Full() {
+		if r.policy == Reject {
+			return old, false, ErrFull
+		}
+		old, _ = r.PopBack()
+		evicted = true
+	}
+	r.head--
+	if r.head < 0 {
+		r.head = len(r.buf) - 1
+	}
+	r.buf[r.head] = v
+	r.n++
+	return old, evicted, nil
+}
+
+// PopFront removes and returns the front element, or false if empty.
+func (r *Ring[T]) PopFront() (T, bool) {
+	var zero T
+	if r.n == 0 {
+		return zero, false
+	}
+	v := r.buf[r.head]
+	r.buf[r.head] = zero // don't keep pointers alive
+	r.head = r.slot(1)
+	r.n--
+	return v, true
+}
+
+// PopBack removes and returns the back element, or false if empty.
+func (r *Ring[T]) PopBack() (T, bool) {
+	var zero T
+	if r.n == 0 {
+		return zero, false
+	}
+	i := r.slot(r.n - 1)
+	v := r.buf[i]
+	r.buf[i] = zero
+	r.n--
+	return v, true
+}
+
+// Front returns the front element without removing it.
+func (r *Ring[T]) Front() (T, bool) {
+	if r.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.buf[r.head], true
+}
+
+// Back returns the back element without removing it.
+func (r *Ring[T]) Back() (T, bool) {
+	if r.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.buf[r.slot(r.n-1)], true
+}
+
+// At returns the i-th element from the front. It panics if i is out of
+// range, like indexing a slice.
+func (r *Ring[T]) At(i int) T {
+	if i < 0 || i >= r.n {
+		panic("queue: index out of range")
+	}
+	return r.buf[r.slot(i)]
+}
+
+// Set replaces the i-th element from the front.
+func (r *Ring[T]) Set(i int, v T) {
+	if i < 0 || i >= r.n {
+		panic("queue: index out of range")
+	}
+	r.buf[r.slot(i)] = v
+}
+
+// Clear removes all elements, keeping the capacity.
+func (r *Ring[T]) Clear() {
+	clear(r.buf)
+	r.head, r.n = 0, 0
+}
+
+// All iterates front to back with logical indexes, like range over a slice.
+// The ring must not be modified during iteration.
+func (r *Ring[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range r.n {
+			if !yield(i, r.buf[r.slot(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Values iterates the elements front to back.
+func (r *Ring[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range r.n {
+			if !yield(r.buf[r.slot(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Slice copies the elements, front to back, into a new slice.
+func (r *Ring[T]) Slice() []T {
+	out := make([]T, r.n)
+	for i := range r.n {
+		out[i] = r.buf[r.slot(i)]
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ViKing-py/lets-go-in-go/06_arrays_and_slices/slicetrace"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Seeing the Backing Array
+// ---------------------------------------------------------
+// Usage:
+//   go run ./06_arrays_and_slices/slicetrace/cmd/slicetrace            (built-in demo)
+//   go run ./06_arrays_and_slices/slicetrace/cmd/slicetrace script.txt
+//
+// A script has one []int operation per line ('#' starts a comment):
+//
+//   lit     a 1 2 3          a = []int{1, 2, 3}
+//   make    a 3 8            a = make([]int, 3, 8)
+//   append  b a 4 5          b = append(a, 4, 5)
+//   slice   c a 1 3          c = a[1:3]
+//   slice   c a 1 3 3        c = a[1:3:3]
+//   copy    c b              copy(c, b)
+//   set     a 0 99           a[0] = 99
+
+// demo replays the lesson: append growth, then the sub-slice pitfall.
+const demo = `
+lit    slice 10 20 30
+append slice slice 40          # cap 3 is full: new array, cap doubles
+append slice slice 50          # fits: same array
+lit    numbers 0 1 2 3 4 5
+slice  sub numbers 1 4         # sub shares numbers' array
+set    sub 0 999               # ...so numbers[1] changes too
+append sub sub 7               # still fits: overwrites numbers[4]!
+slice  safe numbers 1 4 4      # full slice expression caps it
+append safe safe 8             # cap exhausted: safe moves away
+`
+
+func main() {
+	flag.Parse()
+
+	var src io.Reader = strings.NewReader(demo)
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		src = f
+	}
+
+	tr := slicetrace.New[int]()
+	sc := bufio.NewScanner(src)
+	for line := 1; sc.Scan(); line++ {
+		text, _, _ := strings.Cut(sc.Text(), "#")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if err := apply(tr, fields); err != nil {
+			fail(fmt.Errorf("line %d: %w", line, err))
+		}
+	}
+	if err := sc.Err(); err != nil {
+		fail(err)
+	}
+	fmt.Print(tr.Report())
+}
+
+func apply(tr *slicetrace.Tracer[int], f []string) error {
+	var nums []int
+	var err error
+	switch f[0] {
+	case "lit":
+		if len(f) < 2 {
+			return fmt.Errorf("usage: lit NAME [VALUES...]")
+		}
+		if nums, err = ints(f[2:]); err != nil {
+			return err
+		}
+		tr.Literal(f[1], nums...)
+		return nil
+	case "make":
+		if len(f) != 4 {
+			return fmt.Errorf("usage: make NAME LEN CAP")
+		}
+		if nums, err = ints(f[2:]); err != nil {
+			return err
+		}
+		return tr.Make(f[1], nums[0], nums[1])
+	case "append":
+		if len(f) < 3 {
+			return fmt.Errorf("usage: append DST SRC [VALUES...]")
+		}
+		if nums, err = ints(f[3:]); err != nil {
+			return err
+		}
+		return tr.Append(f[1], f[2], nums...)
+	case "slice":
+		if len(f) != 5 && len(f) != 6 {
+			return fmt.Errorf("usage: slice DST SRC LO HI [MAX]")
+		}
+		if nums, err = ints(f[3:]); err != nil {
+			return err
+		}
+		limit := -1
+		if len(nums) == 3 {
+			limit = nums[2]
+		}
+		return tr.Reslice(f[1], f[2], nums[0], nums[1], limit)
+	case "copy":
+		if len(f) != 3 {
+			return fmt.Errorf("usage: copy DST SRC")
+		}
+		return tr.Copy(f[1], f[2])
+	case "set":
+		if len(f) != 4 {
+			return fmt.Errorf("usage: set NAME INDEX VALUE")
+		}
+		if nums, err = ints(f[2:]); err != nil {
+			return err
+		}
+		return tr.Set(f[1], nums[0], nums[1])
+	}
+	return fmt.Errorf("unknown operation %q", f[0])
+}
+
+func ints(fields []string) ([]int, error) {
+	out := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,457 @@
+// Package slicetrace makes the len/cap discussion from 06_arrays_and_slices
+// concrete. A Tracer applies slice operations (make, append, reslice,
+// copy) to named slices and records, after every step, each slice's len,
+// cap and backing-array address, plus whether an append reallocated and
+// by what factor the capacity grew.
+//
+// Render draws the backing arrays and the slices viewing them:
+//
+//	array A  cap=4  @0xc000012345
+//	          0    1    2    3
+//	         [ 1 ][ 2 ][ 3 ][ 0 ]
+//	  a      |====|====|====|----|   len=3 cap=4
+//	  b           |====|----|----|   len=1 cap=3
+//
+// "=" cells are inside len, "-" cells are reachable capacity.
+//
+// Tracer can also be fed slices from real code with Observe, which is how
+// it helps debug aliasing bugs outside the lesson.
+package slicetrace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+// ---------------------------------------------------------
+// 1. RECORDED DATA
+// ---------------------------------------------------------
+
+// View is the state of one named slice at one step.
+type View struct {
+	Name  string
+	Len   int
+	Cap   int
+	Addr  uintptr // address of element 0 (unsafe.SliceData)
+	Array string  // label of the backing array ("A", "B", ...), "" if cap == 0
+}
+
+// Realloc describes an append that moved a slice to a new array.
+type Realloc struct {
+	OldCap, NewCap int
+	Factor         float64 // NewCap / OldCap (0 when OldCap was 0)
+}
+
+// Step is one operation and the state of every slice after it.
+type Step struct {
+	Op      string   // human-readable operation, e.g. "b = append(a, 4)"
+	Realloc *Realloc // set when this step was an append that reallocated
+	Views   []View   // every known slice, sorted by name
+	Diagram string   // Render output after the step
+}
+
+// ---------------------------------------------------------
+// 2. TRACER
+// ---------------------------------------------------------
+
+// array is a backing array seen so far. base is a real pointer (not a
+// uintptr) so the array stays alive, and readable, while it is tracked.
+type array struct {
+	label string
+	base  unsafe.Pointer
+	cap   int
+}
+
+// Tracer owns a set of named slices of T.
+type Tracer[T any] struct {
+	slices map[string][]T
+	arrays []array
+	labels int // arrays labelled so far
+	steps  []Step
+}
+
+// New returns an empty Tracer.
+func New[T any]() *Tracer[T] {
+	return &Tracer[T]{slices: make(map[string][]T)}
+}
+
+// Steps returns everything recorded so far.
+func (t *Tracer[T]) Steps() []Step { return t.steps }
+
+// Slice returns the current value of a named slice.
+func (t *Tracer[T]) Slice(name string) []T { return t.slices[name] }
+
+func (t *Tracer[T]) get(name string) ([]T, error) {
+	s, ok := t.slices[name]
+	if !ok {
+		return nil, fmt.Errorf("slicetrace: unknown slice %q", name)
+	}
+	return s, nil
+}
+
+// Make performs name = make([]T, length, capacity).
+func (t *Tracer[T]) Make(name string, length, capacity int) error {
+	if length < 0 || capacity < length {
+		return fmt.Errorf("slicetrace: make: invalid len %d / cap %d", length, capacity)
+	}
+	t.slices[name] = make([]T, length, capacity)
+	t.record(fmt.Sprintf("%s = make([]T, %d, %d)", name, length, capacity), nil)
+	return nil
+}
+
+// Literal performs name = []T{vals...}.
+func (t *Tracer[T]) Literal(name string, vals ...T) {
+	t.slices[name] = append([]T(nil), vals...)
+	t.record(fmt.Sprintf("%s = %v", name, vals), nil)
+}
+
+// Append performs dst = append(src, vals...) and records a reallocation
+// when the result lives in a different array than src.
+func (t *Tracer[T]) Append(dst, src string, vals ...T) error {
+	s, err := t.get(src)
+	if err != nil {
+		return err
+	}
+	oldCap := cap(s)
+	oldAddr := dataAddr(s)
+	out := append(s, vals...)
+	t.slices[dst] = out
+
+	var re *Realloc
+	if dataAddr(out) != oldAddr || (oldCap == 0 && cap(out) > 0) {
+		re = &Realloc{OldCap: oldCap, NewCap: cap(out)}
+		if oldCap > 0 {
+			re.Factor = float64(cap(out)) / float64(oldCap)
+		}
+	}
+	t.record(fmt.Sprintf("%s = append(%s, %s)", dst, src, joinVals(vals)), re)
+	return nil
+}
+
+// Reslice performs dst = src[lo:hi], or dst = src[lo:hi:max] when max >= 0.
+func (t *Tracer[T]) Reslice(dst, src string, lo, hi, max int) error {
+	s, err := t.get(src)
+	if err != nil {
+		return err
+	}
+	limit := cap(s)
+	if max >= 0 {
+		limit = max
+	}
+	if lo < 0 || hi < lo || limit < hi || limit > cap(s) {
+		return fmt.Errorf("slicetrace: %s[%d:%d:%d] out of range (cap %d)", src, lo, hi, limit, cap(s))
+	}
+	t.slices[dst] = s[lo:hi:limit]
+	op := fmt.Sprintf("%s = %s[%d:%d]", dst, src, lo, hi)
+	if max >= 0 {
+		op = fmt.Sprintf("%s = %s[%d:%d:%d]", dst, src, lo, hi, max)
+	}
+	t.record(op, nil)
+	return nil
+}
+
+// Copy performs copy(dst, src).
+func (t *Tracer[T]) Copy(dst, src string) error {
+	d, err := t.get(dst)
+	if err != nil {
+		return err
+	}
+	s, err := t.get(src)
+	if err != nil {
+		return err
+	}
+	n := copy(d, s)
+	t.record(fmt.Sprintf("copy(%s, %s) // %d copied", dst, src, n), nil)
+	return nil
+}
+
+// Set performs name[i] = v, which is how sharing becomes visible.
+func (t *Tracer[T]) Set(name string, i int, v T) error {
+	s, err := t.get(name)
+	if err != nil {
+		return err
+	}
+	if i < 0 || i >= len(s) {
+		return fmt.Errorf("slicetrace: %s[%d] out of range (len %d)", name, i, len(s))
+	}
+	s[i] = v
+	t.record(fmt.Sprintf("%s[%d] = %v", name, i, v), nil)
+	return nil
+}
+
+// Observe records an externally produced slice under name. Use it to
+// snapshot slices from real code at interesting points:
+//
+//	tr.Observe("batch", batch)
+//	tr.Observe("rest", rest)
+//	fmt.Print(tr.Render())
+//
+// A new value for name is reported as a reallocation only when it lies
+// outside the array the old value was in, so q = q[2:] is not one.
+func (t *Tracer[T]) Observe(name string, s []T) {
+	old, had := t.slices[name]
+	t.slices[name] = s
+	var re *Realloc
+	if had && cap(old) > 0 && cap(s) > 0 && !t.sameArray(old, s) {
+		re = &Realloc{OldCap: cap(old), NewCap: cap(s), Factor: float64(cap(s)) / float64(cap(old))}
+	}
+	t.record("observe "+name, re)
+}
+
+// ---------------------------------------------------------
+// 3. BOOKKEEPING
+// ---------------------------------------------------------
+
+func dataAddr[T any](s []T) uintptr {
+	if cap(s) == 0 {
+		return 0
+	}
+	return uintptr(unsafe.Pointer(unsafe.SliceData(s)))
+}
+
+func elemSize[T any]() uintptr {
+	var zero T
+	return unsafe.Sizeof(zero)
+}
+
+// span returns the address range [lo, hi) of s's capacity.
+func span[T any](s []T) (lo, hi uintptr) {
+	lo = dataAddr(s)
+	return lo, lo + uintptr(cap(s))*elemSize[T]()
+}
+
+func (a *array) span(size uintptr) (lo, hi uintptr) {
+	lo = uintptr(a.base)
+	return lo, lo + uintptr(a.cap)*size
+}
+
+// sameArray reports whether new lies in the array old was recorded in.
+// Zero-size elements all share one address, so for them only an equal
+// data pointer counts.
+func (t *Tracer[T]) sameArray(old, new []T) bool {
+	size := elemSize[T]()
+	if size == 0 {
+		return dataAddr(old) == dataAddr(new)
+	}
+	lo, hi := span(old)
+	for i := range t.arrays {
+		if alo, ahi := t.arrays[i].span(size); lo < ahi && alo < hi {
+			lo, hi = alo, ahi
+			break
+		}
+	}
+	nlo, nhi := span(new)
+	return nlo < hi && lo < nhi
+}
+
+// arrayFor returns the array containing s, registering a new array if s
+// does not overlap a known one. Slices seen through Observe can reveal
+// more of an array than was known, before its base or past its end; the
+// array then grows to cover them, absorbing any other known array that
+// turns out to be part of the same storage.
+func (t *Tracer[T]) arrayFor(s []T) *array {
+	size := elemSize[T]()
+	if cap(s) == 0 || size == 0 {
+		return nil
+	}
+	lo, hi := span(s)
+	base := unsafe.Pointer(unsafe.SliceData(s))
+	found := -1
+	for i := 0; i < len(t.arrays); {
+		alo, ahi := t.arrays[i].span(size)
+		if alo >= hi || lo >= ahi {
+			i++
+			continue
+		}
+		if alo < lo {
+			lo, base = alo, t.arrays[i].base
+		}
+		hi = max(hi, ahi)
+		if found < 0 {
+			found = i
+			i++
+			continue
+		}
+		// A second overlapping array: fold it into the first.
+		t.arrays = append(t.arrays[:i], t.arrays[i+1:]...)
+	}
+	if found < 0 {
+		t.arrays = append(t.arrays, array{label: arrayLabel(t.labels)})
+		t.labels++
+		found = len(t.arrays) - 1
+	}
+	a := &t.arrays[found]
+	a.base = base
+	a.cap = int((hi - lo) / size)
+	return a
+}
+
+// arrayLabel turns 0, 1, ... 25, 26 into A, B, ... Z, AA.
+func arrayLabel(i int) string {
+	label := ""
+	for {
+		label = string(rune('A'+i%26)) + label
+		i = i/26 - 1
+		if i < 0 {
+			return label
+		}
+	}
+}
+
+func (t *Tracer[T]) sortedNames() []string {
+	names := make([]string, 0, len(t.slices))
+	for n := range t.slices {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (t *Tracer[T]) record(op string, re *Realloc) {
+	step := Step{Op: op, Realloc: re}
+	used := make(map[string]bool)
+	for _, name := range t.sortedNames() {
+		s := t.slices[name]
+		v := View{Name: name, Len: len(s), Cap: cap(s), Addr: dataAddr(s)}
+		if a := t.arrayFor(s); a != nil {
+			v.Array = a.label
+			used[a.label] = true
+		}
+		step.Views = append(step.Views, v)
+	}
+	// Forget arrays no slice refers to any more: they are garbage now.
+	live := t.arrays[:0]
+	for _, a := range t.arrays {
+		if used[a.label] {
+			live = append(live, a)
+		}
+	}
+	t.arrays = live
+	step.Diagram = t.Render()
+	t.steps = append(t.steps, step)
+}
+
+// ---------------------------------------------------------
+// 4. RENDERING
+// ---------------------------------------------------------
+
+// Render draws every backing array that is still referenced by at least
+// one named slice, with one row per slice that views it.
+func (t *Tracer[T]) Render() string {
+	var b strings.Builder
+	size := elemSize[T]()
+	names := t.sortedNames()
+	nameWidth := 6
+	for _, name := range names {
+		nameWidth = max(nameWidth, len(name))
+	}
+	indent := strings.Repeat(" ", nameWidth+3)
+
+	var empty []string
+	for _, name := range names {
+		if cap(t.slices[name]) == 0 {
+			empty = append(empty, name)
+		}
+	}
+
+	for _, a := range t.arrays {
+		base := uintptr(a.base)
+		var viewers []string
+		for _, name := range names {
+			if s := t.slices[name]; cap(s) > 0 {
+				if addr := dataAddr(s); addr >= base && addr < base+uintptr(a.cap)*size {
+					viewers = append(viewers, name)
+				}
+			}
+		}
+		if len(viewers) == 0 {
+			continue
+		}
+
+		// All cells of the array, read through a full-capacity view.
+		all := unsafe.Slice((*T)(a.base), a.cap)
+		cells := make([]string, a.cap)
+		width := 1
+		for i, v := range all {
+			cells[i] = fmt.Sprint(v)
+			width = max(width, len(cells[i]))
+		}
+		cell := width + 2 // "[ " value " ]" minus the brackets
+
+		fmt.Fprintf(&b, "array %s  cap=%d  @%#x\n", a.label, a.cap, base)
+		b.WriteString(indent)
+		for i := range cells {
+			fmt.Fprintf(&b, " %-*d", cell+1, i)
+		}
+		b.WriteString("\n" + indent)
+		for _, c := range cells {
+			fmt.Fprintf(&b, "[%*s ]", cell-1, c)
+		}
+		b.WriteString("\n")
+
+		for _, name := range viewers {
+			s := t.slices[name]
+			off := int((dataAddr(s) - base) / size)
+			fmt.Fprintf(&b, "  %-*s ", nameWidth, name)
+			b.WriteString(strings.Repeat(" ", off*(cell+2)))
+			for i := range cap(s) {
+				mark := "-"
+				if i < len(s) {
+					mark = "="
+				}
+				b.WriteString("|" + strings.Repeat(mark, cell+1))
+			}
+			fmt.Fprintf(&b, "|   len=%d cap=%d\n", len(s), cap(s))
+		}
+		b.WriteString("\n")
+	}
+	for _, name := range empty {
+		s := t.slices[name]
+		state := "empty"
+		if s == nil {
+			state = "nil"
+		}
+		fmt.Fprintf(&b, "  %-*s %s (no backing array)\n", nameWidth, name, state)
+	}
+	return b.String()
+}
+
+// Report formats every recorded step: the operation, a reallocation note
+// if any, a len/cap/address table and the diagram.
+func (t *Tracer[T]) Report() string {
+	var b strings.Builder
+	for i, st := range t.steps {
+		fmt.Fprintf(&b, "=== step %d: %s ===\n", i+1, st.Op)
+		if re := st.Realloc; re != nil {
+			if re.OldCap == 0 {
+				fmt.Fprintf(&b, "  ! realloc: new array with cap %d\n", re.NewCap)
+			} else {
+				fmt.Fprintf(&b, "  ! realloc: cap %d -> %d (x%.2f)\n", re.OldCap, re.NewCap, re.Factor)
+			}
+		}
+		nameWidth := 6
+		for _, v := range st.Views {
+			nameWidth = max(nameWidth, len(v.Name))
+		}
+		for _, v := range st.Views {
+			arr := v.Array
+			if arr == "" {
+				arr = "-"
+			}
+			fmt.Fprintf(&b, "  %-*s len=%-3d cap=%-3d array=%-2s @%#x\n", nameWidth, v.Name, v.Len, v.Cap, arr, v.Addr)
+		}
+		b.WriteString("\n")
+		b.WriteString(st.Diagram)
+	}
+	return b.String()
+}
+
+func joinVals[T any](vals []T) string {
+	parts := make([]string, len(vals))
+	for i, v := range vals {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, ", ")
+}
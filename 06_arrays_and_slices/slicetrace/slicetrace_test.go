@@ -0,0 +1,153 @@
+package slicetrace
+
+import (
+	"strings"
+	"testing"
+)
+
+// arrays returns the array label of every view in the last step, by name.
+func arrays[T any](tr *Tracer[T]) map[string]string {
+	steps := tr.Steps()
+	got := make(map[string]string)
+	for _, v := range steps[len(steps)-1].Views {
+		got[v.Name] = v.Array
+	}
+	return got
+}
+
+func lastRealloc[T any](tr *Tracer[T]) *Realloc {
+	steps := tr.Steps()
+	return steps[len(steps)-1].Realloc
+}
+
+func TestAppendGrowth(t *testing.T) {
+	tr := New[int]()
+	tr.Literal("a", 1, 2, 3)
+	if err := tr.Append("a", "a", 4); err != nil {
+		t.Fatal(err)
+	}
+	re := lastRealloc(tr)
+	if re == nil {
+		t.Fatal("append past cap 3 was not reported as a realloc")
+	}
+	if newCap := cap(tr.Slice("a")); re.OldCap != 3 || re.NewCap != newCap || re.Factor != float64(newCap)/3 {
+		t.Errorf("Realloc = %+v, want OldCap 3, NewCap %d", *re, newCap)
+	}
+	if got := arrays(tr)["a"]; got != "B" {
+		t.Errorf("a is in array %q after growing, want B", got)
+	}
+
+	tr.Append("a", "a", 5)
+	if re := lastRealloc(tr); re != nil {
+		t.Errorf("append within cap reported %+v", *re)
+	}
+
+	if err := tr.Append("n", "nothing", 1); err == nil {
+		t.Error("Append from an unknown slice succeeded")
+	}
+	var nilSlice []int
+	tr.Observe("z", nilSlice)
+	tr.Append("z", "z", 7)
+	if re := lastRealloc(tr); re == nil || re.OldCap != 0 || re.Factor != 0 {
+		t.Errorf("append to nil slice Realloc = %+v, want OldCap 0 and Factor 0", re)
+	}
+}
+
+func TestSharing(t *testing.T) {
+	tr := New[int]()
+	tr.Make("a", 3, 4)
+	if err := tr.Reslice("b", "a", 1, 2, -1); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Set("b", 0, 7); err != nil {
+		t.Fatal(err)
+	}
+	if got := tr.Slice("a")[1]; got != 7 {
+		t.Errorf("a[1] = %d after b[0] = 7, want 7", got)
+	}
+	if got := arrays(tr); got["a"] != "A" || got["b"] != "A" {
+		t.Errorf("arrays = %v, want a and b both in A", got)
+	}
+	out := tr.Render()
+	for _, line := range []string{
+		"         [ 0 ][ 7 ][ 0 ][ 0 ]",
+		"  a      |====|====|====|----|   len=3 cap=4",
+		"  b           |====|----|----|   len=1 cap=3",
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("Render is missing %q:\n%s", line, out)
+		}
+	}
+
+	for _, bad := range [][3]int{{-1, 1, -1}, {2, 1, -1}, {0, 5, -1}, {0, 1, 5}} {
+		if err := tr.Reslice("c", "a", bad[0], bad[1], bad[2]); err == nil {
+			t.Errorf("Reslice(%v) succeeded", bad)
+		}
+	}
+	if err := tr.Set("b", 1, 0); err == nil {
+		t.Error("Set past len succeeded")
+	}
+	if err := tr.Make("c", 2, 1); err == nil {
+		t.Error("Make with cap < len succeeded")
+	}
+}
+
+func TestObserveReslice(t *testing.T) {
+	tr := New[int]()
+	q := make([]int, 8)
+	tr.Observe("q", q)
+	q = q[2:]
+	tr.Observe("q", q)
+	if re := lastRealloc(tr); re != nil {
+		t.Errorf("q = q[2:] reported as %+v", *re)
+	}
+	if got := arrays(tr)["q"]; got != "A" {
+		t.Errorf("q[2:] is in array %q, want A", got)
+	}
+
+	q = append(q, 1) // cap 6 is full
+	tr.Observe("q", q)
+	re := lastRealloc(tr)
+	if re == nil || re.OldCap != 6 || re.NewCap != cap(q) {
+		t.Errorf("growing append Realloc = %+v, want OldCap 6, NewCap %d", re, cap(q))
+	}
+
+	tr.Observe("q", q[len(q):len(q)]) // same array, but at its very end
+	if re := lastRealloc(tr); re != nil {
+		t.Errorf("q[len:len] reported as %+v", *re)
+	}
+}
+
+func TestObserveBeforeBase(t *testing.T) {
+	tr := New[int]()
+	q := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	tr.Observe("tail", q[3:])
+	tr.Observe("q", q)
+	if got := arrays(tr); got["q"] != "A" || got["tail"] != "A" {
+		t.Errorf("arrays = %v, want q and tail both in A", got)
+	}
+	out := tr.Render()
+	if !strings.Contains(out, "array A  cap=8 ") || strings.Contains(out, "array B") {
+		t.Errorf("Render should show one array of cap 8:\n%s", out)
+	}
+	if !strings.Contains(out, "  tail                  |====|====|====|====|====|   len=5 cap=5\n") {
+		t.Errorf("Render does not place tail at offset 3:\n%s", out)
+	}
+}
+
+func TestObserveMerges(t *testing.T) {
+	tr := New[int]()
+	q := make([]int, 8)
+	tr.Observe("head", q[0:1:2])
+	tr.Observe("tail", q[4:])
+	if got := arrays(tr); got["head"] != "A" || got["tail"] != "B" {
+		t.Fatalf("arrays = %v, want head in A and tail in B before q is seen", got)
+	}
+	tr.Observe("q", q)
+	if got := arrays(tr); got["head"] != "A" || got["tail"] != "A" || got["q"] != "A" {
+		t.Errorf("arrays = %v, want everything in A once q links them", got)
+	}
+	if out := tr.Render(); strings.Count(out, "array ") != 1 {
+		t.Errorf("Render shows more than one array:\n%s", out)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ViKing-py/lets-go-in-go/06_arrays_and_slices/sliceutil"
+	"github.com/ViKing-py/lets-go-in-go/06_arrays_and_slices/sliceutil/safe"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Who Shares Memory With Whom?
+// ---------------------------------------------------------
+// Usage:
+//   go run ./06_arrays_and_slices/sliceutil/cmd/aliasing
+//
+// For every helper, run the in-place (sliceutil) and the safe variant on
+// a fresh input, then write 999 into the result and look at the input.
+// "shares" is sliceutil.Overlaps; "input after write" shows the effect.
+
+func input() []int {
+	s := make([]int, 6, 10) // spare capacity, so Insert can work in place
+	for i := range s {
+		s[i] = i + 1
+	}
+	return s
+}
+
+type check struct {
+	name string
+	run  func(in []int) []int // returns one result slice to poke at
+}
+
+func main() {
+	isEven := func(v int) bool { return v%2 == 0 }
+	unsafeChecks := []check{
+		{"Insert", func(in []int) []int { return sliceutil.Insert(in, 2, 70, 80) }},
+		{"Delete", func(in []int) []int { return sliceutil.Delete(in, 1, 3) }},
+		{"Reverse", func(in []int) []int { return sliceutil.Reverse(in) }},
+		{"Dedup", func(in []int) []int { return sliceutil.Dedup(in) }},
+		{"Chunk[1]", func(in []int) []int { return sliceutil.Chunk(in, 4)[1] }},
+		{"Window[2]", func(in []int) []int { return sliceutil.Window(in, 3)[2] }},
+		{"Partition.yes", func(in []int) []int { y, _ := sliceutil.Partition(in, isEven); return y }},
+		{"Flatten", func(in []int) []int { return sliceutil.Flatten([][]int{in}) }},
+	}
+	safeChecks := []check{
+		{"Insert", func(in []int) []int { return safe.Insert(in, 2, 70, 80) }},
+		{"Delete", func(in []int) []int { return safe.Delete(in, 1, 3) }},
+		{"Reverse", func(in []int) []int { return safe.Reverse(in) }},
+		{"Dedup", func(in []int) []int { return safe.Dedup(in) }},
+		{"Chunk[1]", func(in []int) []int { return safe.Chunk(in, 4)[1] }},
+		{"Window[2]", func(in []int) []int { return safe.Window(in, 3)[2] }},
+		{"Partition.yes", func(in []int) []int { y, _ := safe.Partition(in, isEven); return y }},
+		{"Flatten", func(in []int) []int { return safe.Flatten([][]int{in}) }},
+	}
+
+	fmt.Println("--- sliceutil (in place) ---")
+	report(unsafeChecks)
+	fmt.Println("\n--- sliceutil/safe ---")
+	if !report(safeChecks) {
+		fmt.Println("BUG: a safe function shared memory with its input")
+	}
+}
+
+// report prints one line per check and returns true if no result
+// shared memory with, or modified, its input.
+func report(checks []check) bool {
+	isolated := true
+	fmt.Printf("%-14s %-7s %-22s %s\n", "func", "shares", "input after call", "input after write")
+	for _, c := range checks {
+		in := input()
+		before := slices.Clone(in)
+		out := c.run(in)
+		afterCall := slices.Clone(in)
+
+		shares := sliceutil.Overlaps(in, out)
+		if len(out) > 0 {
+			out[0] = 999
+		}
+		fmt.Printf("%-14s %-7t %-22s %v\n", c.name, shares, fmt.Sprint(afterCall), in)
+		if shares || !slices.Equal(before, in) {
+			isolated = false
+		}
+	}
+	return isolated
+}
@@ -0,0 +1,85 @@
+// Package safe mirrors sliceutil with one rule for every function: the
+// input is never modified and the result never shares storage with it.
+// Use it when the caller keeps using the original slice, or when the
+// result escapes to code you don't control. The price is one allocation
+// (or more) per call.
+package safe
+
+import "github.com/ViKing-py/lets-go-in-go/06_arrays_and_slices/sliceutil"
+
+// clone returns a copy of s with no spare capacity, so even an append to
+// the result cannot touch memory shared with anything else.
+func clone[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
+
+// Insert returns a new slice with vs inserted at index i.
+func Insert[T any](s []T, i int, vs ...T) []T {
+	_ = s[i:] // bounds check
+	out := make([]T, 0, len(s)+len(vs))
+	out = append(out, s[:i]...)
+	out = append(out, vs...)
+	return append(out, s[i:]...)
+}
+
+// Delete returns a new slice without s[i:j].
+func Delete[T any](s []T, i, j int) []T {
+	_ = s[i:j:len(s)] // bounds check
+	out := make([]T, 0, len(s)-(j-i))
+	out = append(out, s[:i]...)
+	return append(out, s[j:]...)
+}
+
+// Reverse returns a reversed copy of s.
+func Reverse[T any](s []T) []T {
+	return sliceutil.Reverse(clone(s))
+}
+
+// Dedup returns a copy of s with repeated elements removed, keeping first
+// occurrences in order.
+func Dedup[T comparable](s []T) []T {
+	return clone(sliceutil.Dedup(clone(s)))
+}
+
+// Chunk splits a copy of s into pieces of size n. Every chunk has its own
+// backing array, so chunks are independent of s and of each other.
+func Chunk[T any](s []T, n int) [][]T {
+	chunks := sliceutil.Chunk(s, n)
+	for i, c := range chunks {
+		chunks[i] = clone(c)
+	}
+	return chunks
+}
+
+// Window returns every run of n consecutive elements, each in its own
+// backing array.
+func Window[T any](s []T, n int) [][]T {
+	windows := sliceutil.Window(s, n)
+	for i, w := range windows {
+		windows[i] = clone(w)
+	}
+	return windows
+}
+
+// Partition returns the elements satisfying keep and the rest, in their
+// original order, as two independent slices. s is left untouched.
+func Partition[T any](s []T, keep func(T) bool) (yes, no []T) {
+	for _, v := range s {
+		if keep(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return yes, no
+}
+
+// Flatten concatenates all slices in ss into a new slice.
+// sliceutil.Flatten is already independent; it is repeated here so the
+// safe package is complete on its own.
+func Flatten[T any](ss [][]T) []T {
+	return sliceutil.Flatten(ss)
+}
@@ -0,0 +1,107 @@
+package safe
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ViKing-py/lets-go-in-go/06_arrays_and_slices/sliceutil"
+)
+
+// input has spare capacity holding sentinels, so a function that writes
+// past len(s), as an in-place Insert would, is caught too.
+func input() []int {
+	s := make([]int, 10)
+	for i := range s {
+		s[i] = i + 1
+	}
+	s[2], s[4] = 1, 2 // repeats for Dedup
+	return s[:6]
+}
+
+// TestIsolation runs every function on a fresh input and checks the
+// package's promise: the input, up to its capacity, is unchanged, and
+// no result Overlaps it. It then writes into every result and checks the
+// input again.
+func TestIsolation(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	for _, tc := range []struct {
+		name string
+		run  func(in []int) [][]int // every slice the call returned
+	}{
+		{"Insert", func(in []int) [][]int { return [][]int{Insert(in, 2, 70, 80)} }},
+		{"Insert at end", func(in []int) [][]int { return [][]int{Insert(in, len(in), 70)} }},
+		{"Delete", func(in []int) [][]int { return [][]int{Delete(in, 1, 3)} }},
+		{"Delete all", func(in []int) [][]int { return [][]int{Delete(in, 0, len(in))} }},
+		{"Reverse", func(in []int) [][]int { return [][]int{Reverse(in)} }},
+		{"Dedup", func(in []int) [][]int { return [][]int{Dedup(in)} }},
+		{"Chunk", func(in []int) [][]int { return Chunk(in, 4) }},
+		{"Window", func(in []int) [][]int { return Window(in, 3) }},
+		{"Partition", func(in []int) [][]int { y, n := Partition(in, isEven); return [][]int{y, n} }},
+		{"Flatten", func(in []int) [][]int { return [][]int{Flatten([][]int{in, in[:2]})} }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := input()
+			before := slices.Clone(in[:cap(in)])
+			outs := tc.run(in)
+			if !slices.Equal(in[:cap(in)], before) {
+				t.Fatalf("input changed by the call: %v, was %v", in[:cap(in)], before)
+			}
+			for i, out := range outs {
+				if sliceutil.Overlaps(in, out) {
+					t.Errorf("result %d overlaps the input", i)
+				}
+				full := out[:cap(out)]
+				for j := range full {
+					full[j] = -1
+				}
+			}
+			if !slices.Equal(in[:cap(in)], before) {
+				t.Errorf("input changed by writing to a result: %v, was %v", in[:cap(in)], before)
+			}
+		})
+	}
+}
+
+// TestIndependentResults checks that the slices Chunk, Window and
+// Partition return do not share storage with each other either.
+func TestIndependentResults(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	y, n := Partition(input(), isEven)
+	for name, outs := range map[string][][]int{
+		"Chunk":     Chunk(input(), 2),
+		"Window":    Window(input(), 3),
+		"Partition": {y, n},
+	} {
+		for i := range outs {
+			for j := i + 1; j < len(outs); j++ {
+				if sliceutil.Overlaps(outs[i], outs[j]) {
+					t.Errorf("%s: results %d and %d overlap", name, i, j)
+				}
+			}
+		}
+	}
+}
+
+func TestResults(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	in := input() // [1 2 1 4 2 6]
+	check := func(name string, got, want []int) {
+		t.Helper()
+		if !slices.Equal(got, want) {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+	check("Insert", Insert(in, 2, 70, 80), []int{1, 2, 70, 80, 1, 4, 2, 6})
+	check("Delete", Delete(in, 1, 3), []int{1, 4, 2, 6})
+	check("Reverse", Reverse(in), []int{6, 2, 4, 1, 2, 1})
+	check("Dedup", Dedup(in), []int{1, 2, 4, 6})
+	check("Flatten", Flatten([][]int{in[:2], in[4:]}), []int{1, 2, 2, 6})
+	chunks := Chunk(in, 4)
+	check("Chunk[0]", chunks[0], []int{1, 2, 1, 4})
+	check("Chunk[1]", chunks[1], []int{2, 6})
+	windows := Window(in, 5)
+	check("Window[1]", windows[1], []int{2, 1, 4, 2, 6})
+	yes, no := Partition(in, isEven)
+	check("Partition yes", yes, []int{2, 4, 2, 6})
+	check("Partition no", no, []int{1, 1})
+}
@@ -0,0 +1,209 @@
+// Package sliceutil holds generic slice helpers whose aliasing behaviour
+// is part of their contract, following PITFALL 1 of 06_arrays_and_slices
+// ("slices share the same memory").
+//
+// Every function documents one of three guarantees:
+//
+//   - ALIASES: the result shares the input's backing array. Writes through
+//     one are visible through the other. These functions are cheap.
+//   - MAY ALIAS: like append, the result shares storage only if the input
+//     had enough capacity. Treat it as aliasing.
+//   - INDEPENDENT: the result never shares storage with the input.
+//
+// The subpackage sliceutil/safe has the same functions, all INDEPENDENT
+// and none of them modifying their input.
+package sliceutil
+
+import "unsafe"
+
+// ---------------------------------------------------------
+// 1. ALIASING CHECK
+// ---------------------------------------------------------
+
+// Overlaps reports whether a and b can reach a common element of a backing
+// array, looking at their full capacity rather than just their length:
+// an append to one may overwrite the other exactly when Overlaps is true.
+// Empty-capacity slices never overlap anything.
+func Overlaps[T any](a, b []T) bool {
+	if cap(a) == 0 || cap(b) == 0 {
+		return false
+	}
+	size := unsafe.Sizeof(a[:1][0])
+	if size == 0 {
+		return false // zero-sized elements have no storage to share
+	}
+	aStart := uintptr(unsafe.Pointer(unsafe.SliceData(a)))
+	bStart := uintptr(unsafe.Pointer(unsafe.SliceData(b)))
+	aEnd := aStart + uintptr(cap(a))*size
+	bEnd := bStart + uintptr(cap(b))*size
+	return aStart < bEnd && bStart < aEnd
+}
+
+// ---------------------------------------------------------
+// 2. EDITING
+// ---------------------------------------------------------
+
+// Insert inserts vs at index i and returns the modified slice.
+//
+// MAY ALIAS: if s has room, elements from i onward are shifted in place,
+// so other slices of the same array see the shift. Otherwise a new array
+// is allocated. Always use the result: s = sliceutil.Insert(s, i, v).
+// Insert panics if i is out of range [0, len(s)].
+func Insert[T any](s []T, i int, vs ...T) []T {
+	_ = s[i:] // bounds check
+	n := len(vs)
+	if n == 0 {
+		return s
+	}
+	if len(s)+n <= cap(s) {
+		s = s[:len(s)+n]
+		copy(s[i+n:], s[i:])
+		copy(s[i:], vs)
+		return s
+	}
+	out := make([]T, len(s)+n, growCap(cap(s), len(s)+n))
+	copy(out, s[:i])
+	copy(out[i:], vs)
+	copy(out[i+n:], s[i:])
+	return out
+}
+
+// Delete removes s[i:j] and returns the shortened slice.
+//
+// ALIASES: elements are moved down in place, and the now-unused tail
+// s[len(s)-(j-i):] is zeroed so it no longer keeps pointers alive.
+// Delete panics if s[i:j] is not a valid slice of s.
+func Delete[T any](s []T, i, j int) []T {
+	_ = s[i:j:len(s)] // bounds check
+	if i == j {
+		return s
+	}
+	n := copy(s[i:], s[j:])
+	clear(s[i+n:])
+	return s[:i+n]
+}
+
+// Reverse reverses s in place and returns it.
+//
+// ALIASES: the result is s itself.
+func Reverse[T any](s []T) []T {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+	return s
+}
+
+// Dedup removes every repeated element, keeping the first occurrence and
+// the original order, and returns the shortened slice.
+//
+// ALIASES: survivors are compacted in place and the unused tail is zeroed.
+func Dedup[T comparable](s []T) []T {
+	seen := make(map[T]struct{}, len(s))
+	out := s[:0]
+	for _, v := range s {
+		if _, dup := seen[v]; dup {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v) // never reallocates: out is never longer than s
+	}
+	clear(s[len(out):])
+	return out
+}
+
+// ---------------------------------------------------------
+// 3. SPLITTING
+// ---------------------------------------------------------
+
+// Chunk splits s into consecutive pieces of size n (the last may be
+// shorter). It panics if n < 1.
+//
+// ALIASES: every chunk is a view into s. Each chunk's capacity is capped
+// at its length (full slice expression s[lo:hi:hi]), so appending to one
+// chunk reallocates instead of overwriting the next chunk.
+func Chunk[T any](s []T, n int) [][]T {
+	if n < 1 {
+		panic("sliceutil: Chunk size must be >= 1")
+	}
+	out := make([][]T, 0, (len(s)+n-1)/n)
+	for lo := 0; lo < len(s); lo += n {
+		hi := min(lo+n, len(s))
+		out = append(out, s[lo:hi:hi])
+	}
+	return out
+}
+
+// Window returns every run of n consecutive elements (a sliding window).
+// It returns nil if len(s) < n and panics if n < 1.
+//
+// ALIASES: windows are overlapping views into s, capped like Chunk.
+// Writing s[2] changes every window that contains index 2.
+func Window[T any](s []T, n int) [][]T {
+	if n < 1 {
+		panic("sliceutil: Window size must be >= 1")
+	}
+	if len(s) < n {
+		return nil
+	}
+	out := make([][]T, 0, len(s)-n+1)
+	for lo := 0; lo+n <= len(s); lo++ {
+		out = append(out, s[lo:lo+n:lo+n])
+	}
+	return out
+}
+
+// Partition reorders s so that elements satisfying keep come first, and
+// returns the two halves. Both halves keep their relative order.
+//
+// ALIASES: s is rearranged in place and both results are views into it
+// (yes is capped so appending to it cannot overwrite no). A scratch
+// buffer the size of the rejected elements is allocated.
+func Partition[T any](s []T, keep func(T) bool) (yes, no []T) {
+	var rejected []T
+	k := 0
+	for _, v := range s {
+		if keep(v) {
+			s[k] = v
+			k++
+		} else {
+			rejected = append(rejected, v)
+		}
+	}
+	copy(s[k:], rejected)
+	return s[:k:k], s[k:]
+}
+
+// ---------------------------------------------------------
+// 4. JOINING
+// ---------------------------------------------------------
+
+// Flatten concatenates all slices in ss.
+//
+// INDEPENDENT: the result is always freshly allocated (nil when there is
+// nothing to copy), even when ss has a single element.
+func Flatten[T any](ss [][]T) []T {
+	total := 0
+	for _, s := range ss {
+		total += len(s)
+	}
+	if total == 0 {
+		return nil
+	}
+	out := make([]T, 0, total)
+	for _, s := range ss {
+		out = append(out, s...)
+	}
+	return out
+}
+
+// growCap mirrors append's policy closely enough for Insert: double small
+// slices, grow large ones by 25%, never below what is needed.
+func growCap(old, needed int) int {
+	c := old
+	if c < 256 {
+		c *= 2
+	} else {
+		c += c / 4
+	}
+	return max(c, needed)
+}
@@ -0,0 +1,86 @@
+package sliceutil
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOverlaps(t *testing.T) {
+	arr := make([]int, 10)
+	for _, tc := range []struct {
+		name string
+		a, b []int
+		want bool
+	}{
+		{"same", arr, arr, true},
+		{"disjoint by length, shared by capacity", arr[:2], arr[5:7], true},
+		{"capped apart", arr[:2:2], arr[2:4], false},
+		{"sub-slice", arr, arr[9:], true},
+		{"separate arrays", arr, make([]int, 10), false},
+		{"nil", arr, nil, false},
+		{"zero capacity", arr[3:3:3], arr, false},
+	} {
+		if got := Overlaps(tc.a, tc.b); got != tc.want {
+			t.Errorf("%s: Overlaps = %t, want %t", tc.name, got, tc.want)
+		}
+		if got := Overlaps(tc.b, tc.a); got != tc.want {
+			t.Errorf("%s (swapped): Overlaps = %t, want %t", tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestGuarantees checks the guarantee each function documents. The in-
+// place functions are allowed to change their input; what is tested is
+// whether the result shares storage with it, and that the result is
+// right.
+func TestGuarantees(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	input := func(spare int) []int {
+		s := make([]int, 6, 6+spare)
+		copy(s, []int{1, 2, 1, 4, 2, 6})
+		return s
+	}
+	for _, tc := range []struct {
+		name   string
+		spare  int // capacity beyond len(input)
+		run    func(in []int) []int
+		want   []int
+		shares bool
+	}{
+		{"Insert with room", 4, func(in []int) []int { return Insert(in, 2, 70, 80) }, []int{1, 2, 70, 80, 1, 4, 2, 6}, true},
+		{"Insert without room", 0, func(in []int) []int { return Insert(in, 2, 70, 80) }, []int{1, 2, 70, 80, 1, 4, 2, 6}, false},
+		{"Delete", 0, func(in []int) []int { return Delete(in, 1, 3) }, []int{1, 4, 2, 6}, true},
+		{"Reverse", 0, func(in []int) []int { return Reverse(in) }, []int{6, 2, 4, 1, 2, 1}, true},
+		{"Dedup", 0, func(in []int) []int { return Dedup(in) }, []int{1, 2, 4, 6}, true},
+		{"Chunk", 0, func(in []int) []int { return Chunk(in, 4)[1] }, []int{2, 6}, true},
+		{"Window", 0, func(in []int) []int { return Window(in, 5)[1] }, []int{2, 1, 4, 2, 6}, true},
+		{"Partition", 0, func(in []int) []int { y, _ := Partition(in, isEven); return y }, []int{2, 4, 2, 6}, true},
+		{"Flatten", 4, func(in []int) []int { return Flatten([][]int{in}) }, []int{1, 2, 1, 4, 2, 6}, false},
+	} {
+		in := input(tc.spare)
+		before := slices.Clone(in[:cap(in)])
+		out := tc.run(in)
+		if !slices.Equal(out, tc.want) {
+			t.Errorf("%s = %v, want %v", tc.name, out, tc.want)
+		}
+		if got := Overlaps(in, out); got != tc.shares {
+			t.Errorf("%s: result overlaps input = %t, documented %t", tc.name, got, tc.shares)
+		}
+		if !tc.shares && !slices.Equal(in[:cap(in)], before) {
+			t.Errorf("%s: independent, but changed its input to %v", tc.name, in[:cap(in)])
+		}
+	}
+}
+
+// TestCappedViews checks that appending to a chunk or window cannot
+// overwrite the element after it in s.
+func TestCappedViews(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6}
+	chunks := Chunk(s, 2)
+	_ = append(chunks[0], 99)
+	windows := Window(s, 3)
+	_ = append(windows[0], 99)
+	if want := []int{1, 2, 3, 4, 5, 6}; !slices.Equal(s, want) {
+		t.Errorf("append to a view changed s to %v", s)
+	}
+}
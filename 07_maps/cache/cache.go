@@ -0,0 +1,419 @@
+// Package cache is the first "real" thing most teams build out of a map:
+// a bounded, expiring, concurrency-safe cache.
+//
+//   - LRU eviction once MaxEntries is reached (least recently used first).
+//   - Per-entry TTL measured with an injectable clock.Clock.
+//   - Hit / miss / eviction statistics.
+//   - An eviction callback that says why an entry left.
+//   - GetOrLoad with single-flight: concurrent misses for the same key
+//     share one call to the loader.
+//
+// Recency order is kept in an orderedmap.OrderedMap: a hit moves the key
+// to the back, so the front is always the next eviction victim.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+	"github.com/ViKing-py/lets-go-in-go/07_maps/orderedmap"
+)
+
+// Reason says why an entry was removed.
+type Reason int
+
+const (
+	Evicted  Reason = iota // pushed out by MaxEntries (LRU)
+	Expired                // TTL elapsed
+	Deleted                // removed with Delete or Purge
+	Replaced               // overwritten by Set
+)
+
+func (r Reason) String() string {
+	switch r {
+	case Evicted:
+		return "evicted"
+	case Expired:
+		return "expired"
+	case Deleted:
+		return "deleted"
+	case Replaced:
+		return "replaced"
+	}
+	return "unknown"
+}
+
+var (
+	// ErrNoLoader is returned by GetOrLoad when Options.Loader is nil.
+	ErrNoLoader = errors.New("cache: no loader configured")
+	// ErrLoaderPanic is wrapped by the error GetOrLoad returns when the
+	// loader panicked.
+	ErrLoaderPanic = errors.New("cache: loader panicked")
+)
+
+// Options configures a Cache. The zero value is an unbounded cache with
+// no expiry, using the real clock.
+type Options[K comparable, V any] struct {
+	// MaxEntries bounds the cache; 0 means unbounded.
+	MaxEntries int
+	// TTL is the default time-to-live for Set and GetOrLoad; 0 means never.
+	TTL time.Duration
+	// Clock is used for expiry. nil means clock.Real{}.
+	Clock clock.Clock
+	// OnEvict, if set, is called after an entry leaves the cache. It runs
+	// without the cache lock held, so it may call back into the cache.
+	OnEvict func(key K, value V, why Reason)
+	// Loader fills misses in GetOrLoad.
+	Loader func(ctx context.Context, key K) (V, error)
+}
+
+// Stats are cumulative counters.
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Loads       uint64 // loader calls that succeeded
+	LoadErrors  uint64
+	Evictions   uint64 // LRU evictions only
+	Expirations uint64
+}
+
+// HitRate returns Hits / (Hits + Misses), or 0 before any lookup.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type item[V any] struct {
+	value   V
+	expires time.Time // zero means never
+}
+
+// call is an in-flight load shared by every waiter for the same key.
+// Its fields are written before done is closed and only read after.
+type call[V any] struct {
+	done     chan struct{}
+	value    V
+	err      error
+	panicked any // the loader's panic value, if it panicked
+
+	// stale is set, under Cache.mu, when Set, Delete or Purge touches the
+	// key during the load; the result is then returned but not stored.
+	stale bool
+}
+
+type removal[K comparable, V any] struct {
+	key   K
+	value V
+	why   Reason
+}
+
+// Cache is a concurrency-safe LRU + TTL cache.
+type Cache[K comparable, V any] struct {
+	opts Options[K, V]
+
+	mu       sync.Mutex
+	items    *orderedmap.OrderedMap[K, item[V]]
+	inflight map[K]*call[V]
+	stats    Stats
+	timed    int // entries with an expiry; 0 lets setLocked skip firstExpired
+}
+
+// New returns an empty cache.
+func New[K comparable, V any](opts Options[K, V]) *Cache[K, V] {
+	if opts.Clock == nil {
+		opts.Clock = clock.Real{}
+	}
+	return &Cache[K, V]{
+		opts:     opts,
+		items:    orderedmap.New[K, item[V]](),
+		inflight: make(map[K]*call[V]),
+	}
+}
+
+// Get returns the cached value for key. Expired entries count as misses
+// and are removed on the spot.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	v, ok, gone := c.getLocked(key)
+	c.mu.Unlock()
+	c.notify(gone)
+	return v, ok
+}
+
+// getLocked must be called with c.mu held. It returns any removal so the
+// caller can report it after unlocking.
+func (c *Cache[K, V]) getLocked(key K) (V, bool, []removal[K, V]) {
+	var zero V
+	it, ok := c.items.Get(key)
+	if !ok {
+		c.stats.Misses++
+		return zero, false, nil
+	}
+	if c.expired(it) {
+		c.removeLocked(key, it)
+		c.stats.Misses++
+		c.stats.Expirations++
+		return zero, false, []removal[K, V]{{key, it.value, Expired}}
+	}
+	c.items.MoveToBack(key)
+	c.stats.Hits++
+	return it.value, true, nil
+}
+
+func (c *Cache[K, V]) expired(it item[V]) bool {
+	return !it.expires.IsZero() && !c.opts.Clock.Now().Before(it.expires)
+}
+
+// Set stores value with the default TTL.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.opts.TTL)
+}
+
+// SetWithTTL stores value for ttl (0 means never expire). Storing a key
+// makes it the most recently used. A load of key already in flight still
+// returns its result to its waiters, but does not overwrite value.
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	c.invalidateLocked(key)
+	gone := c.setLocked(key, value, ttl)
+	c.mu.Unlock()
+	c.notify(gone)
+}
+
+func (c *Cache[K, V]) setLocked(key K, value V, ttl time.Duration) []removal[K, V] {
+	var gone []removal[K, V]
+	it := item[V]{value: value}
+	if ttl > 0 {
+		it.expires = c.opts.Clock.Now().Add(ttl)
+		c.timed++
+	}
+	if old, ok := c.items.Get(key); ok {
+		c.removeLocked(key, old)
+		gone = append(gone, removal[K, V]{key, old.value, Replaced})
+	}
+	c.items.Set(key, it)
+
+	for c.opts.MaxEntries > 0 && c.items.Len() > c.opts.MaxEntries {
+		// Prefer dropping something already expired over a live LRU victim.
+		if k, old, ok := c.firstExpired(); ok {
+			c.removeLocked(k, old)
+			c.stats.Expirations++
+			gone = append(gone, removal[K, V]{k, old.value, Expired})
+			continue
+		}
+		k, old, _ := c.items.Front()
+		c.removeLocked(k, old)
+		c.stats.Evictions++
+		gone = append(gone, removal[K, V]{k, old.value, Evicted})
+	}
+	return gone
+}
+
+// firstExpired scans from the LRU end. It only runs when the cache is
+// over capacity, and returns at once when no entry has an expiry, so a
+// cache without TTLs evicts in O(1). With TTLs it is O(n) in the worst
+// case.
+func (c *Cache[K, V]) firstExpired() (K, item[V], bool) {
+	if c.timed > 0 {
+		for k, it := range c.items.All() {
+			if c.expired(it) {
+				return k, it, true
+			}
+		}
+	}
+	var k K
+	return k, item[V]{}, false
+}
+
+// invalidateLocked stops an in-flight load of key from storing a result
+// that is older than the caller's change.
+func (c *Cache[K, V]) invalidateLocked(key K) {
+	if cl, ok := c.inflight[key]; ok {
+		cl.stale = true
+	}
+}
+
+// removeLocked deletes key, whose current item is it, and keeps c.timed
+// in step.
+func (c *Cache[K, V]) removeLocked(key K, it item[V]) {
+	c.items.Delete(key)
+	if !it.expires.IsZero() {
+		c.timed--
+	}
+}
+
+// Delete removes key and reports whether it was present. A load of key
+// already in flight will not store its result.
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	c.invalidateLocked(key)
+	it, ok := c.items.Get(key)
+	if ok {
+		c.removeLocked(key, it)
+	}
+	c.mu.Unlock()
+	if ok {
+		c.notify([]removal[K, V]{{key, it.value, Deleted}})
+	}
+	return ok
+}
+
+// RemoveExpired drops every expired entry and returns how many were
+// removed. Call it periodically if stale entries should not linger until
+// their next lookup.
+func (c *Cache[K, V]) RemoveExpired() int {
+	c.mu.Lock()
+	var gone []removal[K, V]
+	for k, it := range c.items.All() {
+		if c.expired(it) {
+			c.removeLocked(k, it) // deleting the current key while ranging is allowed
+			gone = append(gone, removal[K, V]{k, it.value, Expired})
+		}
+	}
+	c.stats.Expirations += uint64(len(gone))
+	c.mu.Unlock()
+	c.notify(gone)
+	return len(gone)
+}
+
+// Purge removes every entry. Loads already in flight will not store
+// their results.
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	for _, cl := range c.inflight {
+		cl.stale = true
+	}
+	var gone []removal[K, V]
+	for k, it := range c.items.All() {
+		gone = append(gone, removal[K, V]{k, it.value, Deleted})
+	}
+	c.items = orderedmap.New[K, item[V]]()
+	c.timed = 0
+	c.mu.Unlock()
+	c.notify(gone)
+}
+
+// Len returns the number of entries, including expired ones that have
+// not been removed yet.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.items.Len()
+}
+
+// Stats returns a copy of the counters.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Keys returns the keys from least to most recently used.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, c.items.Len())
+	for k := range c.items.Keys() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// GetOrLoad returns the cached value or loads it with Options.Loader.
+// If several goroutines miss on the same key at once, the loader runs
+// once and all of them receive its result ("single flight"). Errors are
+// returned to every waiter and are not cached.
+//
+// ctx only bounds this caller's wait: if it is cancelled, GetOrLoad
+// returns ctx.Err() while the load keeps going for the other waiters.
+// The loader runs in its own goroutine and receives
+// context.WithoutCancel of the starting caller's ctx, so it keeps that
+// context's values but not its deadline or cancellation.
+//
+// If the loader panics, every waiter gets an error wrapping
+// ErrLoaderPanic, and the caller that started the load re-panics with
+// the original value if it is still waiting.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K) (V, error) {
+	var zero V
+	if c.opts.Loader == nil {
+		return zero, ErrNoLoader
+	}
+
+	c.mu.Lock()
+	if v, ok, gone := c.getLocked(key); ok {
+		c.mu.Unlock()
+		return v, nil
+	} else if gone != nil {
+		defer c.notify(gone)
+	}
+	if cl, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		return c.wait(ctx, cl, false)
+	}
+	cl := &call[V]{done: make(chan struct{})}
+	c.inflight[key] = cl
+	c.mu.Unlock()
+
+	// Run the loader outside the lock so other keys are not blocked, and
+	// outside this goroutine so this caller can give up like any other.
+	go c.load(context.WithoutCancel(ctx), key, cl)
+	return c.wait(ctx, cl, true)
+}
+
+func (c *Cache[K, V]) load(ctx context.Context, key K, cl *call[V]) {
+	// A panicking loader must still release the waiters.
+	defer func() {
+		if r := recover(); r != nil {
+			cl.panicked = r
+			cl.err = fmt.Errorf("%w: %v", ErrLoaderPanic, r)
+		}
+		c.finish(key, cl)
+	}()
+	cl.value, cl.err = c.opts.Loader(ctx, key)
+}
+
+func (c *Cache[K, V]) finish(key K, cl *call[V]) {
+	c.mu.Lock()
+	delete(c.inflight, key)
+	var gone []removal[K, V]
+	if cl.err == nil {
+		c.stats.Loads++
+		if !cl.stale {
+			gone = c.setLocked(key, cl.value, c.opts.TTL)
+		}
+	} else {
+		c.stats.LoadErrors++
+	}
+	c.mu.Unlock()
+	close(cl.done)
+	c.notify(gone)
+}
+
+func (c *Cache[K, V]) wait(ctx context.Context, cl *call[V], started bool) (V, error) {
+	select {
+	case <-cl.done:
+		if started && cl.panicked != nil {
+			panic(cl.panicked)
+		}
+		return cl.value, cl.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (c *Cache[K, V]) notify(gone []removal[K, V]) {
+	if c.opts.OnEvict == nil {
+		return
+	}
+	for _, r := range gone {
+		c.opts.OnEvict(r.key, r.value, r.why)
+	}
+}
@@ -0,0 +1,326 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+)
+
+var start = time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+// removed records OnEvict calls as "key:reason".
+type removed struct {
+	mu  sync.Mutex
+	got []string
+}
+
+func (r *removed) onEvict(k string, _ int, why Reason) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.got = append(r.got, k+":"+why.String())
+}
+
+func (r *removed) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	got := r.got
+	r.got = nil
+	return got
+}
+
+// waitMisses blocks until c has counted n misses, i.e. n GetOrLoad
+// callers have found the key missing and joined (or started) its load.
+func waitMisses(t *testing.T, c *Cache[string, int], n uint64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for c.Stats().Misses < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("misses = %d, want %d", c.Stats().Misses, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLRU(t *testing.T) {
+	var r removed
+	c := New(Options[string, int]{MaxEntries: 3, OnEvict: r.onEvict})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	c.Get("a")     // a becomes most recently used
+	c.Set("c", 30) // so does c, replacing 3
+	c.Set("d", 4)  // b is now the LRU victim
+	if got, want := c.Keys(), []string{"a", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("Keys = %v, want %v", got, want)
+	}
+	if got, want := r.take(), []string{"c:replaced", "b:evicted"}; !slices.Equal(got, want) {
+		t.Errorf("OnEvict = %v, want %v", got, want)
+	}
+	c.Set("e", 5)
+	c.Set("f", 6)
+	if got, want := c.Keys(), []string{"d", "e", "f"}; !slices.Equal(got, want) {
+		t.Errorf("Keys = %v, want %v", got, want)
+	}
+	if s := c.Stats(); s.Evictions != 3 || s.Hits != 1 {
+		t.Errorf("Stats = %+v, want 3 evictions and 1 hit", s)
+	}
+}
+
+func TestTTL(t *testing.T) {
+	fake := clock.NewFake(start)
+	var r removed
+	c := New(Options[string, int]{TTL: time.Minute, Clock: fake, OnEvict: r.onEvict})
+	c.Set("a", 1)
+	c.SetWithTTL("forever", 2, 0)
+	c.SetWithTTL("short", 3, 10*time.Second)
+
+	fake.Advance(10 * time.Second)
+	if _, ok := c.Get("short"); ok {
+		t.Error("short survived its 10s TTL")
+	}
+	fake.Advance(49 * time.Second)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) at 59s = %d, %v, want 1, true", v, ok)
+	}
+	fake.Advance(time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Error("a survived its 1m TTL")
+	}
+	fake.Advance(time.Hour)
+	if v, ok := c.Get("forever"); !ok || v != 2 {
+		t.Errorf("Get(forever) = %d, %v, want 2, true", v, ok)
+	}
+	if got, want := r.take(), []string{"short:expired", "a:expired"}; !slices.Equal(got, want) {
+		t.Errorf("OnEvict = %v, want %v", got, want)
+	}
+	if s := c.Stats(); s.Expirations != 2 || s.Misses != 2 {
+		t.Errorf("Stats = %+v, want 2 expirations and 2 misses", s)
+	}
+}
+
+func TestRemoveExpired(t *testing.T) {
+	fake := clock.NewFake(start)
+	c := New(Options[string, int]{Clock: fake})
+	c.SetWithTTL("a", 1, time.Second)
+	c.SetWithTTL("b", 2, time.Minute)
+	c.SetWithTTL("c", 3, time.Second)
+	fake.Advance(time.Second)
+	if n := c.RemoveExpired(); n != 2 {
+		t.Errorf("RemoveExpired = %d, want 2", n)
+	}
+	if got := c.Keys(); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("Keys = %v, want [b]", got)
+	}
+}
+
+func TestEvictExpiredFirst(t *testing.T) {
+	fake := clock.NewFake(start)
+	var r removed
+	c := New(Options[string, int]{MaxEntries: 2, Clock: fake, OnEvict: r.onEvict})
+	c.SetWithTTL("a", 1, 0)
+	c.SetWithTTL("b", 2, time.Second)
+	fake.Advance(time.Second)
+	c.Set("c", 3) // a is the LRU entry, but b has expired
+	if got, want := c.Keys(), []string{"a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Keys = %v, want %v", got, want)
+	}
+	c.Set("d", 4) // nothing expired any more: plain LRU
+	if got, want := r.take(), []string{"b:expired", "a:evicted"}; !slices.Equal(got, want) {
+		t.Errorf("OnEvict = %v, want %v", got, want)
+	}
+}
+
+func TestSingleFlight(t *testing.T) {
+	const n = 50
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := New(Options[string, int]{
+		Loader: func(ctx context.Context, key string) (int, error) {
+			calls.Add(1)
+			<-release
+			return len(key), nil
+		},
+	})
+
+	var wg sync.WaitGroup
+	results := make([]int, n)
+	errs := make([]error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = c.GetOrLoad(context.Background(), "hello")
+		}()
+	}
+	waitMisses(t, c, n)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("loader ran %d times, want 1", got)
+	}
+	for i := range n {
+		if results[i] != 5 || errs[i] != nil {
+			t.Fatalf("caller %d got %d, %v, want 5, nil", i, results[i], errs[i])
+		}
+	}
+	if v, err := c.GetOrLoad(context.Background(), "hello"); v != 5 || err != nil || calls.Load() != 1 {
+		t.Errorf("cached GetOrLoad = %d, %v after %d loads, want 5, nil after 1", v, err, calls.Load())
+	}
+	if s := c.Stats(); s.Loads != 1 || s.Hits != 1 {
+		t.Errorf("Stats = %+v, want 1 load and 1 hit", s)
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	errDown := errors.New("database down")
+	var calls int
+	c := New(Options[string, int]{
+		Loader: func(context.Context, string) (int, error) {
+			calls++
+			return 0, errDown
+		},
+	})
+	for range 2 {
+		if _, err := c.GetOrLoad(context.Background(), "k"); err != errDown {
+			t.Errorf("GetOrLoad error = %v, want %v", err, errDown)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("loader ran %d times, want 2: errors must not be cached", calls)
+	}
+	if _, err := New(Options[string, int]{}).GetOrLoad(context.Background(), "k"); err != ErrNoLoader {
+		t.Errorf("GetOrLoad without loader = %v, want ErrNoLoader", err)
+	}
+}
+
+func TestLoaderPanic(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	c := New(Options[string, int]{
+		Loader: func(context.Context, string) (int, error) {
+			if calls.Add(1) == 1 {
+				<-release
+				panic("boom")
+			}
+			return 7, nil
+		},
+	})
+
+	starter := make(chan any)
+	go func() {
+		defer func() { starter <- recover() }()
+		c.GetOrLoad(context.Background(), "k")
+	}()
+	waitMisses(t, c, 1)
+	waiter := make(chan error)
+	go func() {
+		_, err := c.GetOrLoad(context.Background(), "k")
+		waiter <- err
+	}()
+	waitMisses(t, c, 2)
+	close(release)
+
+	if r := <-starter; r != "boom" {
+		t.Errorf("starting caller recovered %v, want boom", r)
+	}
+	if err := <-waiter; !errors.Is(err, ErrLoaderPanic) {
+		t.Errorf("waiter error = %v, want ErrLoaderPanic", err)
+	}
+	// The failed load must not be left in flight.
+	if v, err := c.GetOrLoad(context.Background(), "k"); v != 7 || err != nil {
+		t.Errorf("GetOrLoad after panic = %d, %v, want 7, nil", v, err)
+	}
+	if s := c.Stats(); s.LoadErrors != 1 || s.Loads != 1 {
+		t.Errorf("Stats = %+v, want 1 load error and 1 load", s)
+	}
+}
+
+type ctxKey struct{}
+
+func TestCallerCancel(t *testing.T) {
+	release := make(chan struct{})
+	loaderErr := make(chan error, 1)
+	c := New(Options[string, int]{
+		Loader: func(ctx context.Context, key string) (int, error) {
+			<-release
+			if ctx.Value(ctxKey{}) != "trace-1" {
+				t.Error("loader lost the starting caller's context values")
+			}
+			loaderErr <- ctx.Err()
+			return 42, nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "trace-1"))
+	first := make(chan error)
+	go func() {
+		_, err := c.GetOrLoad(ctx, "k")
+		first <- err
+	}()
+	waitMisses(t, c, 1)
+	second := make(chan int)
+	go func() {
+		v, _ := c.GetOrLoad(context.Background(), "k")
+		second <- v
+	}()
+	waitMisses(t, c, 2)
+
+	cancel()
+	if err := <-first; err != context.Canceled {
+		t.Errorf("cancelled caller got %v, want context.Canceled", err)
+	}
+	close(release)
+	if v := <-second; v != 42 {
+		t.Errorf("other waiter got %d, want 42", v)
+	}
+	if err := <-loaderErr; err != nil {
+		t.Errorf("loader's ctx.Err() = %v, want nil after the starter cancelled", err)
+	}
+	if v, ok := c.Get("k"); !ok || v != 42 {
+		t.Errorf("Get after load = %d, %v, want 42, true", v, ok)
+	}
+}
+
+func TestStaleLoad(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		change func(c *Cache[string, int])
+		want   int
+		cached bool
+	}{
+		{"Set", func(c *Cache[string, int]) { c.Set("k", 2) }, 2, true},
+		{"Delete", func(c *Cache[string, int]) { c.Delete("k") }, 0, false},
+		{"Purge", func(c *Cache[string, int]) { c.Purge() }, 0, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			c := New(Options[string, int]{
+				Loader: func(context.Context, string) (int, error) {
+					<-release
+					return 1, nil
+				},
+			})
+			done := make(chan int)
+			go func() {
+				v, _ := c.GetOrLoad(context.Background(), "k")
+				done <- v
+			}()
+			waitMisses(t, c, 1)
+			tt.change(c)
+			close(release)
+			if v := <-done; v != 1 {
+				t.Errorf("GetOrLoad = %d, want the loaded 1", v)
+			}
+			if v, ok := c.Get("k"); v != tt.want || ok != tt.cached {
+				t.Errorf("Get after load = %d, %v, want %d, %v", v, ok, tt.want, tt.cached)
+			}
+		})
+	}
+}
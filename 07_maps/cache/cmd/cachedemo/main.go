@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+	"github.com/ViKing-py/lets-go-in-go/07_maps/cache"
+)
+
+// ---------------------------------------------------------
+// TOPIC: A Cache Built on Maps
+// ---------------------------------------------------------
+// Usage:
+//   go run ./07_maps/cache/cmd/cachedemo
+//
+// Walks through LRU eviction, TTL expiry (on a fake clock, so nothing
+// sleeps) and single-flight loading.
+
+func main() {
+	fake := clock.NewFake(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	var loads atomic.Int32
+
+	roles := cache.New(cache.Options[string, string]{
+		MaxEntries: 2,
+		TTL:        time.Minute,
+		Clock:      fake,
+		OnEvict: func(k, v string, why cache.Reason) {
+			fmt.Printf("  (callback) %s=%q %s\n", k, v, why)
+		},
+		Loader: func(ctx context.Context, user string) (string, error) {
+			loads.Add(1)
+			time.Sleep(50 * time.Millisecond) // pretend this is a database call
+			return strings.ToUpper(user[:1]) + user[1:] + " User", nil
+		},
+	})
+
+	fmt.Println("--- 1. LRU eviction (MaxEntries: 2) ---")
+	roles.Set("admin", "Super User")
+	roles.Set("editor", "Content Manager")
+	roles.Get("admin")               // admin is now most recently used
+	roles.Set("viewer", "Read Only") // so editor is evicted, not admin
+	fmt.Println("  keys, LRU first:", roles.Keys())
+
+	fmt.Println("\n--- 2. TTL expiry (TTL: 1m) ---")
+	fake.Advance(30 * time.Second)
+	_, ok := roles.Get("viewer")
+	fmt.Println("  after 30s, viewer cached?", ok)
+	fake.Advance(31 * time.Second)
+	_, ok = roles.Get("viewer")
+	fmt.Println("  after 61s, viewer cached?", ok)
+
+	fmt.Println("\n--- 3. Single flight ---")
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			roles.GetOrLoad(context.Background(), "guest")
+		}()
+	}
+	wg.Wait()
+	v, _ := roles.GetOrLoad(context.Background(), "guest")
+	fmt.Printf("  11 lookups of guest -> %q, loader ran %d time(s)\n", v, loads.Load())
+
+	s := roles.Stats()
+	fmt.Printf("\nStats: hits=%d misses=%d loads=%d evictions=%d expirations=%d hit rate=%.0f%%\n",
+		s.Hits, s.Misses, s.Loads, s.Evictions, s.Expirations, s.HitRate()*100)
+}
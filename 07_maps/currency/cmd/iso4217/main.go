@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ViKing-py/lets-go-in-go/07_maps/currency"
+)
+
+// ---------------------------------------------------------
+// TOPIC: An ISO 4217 Currency Registry
+// ---------------------------------------------------------
+// Usage:
+//   go run ./07_maps/currency/cmd/iso4217 UAH 840 jpy DEM
+//   go run ./07_maps/currency/cmd/iso4217 -lang uk UAH EUR
+//   go run ./07_maps/currency/cmd/iso4217 -list historic
+//   go run ./07_maps/currency/cmd/iso4217 -validate UAH DEM XYZ usd
+//
+// Arguments may be alpha codes (any case) or numeric codes.
+
+func main() {
+	lang := flag.String("lang", "en", "name language: en or uk")
+	list := flag.String("list", "", "list currencies: active, historic or all")
+	validate := flag.Bool("validate", false, "validate codes for invoicing instead of looking them up")
+	flag.Parse()
+	l := currency.Lang(*lang)
+
+	if *list != "" {
+		reg := currency.Default()
+		var cs []currency.Currency
+		switch *list {
+		case "active":
+			cs = reg.Active()
+		case "historic":
+			cs = reg.Historic()
+		case "all":
+			cs = reg.All()
+		default:
+			fail(fmt.Errorf("unknown -list %q: want active, historic or all", *list))
+		}
+		for _, c := range cs {
+			printRow(c, l)
+		}
+		fmt.Printf("%d currencies\n", len(cs))
+		return
+	}
+
+	if flag.NArg() == 0 {
+		fail(errors.New("usage: iso4217 [-lang uk] [-validate] CODE..."))
+	}
+	exit := 0
+	for _, arg := range flag.Args() {
+		if *validate {
+			if err := currency.Validate(arg); err != nil {
+				fmt.Printf("%-5s %v\n", arg, err)
+				exit = 1
+			} else {
+				fmt.Printf("%-5s ok\n", arg)
+			}
+			continue
+		}
+		c, ok := currency.Default().Find(arg)
+		if !ok {
+			fmt.Printf("%-5s not found\n", arg)
+			exit = 1
+			continue
+		}
+		printRow(c, l)
+	}
+	os.Exit(exit)
+}
+
+func printRow(c currency.Currency, l currency.Lang) {
+	minor := "-"
+	if c.MinorUnits != currency.NoMinorUnits {
+		minor = fmt.Sprint(c.MinorUnits)
+	}
+	status := "active"
+	if !c.Active() {
+		status = "withdrawn " + c.Withdrawn
+	}
+	fmt.Printf("%s %s  minor=%s  %-4s  %-36s %s  e.g. %s\n",
+		c.Code, c.NumericCode(), minor, c.Symbol, c.Name(l), status, c.FormatMinor(123456))
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
@@ -0,0 +1,282 @@
+// Package currency replaces the three-entry `currencies` map from 07_maps
+// with a complete ISO 4217 registry:
+//
+//   - alpha code ("UAH") and numeric code (980) lookup,
+//   - minor-unit digits (JPY has 0, KWD has 3, gold has none),
+//   - a display symbol and names in English and Ukrainian,
+//   - active vs historic (withdrawn) currencies.
+//
+// The data lives in iso4217.csv, embedded at build time. Edit that file
+// (not the Go code) when ISO publishes an amendment.
+package currency
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ---------------------------------------------------------
+// 1. CURRENCY
+// ---------------------------------------------------------
+
+// Lang selects the language of a currency name.
+type Lang string
+
+const (
+	EN Lang = "en"
+	UK Lang = "uk"
+)
+
+// NoMinorUnits is the MinorUnits value of currencies that are not
+// divided, such as XAU (gold) or XDR.
+const NoMinorUnits = -1
+
+// Currency is one ISO 4217 entry.
+type Currency struct {
+	Code       string // alpha-3, e.g. "UAH"
+	Numeric    int    // e.g. 980; print with NumericCode for the "008" form
+	MinorUnits int    // digits after the decimal point, or NoMinorUnits
+	Symbol     string // may be empty
+	Withdrawn  string // "YYYY-MM" for historic currencies, "" if active
+	names      map[Lang]string
+}
+
+// Name returns the name in lang, falling back to English.
+func (c Currency) Name(lang Lang) string {
+	if n, ok := c.names[lang]; ok && n != "" {
+		return n
+	}
+	return c.names[EN]
+}
+
+// Active reports whether the currency is still in use.
+func (c Currency) Active() bool { return c.Withdrawn == "" }
+
+// NumericCode returns the numeric code zero-padded to three digits.
+func (c Currency) NumericCode() string { return fmt.Sprintf("%03d", c.Numeric) }
+
+// FormatMinor renders an amount given in minor units, e.g. 12345 UAH
+// kopiykas -> "123.45". Currencies without minor units print as-is.
+func (c Currency) FormatMinor(amount int64) string {
+	if c.MinorUnits <= 0 {
+		return strconv.FormatInt(amount, 10)
+	}
+	sign := ""
+	u := uint64(amount)
+	if amount < 0 {
+		sign, u = "-", uint64(-amount) // -MinInt64 wraps to the right magnitude
+	}
+	s := fmt.Sprintf("%0*d", c.MinorUnits+1, u)
+	cut := len(s) - c.MinorUnits
+	return sign + s[:cut] + "." + s[cut:]
+}
+
+func (c Currency) String() string { return c.Code }
+
+// ---------------------------------------------------------
+// 2. REGISTRY
+// ---------------------------------------------------------
+
+var (
+	ErrInvalidCode = errors.New("currency: code must be three letters A-Z")
+	ErrUnknown     = errors.New("currency: unknown code")
+	ErrWithdrawn   = errors.New("currency: code is withdrawn")
+)
+
+// Registry indexes currencies by alpha and numeric code. It is read-only
+// after Load and safe for concurrent use.
+type Registry struct {
+	byCode    map[string]Currency
+	byNumeric map[int]Currency
+	all       []Currency // sorted by code
+}
+
+// Load reads the CSV format of iso4217.csv: a header line, then
+// code,numeric,minor,symbol,name_en,name_uk,withdrawn. Lines starting
+// with '#' are comments.
+func Load(r io.Reader) (*Registry, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 7
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("currency: read header: %w", err)
+	}
+	if header[0] != "code" {
+		return nil, fmt.Errorf("currency: unexpected header %q", header)
+	}
+
+	reg := &Registry{byCode: make(map[string]Currency), byNumeric: make(map[int]Currency)}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("currency: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		c, err := parseRecord(rec)
+		if err != nil {
+			return nil, fmt.Errorf("currency: line %d: %w", line, err)
+		}
+		if _, dup := reg.byCode[c.Code]; dup {
+			return nil, fmt.Errorf("currency: line %d: duplicate code %s", line, c.Code)
+		}
+		reg.byCode[c.Code] = c
+		reg.all = append(reg.all, c)
+
+		// ISO may reissue a numeric code after a withdrawal, so
+		// duplicates are allowed as long as at most one is active.
+		// An active entry always wins.
+		if prev, ok := reg.byNumeric[c.Numeric]; ok {
+			if prev.Active() && c.Active() {
+				return nil, fmt.Errorf("currency: line %d: numeric %s used by %s and %s",
+					line, c.NumericCode(), prev.Code, c.Code)
+			}
+			if prev.Active() || prev.Withdrawn > c.Withdrawn {
+				continue
+			}
+		}
+		reg.byNumeric[c.Numeric] = c
+	}
+	sort.Slice(reg.all, func(i, j int) bool { return reg.all[i].Code < reg.all[j].Code })
+	return reg, nil
+}
+
+func parseRecord(rec []string) (Currency, error) {
+	c := Currency{
+		Code:      rec[0],
+		Symbol:    rec[3],
+		Withdrawn: rec[6],
+		names:     map[Lang]string{EN: rec[4], UK: rec[5]},
+	}
+	if !validCode(c.Code) {
+		return c, fmt.Errorf("%w: %q", ErrInvalidCode, c.Code)
+	}
+	n, err := strconv.Atoi(rec[1])
+	if err != nil || len(rec[1]) != 3 || n <= 0 {
+		return c, fmt.Errorf("%s: bad numeric code %q", c.Code, rec[1])
+	}
+	c.Numeric = n
+	if rec[2] == "-" {
+		c.MinorUnits = NoMinorUnits
+	} else if c.MinorUnits, err = strconv.Atoi(rec[2]); err != nil || c.MinorUnits < 0 || c.MinorUnits > 4 {
+		return c, fmt.Errorf("%s: bad minor units %q", c.Code, rec[2])
+	}
+	if rec[4] == "" {
+		return c, fmt.Errorf("%s: missing English name", c.Code)
+	}
+	return c, nil
+}
+
+func validCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if code[i] < 'A' || code[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Lookup finds a currency by alpha code. Case does not matter.
+func (r *Registry) Lookup(code string) (Currency, bool) {
+	c, ok := r.byCode[strings.ToUpper(code)]
+	return c, ok
+}
+
+// LookupNumeric finds a currency by numeric code. If the number was
+// reused, the active currency (or the most recently withdrawn) wins.
+func (r *Registry) LookupNumeric(n int) (Currency, bool) {
+	c, ok := r.byNumeric[n]
+	return c, ok
+}
+
+// Find accepts either form: "UAH", "uah", "980" or "008".
+func (r *Registry) Find(s string) (Currency, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return r.LookupNumeric(n)
+	}
+	return r.Lookup(s)
+}
+
+// Validate checks that code is a well-formed, known and active currency,
+// which is what an invoice needs. Case does not matter, as in Lookup.
+// Use errors.Is with ErrInvalidCode, ErrUnknown or ErrWithdrawn to tell
+// the cases apart.
+func (r *Registry) Validate(code string) error {
+	upper := strings.ToUpper(code)
+	if !validCode(upper) {
+		return fmt.Errorf("%w: %q", ErrInvalidCode, code)
+	}
+	code = upper
+	c, ok := r.byCode[code]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknown, code)
+	}
+	if !c.Active() {
+		return fmt.Errorf("%w: %s (%s) since %s", ErrWithdrawn, code, c.Name(EN), c.Withdrawn)
+	}
+	return nil
+}
+
+// All returns every currency sorted by code.
+func (r *Registry) All() []Currency {
+	return append([]Currency(nil), r.all...)
+}
+
+// Active returns the active currencies sorted by code.
+func (r *Registry) Active() []Currency {
+	return r.filter(Currency.Active)
+}
+
+// Historic returns the withdrawn currencies sorted by code.
+func (r *Registry) Historic() []Currency {
+	return r.filter(func(c Currency) bool { return !c.Active() })
+}
+
+func (r *Registry) filter(keep func(Currency) bool) []Currency {
+	var out []Currency
+	for _, c := range r.all {
+		if keep(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ---------------------------------------------------------
+// 3. EMBEDDED DEFAULT
+// ---------------------------------------------------------
+
+//go:embed iso4217.csv
+var iso4217 string
+
+// Default returns the registry built from the embedded iso4217.csv. It
+// is parsed once, on first use.
+var Default = sync.OnceValue(func() *Registry {
+	reg, err := Load(strings.NewReader(iso4217))
+	if err != nil {
+		panic(err) // the embedded file is part of the source; a bad edit is a bug
+	}
+	return reg
+})
+
+// Lookup calls Default().Lookup.
+func Lookup(code string) (Currency, bool) { return Default().Lookup(code) }
+
+// LookupNumeric calls Default().LookupNumeric.
+func LookupNumeric(n int) (Currency, bool) { return Default().LookupNumeric(n) }
+
+// Validate calls Default().Validate.
+func Validate(code string) error { return Default().Validate(code) }
@@ -0,0 +1,44 @@
+package currency
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want error
+	}{
+		{"UAH", nil},
+		{"uah", nil},
+		{"Eur", nil},
+		{"XCG", nil},
+		{"ANG", ErrWithdrawn},
+		{"bgn", ErrWithdrawn},
+		{"QQQ", ErrUnknown},
+		{"qqq", ErrUnknown},
+		{"US", ErrInvalidCode},
+		{"US1", ErrInvalidCode},
+		{"", ErrInvalidCode},
+	} {
+		if err := Validate(tc.code); !errors.Is(err, tc.want) {
+			t.Errorf("Validate(%q) = %v, want %v", tc.code, err, tc.want)
+		}
+	}
+}
+
+// TestCaribbeanGuilder checks the 2025 switch: XCG took over numeric
+// code 532 from ANG.
+func TestCaribbeanGuilder(t *testing.T) {
+	c, ok := Lookup("xcg")
+	if !ok || !c.Active() || c.MinorUnits != 2 {
+		t.Fatalf("Lookup(xcg) = %+v, %t; want an active currency with 2 minor digits", c, ok)
+	}
+	if n, _ := LookupNumeric(532); n.Code != "XCG" {
+		t.Errorf("LookupNumeric(532) = %s, want XCG", n.Code)
+	}
+	if ang, _ := Lookup("ANG"); ang.Active() {
+		t.Error("ANG is still active")
+	}
+}
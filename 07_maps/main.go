@@ -22,6 +22,9 @@ func main() {
 		"UAH": "Ukrainian Hryvnia", // Note the trailing comma!
 	}
 
+	// Three hand-typed codes are fine for a lesson; ./currency embeds the
+	// full ISO 4217 list with numeric codes and minor units.
+
 	fmt.Println("Initial currencies:", currencies)
 
 	// 2. ADDING & UPDATING KEYS
@@ -29,6 +32,8 @@ func main() {
 	// If the key exists, the value is overwritten.
 	userRoles["admin"] = "Super User"
 	userRoles["editor"] = "Content Manager"
+	// A role label is only the start: ./rbac turns roles into permission
+	// sets with inheritance and explains every access decision.
 
 	fmt.Println("User Roles:", userRoles)
 
@@ -58,6 +63,8 @@ func main() {
 
 	// If you delete a key that doesn't exist, nothing happens (no error).
 	delete(currencies, "NOT_EXISTING") // Safe operation
+	// To see exactly what changed between two versions of a map, use
+	// maputil.Diff from ./maputil.
 }
 
 // ---------------------------------------------------------
@@ -79,7 +86,14 @@ func main() {
 // 2. Random Iteration Order:
 //    When you loop over a map using "range", the order is NOT guaranteed.
 //    It is randomized intentionally by Go to prevent developers from relying on order.
+//    If you need a stable order, sort the keys first or use ./orderedmap.
 //
 // 3. Maps are Reference Types:
 //    If you pass a map to a function and modify it inside that function,
 //    the changes persist in the original map.
+//
+// 4. Maps are NOT safe for concurrent use:
+//    Reading and writing the same map from several goroutines without a lock
+//    is a data race. The runtime may even crash with
+//    "fatal error: concurrent map writes".
+//    Guard the map with a sync.Mutex, use sync.Map, or use ./syncmap.
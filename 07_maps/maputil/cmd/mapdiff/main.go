@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ViKing-py/lets-go-in-go/07_maps/maputil"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Diffing, Merging and Printing Maps
+// ---------------------------------------------------------
+// Usage:
+//   go run ./07_maps/maputil/cmd/mapdiff
+//
+// Run it twice: every line of output is identical, even though Go
+// randomizes map iteration order.
+
+func main() {
+	// 1. DIFF: the lesson's currencies before and after some edits.
+	before := map[string]string{
+		"USD": "US Dollar",
+		"EUR": "Euro",
+		"UAH": "Ukrainian Hryvnia",
+	}
+	after := maps.Clone(before)
+	delete(after, "USD")
+	after["UAH"] = "Hryvnia"
+	after["GBP"] = "Pound Sterling"
+
+	fmt.Println("--- 1. Diff ---")
+	fmt.Print(maputil.Diff(before, after))
+
+	// 2. MERGE with three strategies.
+	stockA := map[string]int{"apples": 3, "pears": 1}
+	stockB := map[string]int{"apples": 5, "plums": 2}
+	fmt.Println("\n--- 2. Merge ---")
+	fmt.Print("keep left:\n", maputil.Sprint(maputil.Merge(stockA, stockB, maputil.KeepLeft[string, int]())))
+	fmt.Print("keep right:\n", maputil.Sprint(maputil.Merge(stockA, stockB, maputil.KeepRight[string, int]())))
+	sum := func(_ string, l, r int) int { return l + r }
+	fmt.Print("sum:\n", maputil.Sprint(maputil.Merge(stockA, stockB, sum)))
+
+	// 3. NESTED: two JSON configs.
+	base := decode(`{"name":"billing","limits":{"daily":100,"monthly":2000},"tags":["eu"]}`)
+	override := decode(`{"limits":{"daily":250,"burst":10},"tags":["eu","ua"],"debug":true}`)
+
+	fmt.Println("\n--- 3. Nested diff ---")
+	for _, c := range maputil.DiffTree(base, override) {
+		fmt.Println(c)
+	}
+
+	fmt.Println("\n--- 4. Nested merge (right wins, tags concatenated) ---")
+	merged := maputil.MergeTree(base, override, func(path []string, l, r any) any {
+		if strings.Join(path, ".") == "tags" {
+			return slices.Concat(l.([]any), r.([]any)) // never append to a shared slice
+		}
+		return r
+	})
+	if err := maputil.Fprint(os.Stdout, merged); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func decode(s string) map[string]any {
+	var m map[string]any
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		panic(err)
+	}
+	return m
+}
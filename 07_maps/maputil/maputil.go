@@ -0,0 +1,393 @@
+// Package maputil holds the map helpers the 07_maps lesson keeps wishing
+// for:
+//
+//   - Diff: which keys were added, removed or changed between two maps.
+//   - Merge: combine two maps with a pluggable conflict strategy.
+//   - Fprint: print any map with sorted keys, one per line, so output is
+//     stable across runs (safe for logs and golden files).
+//
+// DiffTree and MergeTree do the same for nested maps: map[string]any
+// values, the shape encoding/json produces, and typed ones such as
+// map[string]map[string]int, recursing into every sub-map.
+package maputil
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ---------------------------------------------------------
+// 1. DIFF
+// ---------------------------------------------------------
+
+// Entry is one key with its value before (Old) and after (New). For an
+// added key Old is the zero value; for a removed key New is.
+type Entry[K comparable, V any] struct {
+	Key K
+	Old V
+	New V
+}
+
+// Changes is the result of Diff. Each slice is sorted by key.
+type Changes[K cmp.Ordered, V any] struct {
+	Added   []Entry[K, V]
+	Removed []Entry[K, V]
+	Changed []Entry[K, V]
+}
+
+// Empty reports whether the two maps were equal.
+func (c Changes[K, V]) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// String renders one line per entry, prefixed "+" (added), "-" (removed)
+// or "~" (changed), e.g. "~ UAH: Ukrainian Hryvnia -> Hryvnia".
+func (c Changes[K, V]) String() string {
+	var b strings.Builder
+	for _, e := range c.Added {
+		fmt.Fprintf(&b, "+ %v: %v\n", e.Key, e.New)
+	}
+	for _, e := range c.Removed {
+		fmt.Fprintf(&b, "- %v: %v\n", e.Key, e.Old)
+	}
+	for _, e := range c.Changed {
+		fmt.Fprintf(&b, "~ %v: %v -> %v\n", e.Key, e.Old, e.New)
+	}
+	return b.String()
+}
+
+// Diff compares old and new with ==.
+func Diff[K cmp.Ordered, V comparable](old, new map[K]V) Changes[K, V] {
+	return DiffFunc(old, new, func(a, b V) bool { return a == b })
+}
+
+// DiffFunc compares old and new with eq, for values that are not
+// comparable (slices, maps) or need a looser notion of equality.
+func DiffFunc[K cmp.Ordered, V any](old, new map[K]V, eq func(a, b V) bool) Changes[K, V] {
+	var c Changes[K, V]
+	for k, ov := range old {
+		nv, ok := new[k]
+		switch {
+		case !ok:
+			c.Removed = append(c.Removed, Entry[K, V]{Key: k, Old: ov})
+		case !eq(ov, nv):
+			c.Changed = append(c.Changed, Entry[K, V]{Key: k, Old: ov, New: nv})
+		}
+	}
+	for k, nv := range new {
+		if _, ok := old[k]; !ok {
+			c.Added = append(c.Added, Entry[K, V]{Key: k, New: nv})
+		}
+	}
+	// Map iteration order is random; sort so the result is deterministic.
+	byKey := func(a, b Entry[K, V]) int { return cmp.Compare(a.Key, b.Key) }
+	slices.SortFunc(c.Added, byKey)
+	slices.SortFunc(c.Removed, byKey)
+	slices.SortFunc(c.Changed, byKey)
+	return c
+}
+
+// ---------------------------------------------------------
+// 2. MERGE
+// ---------------------------------------------------------
+
+// Strategy resolves a key present in both maps.
+type Strategy[K comparable, V any] func(key K, left, right V) V
+
+// KeepLeft keeps the value from the first map.
+func KeepLeft[K comparable, V any]() Strategy[K, V] {
+	return func(_ K, left, _ V) V { return left }
+}
+
+// KeepRight keeps the value from the second map (what a plain
+// `for k, v := range right { out[k] = v }` does).
+func KeepRight[K comparable, V any]() Strategy[K, V] {
+	return func(_ K, _, right V) V { return right }
+}
+
+// Merge returns a new map with every key of left and right. Keys in
+// both are resolved with resolve; a nil resolve means KeepRight. Neither
+// input is modified.
+func Merge[K comparable, V any](left, right map[K]V, resolve Strategy[K, V]) map[K]V {
+	if resolve == nil {
+		resolve = KeepRight[K, V]()
+	}
+	out := make(map[K]V, max(len(left), len(right)))
+	for k, v := range left {
+		out[k] = v
+	}
+	for k, rv := range right {
+		if lv, ok := out[k]; ok {
+			out[k] = resolve(k, lv, rv)
+		} else {
+			out[k] = rv
+		}
+	}
+	return out
+}
+
+// ---------------------------------------------------------
+// 3. NESTED MAPS
+// ---------------------------------------------------------
+
+// Kind classifies a Change.
+type Kind int
+
+const (
+	Added Kind = iota
+	Removed
+	Changed
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	case Changed:
+		return "~"
+	}
+	return "?"
+}
+
+// Change is one leaf difference in a nested map. Path holds the keys
+// from the root, e.g. ["limits", "daily"].
+type Change struct {
+	Kind Kind
+	Path []string
+	Old  any
+	New  any
+}
+
+func (c Change) String() string {
+	p := strings.Join(c.Path, ".")
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+ %s: %v", p, c.New)
+	case Removed:
+		return fmt.Sprintf("- %s: %v", p, c.Old)
+	}
+	return fmt.Sprintf("~ %s: %v -> %v", p, c.Old, c.New)
+}
+
+// DiffTree compares two nested maps. When a key holds a map on both
+// sides the comparison recurses; anything else is compared with
+// reflect.DeepEqual and reported as a single change. Changes are in
+// sorted path order.
+//
+// Any map value counts as a sub-map, not just map[string]any: a
+// map[string]map[string]int recurses too, as long as both sides' maps
+// have the same key type. Non-string keys appear in Path as printed by
+// fmt.Sprint.
+func DiffTree(old, new map[string]any) []Change {
+	var out []Change
+	diffTree(nil, reflect.ValueOf(old), reflect.ValueOf(new), &out)
+	return out
+}
+
+func diffTree(path []string, old, new reflect.Value, out *[]Change) {
+	keys := old.MapKeys()
+	for _, k := range new.MapKeys() {
+		if !old.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	slices.SortFunc(keys, compareKeys)
+
+	for _, k := range keys {
+		p := append(slices.Clip(path), fmt.Sprint(k)) // Clip: siblings must not share a backing array
+		ov, nv := old.MapIndex(k), new.MapIndex(k)
+		switch {
+		case !ov.IsValid():
+			*out = append(*out, Change{Kind: Added, Path: p, New: nv.Interface()})
+		case !nv.IsValid():
+			*out = append(*out, Change{Kind: Removed, Path: p, Old: ov.Interface()})
+		default:
+			if om, nm, ok := subMaps(ov, nv); ok {
+				diffTree(p, om, nm, out)
+			} else if !reflect.DeepEqual(ov.Interface(), nv.Interface()) {
+				*out = append(*out, Change{Kind: Changed, Path: p, Old: ov.Interface(), New: nv.Interface()})
+			}
+		}
+	}
+}
+
+// subMaps unwraps two map values held in interfaces and reports whether
+// both are maps with the same key type, so one's keys can index the
+// other.
+func subMaps(a, b reflect.Value) (reflect.Value, reflect.Value, bool) {
+	a, b = unwrap(a), unwrap(b)
+	ok := a.Kind() == reflect.Map && b.Kind() == reflect.Map && a.Type().Key() == b.Type().Key()
+	return a, b, ok
+}
+
+func unwrap(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v
+}
+
+// MergeTree merges nested maps. Where both sides hold a map of the same
+// type the merge recurses, whatever that type is; any other conflict is
+// passed to resolve with its path (nil resolve keeps the right value).
+// Inside a typed sub-map such as map[string]int, resolve must return a
+// value of the element type (or nil for its zero value). The inputs are
+// not modified.
+func MergeTree(left, right map[string]any, resolve func(path []string, left, right any) any) map[string]any {
+	if resolve == nil {
+		resolve = func(_ []string, _, r any) any { return r }
+	}
+	return mergeTree(nil, reflect.ValueOf(left), reflect.ValueOf(right), resolve).Interface().(map[string]any)
+}
+
+func mergeTree(path []string, left, right reflect.Value, resolve func([]string, any, any) any) reflect.Value {
+	out := reflect.MakeMapWithSize(left.Type(), max(left.Len(), right.Len()))
+	iter := left.MapRange()
+	for iter.Next() {
+		out.SetMapIndex(iter.Key(), cloneTree(iter.Value()))
+	}
+	iter = right.MapRange()
+	for iter.Next() {
+		k, rv := iter.Key(), iter.Value()
+		lv := out.MapIndex(k)
+		if !lv.IsValid() {
+			out.SetMapIndex(k, cloneTree(rv))
+			continue
+		}
+		p := append(slices.Clip(path), fmt.Sprint(k))
+		if lm, rm, ok := subMaps(lv, rv); ok && lm.Type() == rm.Type() {
+			out.SetMapIndex(k, mergeTree(p, lm, rm, resolve))
+			continue
+		}
+		v := reflect.ValueOf(resolve(p, lv.Interface(), rv.Interface()))
+		elem := out.Type().Elem()
+		switch {
+		case !v.IsValid():
+			v = reflect.Zero(elem) // SetMapIndex would delete the key
+		case !v.Type().AssignableTo(elem):
+			panic(fmt.Sprintf("maputil: MergeTree: resolve returned %s at %s, want %s",
+				v.Type(), strings.Join(p, "."), elem))
+		}
+		out.SetMapIndex(k, v)
+	}
+	return out
+}
+
+// cloneTree copies nested maps, of any map type, so the result never
+// aliases an input. Other values (including slices) are shared.
+func cloneTree(v reflect.Value) reflect.Value {
+	m := unwrap(v)
+	if m.Kind() != reflect.Map || m.IsNil() {
+		return v
+	}
+	out := reflect.MakeMapWithSize(m.Type(), m.Len())
+	iter := m.MapRange()
+	for iter.Next() {
+		out.SetMapIndex(iter.Key(), cloneTree(iter.Value()))
+	}
+	return out
+}
+
+// ---------------------------------------------------------
+// 4. DETERMINISTIC PRINTING
+// ---------------------------------------------------------
+
+// Fprint writes m with one "key: value" per line, keys sorted, nested
+// maps indented by two spaces. m may be any map type (or a pointer to
+// one); a nil map prints nothing.
+//
+// fmt's %v also sorts map keys, but puts everything on one line, which
+// is hard to read and diff once maps nest.
+func Fprint(w io.Writer, m any) error {
+	v := reflect.ValueOf(m)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Map {
+		return fmt.Errorf("maputil: Fprint of %T, want a map", m)
+	}
+	var b strings.Builder
+	printMap(&b, v, "")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Sprint is Fprint into a string.
+func Sprint(m any) string {
+	var b strings.Builder
+	if err := Fprint(&b, m); err != nil {
+		return err.Error()
+	}
+	return b.String()
+}
+
+func printMap(b *strings.Builder, m reflect.Value, indent string) {
+	keys := m.MapKeys()
+	slices.SortFunc(keys, compareKeys)
+	for _, k := range keys {
+		v := m.MapIndex(k)
+		for v.Kind() == reflect.Interface && !v.IsNil() {
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Map {
+			fmt.Fprintf(b, "%s%v:\n", indent, k)
+			printMap(b, v, indent+"  ")
+			continue
+		}
+		fmt.Fprintf(b, "%s%v: %v\n", indent, k, v)
+	}
+}
+
+// compareKeys orders numbers numerically, strings lexically and bools
+// false first. Keys of different kinds (1 and "1" in a map[any]V) are
+// ordered by kind, then by type name, so no two distinct keys compare
+// equal and the order never depends on map iteration. Other key types
+// fall back to comparing their printed form.
+func compareKeys(a, b reflect.Value) int {
+	for a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	for b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+	if c := cmp.Compare(a.Kind(), b.Kind()); c != 0 || !a.IsValid() {
+		return c // !a.IsValid(): both are the nil interface
+	}
+	c := 0
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c = cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		c = cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		c = cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		c = cmp.Compare(a.String(), b.String())
+	case reflect.Bool:
+		c = cmp.Compare(boolInt(a.Bool()), boolInt(b.Bool()))
+	default:
+		c = cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+	if c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Type().String(), b.Type().String()); c != 0 {
+		return c
+	}
+	// Same type and printed form: only values such as structs holding
+	// 1 and "1" get this far, and %#v tells those apart.
+	return cmp.Compare(fmt.Sprintf("%#v", a), fmt.Sprintf("%#v", b))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,205 @@
+package maputil
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	before := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	after := map[string]int{"a": 1, "b": 20, "d": 40, "e": 5, "f": 6}
+	c := Diff(before, after)
+	want := Changes[string, int]{
+		Added:   []Entry[string, int]{{Key: "e", New: 5}, {Key: "f", New: 6}},
+		Removed: []Entry[string, int]{{Key: "c", Old: 3}},
+		Changed: []Entry[string, int]{{"b", 2, 20}, {"d", 4, 40}},
+	}
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("Diff = %+v, want %+v", c, want)
+	}
+	if got, want := c.String(), "+ e: 5\n+ f: 6\n- c: 3\n~ b: 2 -> 20\n~ d: 4 -> 40\n"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+	if !Diff(before, before).Empty() || c.Empty() {
+		t.Error("Empty is wrong")
+	}
+
+	eq := func(a, b []int) bool { return len(a) == len(b) }
+	if c := DiffFunc(map[int][]int{1: {1}}, map[int][]int{1: {2}}, eq); !c.Empty() {
+		t.Errorf("DiffFunc with a loose eq = %+v, want no changes", c)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	left := map[string]int{"a": 1, "b": 2}
+	right := map[string]int{"b": 20, "c": 3}
+	for _, tt := range []struct {
+		name    string
+		resolve Strategy[string, int]
+		want    int
+	}{
+		{"nil", nil, 20},
+		{"left", KeepLeft[string, int](), 2},
+		{"right", KeepRight[string, int](), 20},
+		{"sum", func(_ string, l, r int) int { return l + r }, 22},
+	} {
+		got := Merge(left, right, tt.resolve)
+		if want := map[string]int{"a": 1, "b": tt.want, "c": 3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Merge(%s) = %v, want %v", tt.name, got, want)
+		}
+	}
+	if left["b"] != 2 || len(left) != 2 || len(right) != 2 {
+		t.Error("Merge modified its inputs")
+	}
+}
+
+func decode(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func changeLines(cs []Change) string {
+	var b strings.Builder
+	for _, c := range cs {
+		b.WriteString(c.String() + "\n")
+	}
+	return b.String()
+}
+
+func TestDiffTree(t *testing.T) {
+	base := decode(t, `{"name":"billing","limits":{"daily":100,"monthly":2000},"tags":["eu"],"owner":{"team":"pay"}}`)
+	override := decode(t, `{"limits":{"daily":250,"burst":10},"tags":["eu","ua"],"owner":"pay","debug":true}`)
+	want := "+ debug: true\n" +
+		"+ limits.burst: 10\n" +
+		"~ limits.daily: 100 -> 250\n" +
+		"- limits.monthly: 2000\n" +
+		"- name: billing\n" +
+		"~ owner: map[team:pay] -> pay\n" +
+		"~ tags: [eu] -> [eu ua]\n"
+	if got := changeLines(DiffTree(base, override)); got != want {
+		t.Errorf("DiffTree =\n%s\nwant\n%s", got, want)
+	}
+	if got := DiffTree(base, base); len(got) != 0 {
+		t.Errorf("DiffTree of equal trees = %v", got)
+	}
+}
+
+func TestDiffTreeTyped(t *testing.T) {
+	old := map[string]any{
+		"limits": map[string]map[string]int{
+			"eu": {"daily": 100, "burst": 5},
+			"us": {"daily": 50},
+		},
+		"ports": map[int]string{80: "http", 443: "https"},
+	}
+	new := map[string]any{
+		"limits": map[string]map[string]int{
+			"eu": {"daily": 100, "burst": 10},
+			"ua": {"daily": 70},
+		},
+		"ports": map[int]string{443: "https", 8080: "alt"},
+	}
+	want := "~ limits.eu.burst: 5 -> 10\n" +
+		"+ limits.ua: map[daily:70]\n" +
+		"- limits.us: map[daily:50]\n" +
+		"- ports.80: http\n" +
+		"+ ports.8080: alt\n"
+	if got := changeLines(DiffTree(old, new)); got != want {
+		t.Errorf("DiffTree =\n%s\nwant\n%s", got, want)
+	}
+
+	// A typed map against a JSON-shaped one still recurses: the keys agree.
+	mixed := map[string]any{"limits": map[string]any{"eu": map[string]any{"daily": 100, "burst": 5}}}
+	typed := map[string]any{"limits": map[string]map[string]int{"eu": {"daily": 100, "burst": 5}}}
+	if got := DiffTree(mixed, typed); len(got) != 0 {
+		t.Errorf("DiffTree(any, typed) = %v, want no changes", got)
+	}
+}
+
+func TestMergeTree(t *testing.T) {
+	left := decode(t, `{"name":"billing","limits":{"daily":100,"monthly":2000},"tags":["eu"]}`)
+	right := decode(t, `{"limits":{"daily":250,"burst":10},"tags":["ua"],"debug":true}`)
+	var paths []string
+	merged := MergeTree(left, right, func(path []string, l, r any) any {
+		paths = append(paths, strings.Join(path, "."))
+		if path[0] == "tags" {
+			return append(append([]any{}, l.([]any)...), r.([]any)...)
+		}
+		return l
+	})
+	want := decode(t, `{"name":"billing","limits":{"daily":100,"monthly":2000,"burst":10},"tags":["eu","ua"],"debug":true}`)
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("MergeTree = %v, want %v", merged, want)
+	}
+	if len(paths) != 2 {
+		t.Errorf("resolve called for %v, want limits.daily and tags", paths)
+	}
+
+	merged["limits"].(map[string]any)["daily"] = 0
+	if left["limits"].(map[string]any)["daily"] != 100.0 {
+		t.Error("MergeTree result aliases the left input")
+	}
+
+	if got := MergeTree(left, right, nil)["limits"].(map[string]any)["daily"]; got != 250.0 {
+		t.Errorf("nil resolve kept %v, want the right value 250", got)
+	}
+}
+
+func TestMergeTreeTyped(t *testing.T) {
+	left := map[string]any{"stock": map[string]map[string]int{
+		"kyiv": {"apples": 3, "pears": 1},
+	}}
+	right := map[string]any{"stock": map[string]map[string]int{
+		"kyiv": {"apples": 5},
+		"lviv": {"plums": 2},
+	}}
+	sum := func(_ []string, l, r any) any { return l.(int) + r.(int) }
+	merged := MergeTree(left, right, sum)
+	want := map[string]any{"stock": map[string]map[string]int{
+		"kyiv": {"apples": 8, "pears": 1},
+		"lviv": {"plums": 2},
+	}}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("MergeTree = %v, want %v", merged, want)
+	}
+	merged["stock"].(map[string]map[string]int)["lviv"]["plums"] = 0
+	if right["stock"].(map[string]map[string]int)["lviv"]["plums"] != 2 {
+		t.Error("MergeTree result aliases the right input")
+	}
+
+	zero := MergeTree(left, right, func([]string, any, any) any { return nil })
+	if got, ok := zero["stock"].(map[string]map[string]int)["kyiv"]["apples"]; !ok || got != 0 {
+		t.Errorf("resolve returning nil stored %d, %v, want 0, true", got, ok)
+	}
+}
+
+func TestSprint(t *testing.T) {
+	type pair struct{ A any }
+	m := map[any]int{
+		1: 1, "1": 2, int8(1): 3, true: 4, nil: 5, 2.5: 6, "a": 7, -3: 8,
+		pair{1}: 9, pair{"1"}: 10,
+	}
+	want := Sprint(m)
+	for range 50 {
+		if got := Sprint(m); got != want {
+			t.Fatalf("Sprint changed between runs:\n%s\nthen\n%s", want, got)
+		}
+	}
+	if !strings.HasPrefix(want, "<nil>: 5\ntrue: 4\n-3: 8\n1: 1\n1: 3\n2.5: 6\n1: 2\na: 7\n") {
+		t.Errorf("Sprint order:\n%s", want)
+	}
+
+	nested := map[string]any{"b": map[int]string{10: "x", 9: "y"}, "a": 1}
+	if got, want := Sprint(nested), "a: 1\nb:\n  9: y\n  10: x\n"; got != want {
+		t.Errorf("Sprint(nested) = %q, want %q", got, want)
+	}
+	if got := Sprint(42); !strings.Contains(got, "want a map") {
+		t.Errorf("Sprint(42) = %q, want an error", got)
+	}
+}
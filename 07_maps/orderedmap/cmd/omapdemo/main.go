@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ViKing-py/lets-go-in-go/07_maps/orderedmap"
+)
+
+// ---------------------------------------------------------
+// TOPIC: A Map That Keeps Insertion Order
+// ---------------------------------------------------------
+// Usage:
+//   go run ./07_maps/orderedmap/cmd/omapdemo
+//   go test -bench . -benchmem ./07_maps/orderedmap
+//
+// Runs the lesson's currencies through an OrderedMap: printing, moving,
+// deleting and a JSON round trip all keep the order. The benchmarks in
+// orderedmap_test.go compare it with sorting the keys of a plain map.
+
+func main() {
+	currencies := orderedmap.New[string, string]()
+	currencies.Set("USD", "US Dollar")
+	currencies.Set("EUR", "Euro")
+	currencies.Set("UAH", "Ukrainian Hryvnia")
+	fmt.Println("Initial currencies:", currencies) // always USD, EUR, UAH
+
+	currencies.MoveToFront("UAH")
+	currencies.Delete("USD")
+	fmt.Println("After MoveToFront(UAH) + Delete(USD):", currencies)
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.Encode(currencies) // {"UAH":"Ukrainian Hryvnia","EUR":"Euro"}
+
+	var back orderedmap.OrderedMap[string, string]
+	if err := json.Unmarshal([]byte(`{"zloty":"PLN","euro":"EUR","dollar":"USD"}`), &back); err != nil {
+		fmt.Println("unmarshal:", err)
+		return
+	}
+	fmt.Println("Decoded in file order:", &back)
+}
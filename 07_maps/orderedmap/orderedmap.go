@@ -0,0 +1,362 @@
+// Package orderedmap provides OrderedMap, a map that remembers insertion
+// order. It answers PITFALL 2 of 07_maps ("Random Iteration Order"):
+// ranging over an OrderedMap, printing it or encoding it to JSON always
+// lists keys in the order they were first set.
+//
+// Get, Set and Delete are O(1): a built-in map points into a doubly
+// linked list that holds the order.
+package orderedmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *entry[K, V]
+}
+
+// OrderedMap is a map with a stable, insertion-based iteration order.
+// The zero value is an empty map ready to use. Unlike a built-in map it
+// must not be copied after first use (pass *OrderedMap around instead),
+// and it is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	index map[K]*entry[K, V]
+	// root is a sentinel: root.next is the first entry, root.prev the last.
+	// Using a sentinel removes every "is this the head?" special case.
+	root entry[K, V]
+}
+
+// New returns an empty OrderedMap.
+func New[K comparable, V any]() *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{}
+	m.lazyInit()
+	return m
+}
+
+func (m *OrderedMap[K, V]) lazyInit() {
+	if m.index == nil {
+		m.index = make(map[K]*entry[K, V])
+		m.root.next = &m.root
+		m.root.prev = &m.root
+	}
+}
+
+// Len returns the number of keys.
+func (m *OrderedMap[K, V]) Len() int { return len(m.index) }
+
+// Get returns the value for key and whether it was present — the same
+// "comma ok" idiom as a built-in map.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, ok := m.index[key]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has reports whether key is present.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.index[key]
+	return ok
+}
+
+// Set stores value under key. A new key goes to the back; updating an
+// existing key keeps its position. It reports whether the key was new.
+func (m *OrderedMap[K, V]) Set(key K, value V) bool {
+	m.lazyInit()
+	if e, ok := m.index[key]; ok {
+		e.value = value
+		return false
+	}
+	e := &entry[K, V]{key: key, value: value}
+	m.insertBefore(e, &m.root)
+	m.index[key] = e
+	return true
+}
+
+// Delete removes key and reports whether it was present. Like the
+// built-in delete, removing a missing key is a no-op.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := m.index[key]
+	if !ok {
+		return false
+	}
+	m.unlink(e)
+	delete(m.index, key)
+	return true
+}
+
+// MoveToFront makes key the first in iteration order.
+// It reports false if key is not present.
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	e, ok := m.index[key]
+	if !ok {
+		return false
+	}
+	m.unlink(e)
+	m.insertBefore(e, m.root.next)
+	return true
+}
+
+// MoveToBack makes key the last in iteration order.
+// It reports false if key is not present.
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	e, ok := m.index[key]
+	if !ok {
+		return false
+	}
+	m.unlink(e)
+	m.insertBefore(e, &m.root)
+	return true
+}
+
+// Front returns the first key and value.
+func (m *OrderedMap[K, V]) Front() (K, V, bool) {
+	return m.edge(m.root.next)
+}
+
+// Back returns the last key and value.
+func (m *OrderedMap[K, V]) Back() (K, V, bool) {
+	return m.edge(m.root.prev)
+}
+
+func (m *OrderedMap[K, V]) edge(e *entry[K, V]) (K, V, bool) {
+	if m.Len() == 0 {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return e.key, e.value, true
+}
+
+func (m *OrderedMap[K, V]) insertBefore(e, at *entry[K, V]) {
+	e.prev = at.prev
+	e.next = at
+	at.prev.next = e
+	at.prev = e
+}
+
+func (m *OrderedMap[K, V]) unlink(e *entry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev, e.next = nil, nil
+}
+
+// ---------------------------------------------------------
+// ITERATION
+// ---------------------------------------------------------
+
+// All iterates keys and values in order. Deleting the current key during
+// iteration is allowed; other modifications give unspecified results.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.Len() == 0 {
+			return
+		}
+		for e := m.root.next; e != &m.root; {
+			next := e.next // saved first, so yield may delete e
+			if !yield(e.key, e.value) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Backward iterates keys and values in reverse order.
+func (m *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.Len() == 0 {
+			return
+		}
+		for e := m.root.prev; e != &m.root; {
+			prev := e.prev
+			if !yield(e.key, e.value) {
+				return
+			}
+			e = prev
+		}
+	}
+}
+
+// Keys iterates the keys in order.
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values iterates the values in key order.
+func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// String formats the map like fmt prints a built-in map, but in
+// insertion order: map[USD:US Dollar EUR:Euro].
+func (m *OrderedMap[K, V]) String() string {
+	var b strings.Builder
+	b.WriteString("map[")
+	first := true
+	for k, v := range m.All() {
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		fmt.Fprintf(&b, "%v:%v", k, v)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// ---------------------------------------------------------
+// JSON
+// ---------------------------------------------------------
+// Keys follow encoding/json's rules for map keys: strings, integer types
+// and types implementing encoding.TextMarshaler / TextUnmarshaler.
+
+// MarshalJSON encodes the map as a JSON object with keys in order. It has
+// a value receiver so that an OrderedMap stored by value, say as a struct
+// field, encodes as an object too, not as {} from its unexported fields.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	// m is a copy, so the list ends at the original's root, not at
+	// &m.root, and All would never stop. Walk Len entries instead.
+	e := m.root.next
+	for i := range len(m.index) {
+		k, v := e.key, e.value
+		e = e.next
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		ks, err := keyToString(k)
+		if err != nil {
+			return nil, err
+		}
+		kb, _ := json.Marshal(ks)
+		buf.Write(kb)
+		buf.WriteByte(':')
+		vb, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("orderedmap: key %q: %w", ks, err)
+		}
+		buf.Write(vb)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON replaces the contents of m with a JSON object, keeping
+// the object's key order. A repeated key keeps its first position and
+// its last value, matching how encoding/json fills a map.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil { // JSON null leaves the map untouched, like encoding/json
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("orderedmap: expected JSON object, got %v", tok)
+	}
+
+	fresh := New[K, V]()
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := keyFromString[K](tok.(string))
+		if err != nil {
+			return err
+		}
+		var v V
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("orderedmap: key %q: %w", tok, err)
+		}
+		fresh.Set(key, v)
+	}
+	if _, err := dec.Token(); err != nil { // closing '}'
+		return err
+	}
+	*m = OrderedMap[K, V]{}
+	m.lazyInit()
+	for k, v := range fresh.All() {
+		m.Set(k, v)
+	}
+	return nil
+}
+
+// keyToString and keyFromString follow encoding/json's precedence for
+// map keys, which differs by direction: a string kind is written as is
+// even if it has MarshalText, but UnmarshalText wins when reading.
+func keyToString[K comparable](k K) (string, error) {
+	rv := reflect.ValueOf(k)
+	if rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+	if tm, ok := any(k).(encoding.TextMarshaler); ok {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return "", nil
+		}
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("orderedmap: unsupported JSON key type %T", k)
+}
+
+func keyFromString[K comparable](s string) (K, error) {
+	var k K
+	if tu, ok := any(&k).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(s))
+		return k, err
+	}
+	rv := reflect.ValueOf(&k).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+		return k, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return k, fmt.Errorf("orderedmap: key %q: %w", s, err)
+		}
+		rv.SetInt(n)
+		return k, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return k, fmt.Errorf("orderedmap: key %q: %w", s, err)
+		}
+		rv.SetUint(n)
+		return k, nil
+	}
+	return k, fmt.Errorf("orderedmap: unsupported JSON key type %T", k)
+}
@@ -0,0 +1,291 @@
+package orderedmap
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func keysOf[K comparable, V any](m *OrderedMap[K, V]) []K {
+	return slices.Collect(m.Keys())
+}
+
+func TestInsertionOrder(t *testing.T) {
+	var m OrderedMap[string, int] // the zero value is ready to use
+	for i, k := range []string{"USD", "EUR", "UAH", "PLN", "GBP"} {
+		if !m.Set(k, i) {
+			t.Errorf("Set(%q) on a new key = false", k)
+		}
+	}
+	if m.Set("EUR", 10) { // updating keeps the position
+		t.Error("Set on an existing key = true")
+	}
+	check := func(step string, want ...string) {
+		t.Helper()
+		if got := keysOf(&m); !slices.Equal(got, want) {
+			t.Errorf("after %s: keys = %v, want %v", step, got, want)
+		}
+		var back []string
+		for k := range m.Backward() {
+			back = append(back, k)
+		}
+		slices.Reverse(back)
+		if !slices.Equal(back, want) {
+			t.Errorf("after %s: reversed Backward = %v, want %v", step, back, want)
+		}
+		if m.Len() != len(want) {
+			t.Errorf("after %s: Len = %d, want %d", step, m.Len(), len(want))
+		}
+	}
+	check("Set", "USD", "EUR", "UAH", "PLN", "GBP")
+	if v, _ := m.Get("EUR"); v != 10 {
+		t.Errorf("Get(EUR) = %d, want 10", v)
+	}
+
+	m.Delete("UAH")
+	m.Set("UAH", 2) // back in, at the end
+	check("Delete and Set", "USD", "EUR", "PLN", "GBP", "UAH")
+	m.MoveToFront("GBP")
+	m.MoveToBack("USD")
+	check("MoveToFront and MoveToBack", "GBP", "EUR", "PLN", "UAH", "USD")
+	if k, _, _ := m.Front(); k != "GBP" {
+		t.Errorf("Front = %q, want GBP", k)
+	}
+	if k, _, _ := m.Back(); k != "USD" {
+		t.Errorf("Back = %q, want USD", k)
+	}
+
+	for k := range m.All() { // deleting the current key is allowed
+		if k != "PLN" {
+			m.Delete(k)
+		}
+	}
+	check("deleting during All", "PLN")
+	if got, want := m.String(), "map[PLN:3]"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	const in = `{"zloty":"PLN","euro":"EUR","dollar":"USD","hryvnia":"UAH"}`
+	var m OrderedMap[string, string]
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := keysOf(&m), []string{"zloty", "euro", "dollar", "hryvnia"}; !slices.Equal(got, want) {
+		t.Errorf("decoded keys = %v, want %v", got, want)
+	}
+	out, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("Marshal(&m) = %s, want %s", out, in)
+	}
+
+	// A map held by value, as a struct field or in a slice, encodes the
+	// same as through a pointer.
+	type doc struct {
+		Rates OrderedMap[string, string] `json:"rates"`
+	}
+	var d doc
+	if err := json.Unmarshal([]byte(`{"rates":`+in+`}`), &d); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []any{d, []OrderedMap[string, string]{d.Rates}} {
+		out, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(out), in) {
+			t.Errorf("Marshal(%T) = %s, want it to contain %s", v, out, in)
+		}
+	}
+}
+
+func TestJSONKeys(t *testing.T) {
+	m := New[int, bool]()
+	m.Set(30, true)
+	m.Set(-2, false)
+	m.Set(100, true)
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"30":true,"-2":false,"100":true}`; string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+	back := New[int, bool]()
+	if err := json.Unmarshal(out, back); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := keysOf(back), []int{30, -2, 100}; !slices.Equal(got, want) {
+		t.Errorf("decoded keys = %v, want %v", got, want)
+	}
+	if err := json.Unmarshal([]byte(`{"x":true}`), back); err == nil {
+		t.Error("Unmarshal of a non-integer key into OrderedMap[int, bool] succeeded")
+	}
+	if _, err := json.Marshal(New[float64, int]()); err != nil {
+		t.Errorf("Marshal of an empty map with an unsupported key type: %v", err)
+	}
+	bad := New[float64, int]()
+	bad.Set(1.5, 1)
+	if _, err := json.Marshal(bad); err == nil {
+		t.Error("Marshal with float64 keys succeeded")
+	}
+}
+
+// shout is a string key whose text form is upper case.
+type shout string
+
+func (s shout) MarshalText() ([]byte, error) { return []byte(strings.ToUpper(string(s))), nil }
+
+func (s *shout) UnmarshalText(b []byte) error {
+	*s = shout(strings.ToLower(string(b)))
+	return nil
+}
+
+// TestJSONKeyPrecedence checks the encoding/json rules for a key with
+// both a string kind and text methods: "keys of any string type are used
+// directly" when writing, while UnmarshalText wins when reading.
+func TestJSONKeyPrecedence(t *testing.T) {
+	m := New[shout, int]()
+	m.Set("kyiv", 1)
+	if out, err := json.Marshal(m); err != nil || string(out) != `{"kyiv":1}` {
+		t.Errorf("Marshal = %s, %v; want {\"kyiv\":1}", out, err)
+	}
+	back := New[shout, int]()
+	if err := json.Unmarshal([]byte(`{"LVIV":2}`), back); err != nil {
+		t.Fatal(err)
+	}
+	if got := keysOf(back); !slices.Equal(got, []shout{"lviv"}) {
+		t.Errorf("Unmarshal keys = %q, want [lviv] from UnmarshalText", got)
+	}
+}
+
+func TestJSONEdgeCases(t *testing.T) {
+	var zero OrderedMap[string, int]
+	if out, err := json.Marshal(zero); err != nil || string(out) != "{}" {
+		t.Errorf("Marshal(zero value) = %s, %v; want {}", out, err)
+	}
+	var nilMap *OrderedMap[string, int]
+	if out, err := json.Marshal(nilMap); err != nil || string(out) != "null" {
+		t.Errorf("Marshal(nil pointer) = %s, %v; want null", out, err)
+	}
+
+	m := New[string, int]()
+	m.Set("kept", 1)
+	if err := json.Unmarshal([]byte(`null`), m); err != nil || m.Len() != 1 {
+		t.Errorf("Unmarshal(null) = %v and left %d keys, want the map untouched", err, m.Len())
+	}
+	// A repeated key keeps its first position and its last value.
+	if err := json.Unmarshal([]byte(`{"a":1,"b":2,"a":3}`), m); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m.String(), "map[a:3 b:2]"; got != want {
+		t.Errorf("after repeated key: %s, want %s", got, want)
+	}
+	if err := json.Unmarshal([]byte(`[1,2]`), m); err == nil {
+		t.Error("Unmarshal of an array succeeded")
+	}
+}
+
+// ---------------------------------------------------------
+// BENCHMARKS: OrderedMap vs map + sorted keys
+// ---------------------------------------------------------
+// go test -bench . -benchmem ./07_maps/orderedmap
+//
+// The usual workaround for random map order is to collect and sort the
+// keys every time you print; OrderedMap pays for order on insert instead.
+
+const benchSize = 1_000
+
+var (
+	benchKeys = func() []string {
+		k := make([]string, benchSize)
+		for i := range k {
+			k[i] = "key-" + strconv.Itoa(benchSize-i) // inserted in reverse-sorted order
+		}
+		return k
+	}()
+	sink int
+)
+
+func buildMap() map[string]int {
+	m := make(map[string]int)
+	for i, k := range benchKeys {
+		m[k] = i
+	}
+	return m
+}
+
+func buildOrdered() *OrderedMap[string, int] {
+	m := New[string, int]()
+	for i, k := range benchKeys {
+		m.Set(k, i)
+	}
+	return m
+}
+
+func BenchmarkBuild(b *testing.B) {
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sink += len(buildMap())
+		}
+	})
+	b.Run("OrderedMap", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sink += buildOrdered().Len()
+		}
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	b.Run("map", func(b *testing.B) {
+		m := buildMap()
+		b.ResetTimer()
+		for i := range b.N {
+			sink += m[benchKeys[i%benchSize]]
+		}
+	})
+	b.Run("OrderedMap", func(b *testing.B) {
+		m := buildOrdered()
+		b.ResetTimer()
+		for i := range b.N {
+			v, _ := m.Get(benchKeys[i%benchSize])
+			sink += v
+		}
+	})
+}
+
+func BenchmarkOrderedWalk(b *testing.B) {
+	b.Run("map+sort", func(b *testing.B) {
+		m := buildMap()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			sorted := make([]string, 0, len(m))
+			for k := range m {
+				sorted = append(sorted, k)
+			}
+			slices.Sort(sorted)
+			for _, k := range sorted {
+				sink += m[k]
+			}
+		}
+	})
+	b.Run("OrderedMap", func(b *testing.B) {
+		m := buildOrdered()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			for _, v := range m.All() {
+				sink += v
+			}
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ViKing-py/lets-go-in-go/07_maps/rbac"
+)
+
+// ---------------------------------------------------------
+// TOPIC: From userRoles to Role-Based Access Control
+// ---------------------------------------------------------
+// Usage:
+//   go run ./07_maps/rbac/cmd/rbac editor read article
+//   go run ./07_maps/rbac/cmd/rbac -policy my_policy.json guest write article
+//   go run ./07_maps/rbac/cmd/rbac -perms admin
+//
+// The embedded policy.json reuses the lesson's users: "admin" is a
+// Super User and "editor" a Content Manager.
+
+//go:embed policy.json
+var defaultPolicy []byte
+
+func main() {
+	path := flag.String("policy", "", "JSON policy file (default: embedded policy.json)")
+	perms := flag.Bool("perms", false, "list USER's effective permissions instead of checking one")
+	flag.Parse()
+
+	src := defaultPolicy
+	if *path != "" {
+		b, err := os.ReadFile(*path)
+		if err != nil {
+			fail(err)
+		}
+		src = b
+	}
+	policy, err := rbac.Load(bytes.NewReader(src))
+	if err != nil {
+		fail(err)
+	}
+
+	if *perms {
+		if flag.NArg() != 1 {
+			fail(fmt.Errorf("usage: rbac -perms USER"))
+		}
+		user := flag.Arg(0)
+		for _, r := range policy.Roles(user) {
+			fmt.Printf("role: %s (%s)\n", r, policy.Label(r))
+		}
+		for _, p := range policy.EffectivePermissions(user) {
+			fmt.Println("  ", p)
+		}
+		return
+	}
+
+	if flag.NArg() != 3 {
+		fail(fmt.Errorf("usage: rbac USER ACTION RESOURCE"))
+	}
+	d := policy.Can(flag.Arg(0), flag.Arg(1), flag.Arg(2))
+	fmt.Println(d)
+	if !d.Allowed {
+		os.Exit(1)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
@@ -0,0 +1,317 @@
+// Package rbac grows the `userRoles` map from 07_maps (username -> role
+// label) into role-based access control:
+//
+//   - Roles have permission sets ("read:article", "write:*", "*:*").
+//   - Roles inherit other roles (editor inherits viewer).
+//   - Users are assigned one or more roles.
+//   - Can(user, action, resource) returns a Decision that explains which
+//     assignment, inheritance chain and permission granted access, or why
+//     nothing did.
+//
+// Policies load from JSON and are validated: unknown roles and
+// inheritance cycles are rejected.
+package rbac
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ---------------------------------------------------------
+// 1. PERMISSIONS
+// ---------------------------------------------------------
+
+// Permission is "action:resource". Either side may be "*".
+type Permission struct {
+	Action   string
+	Resource string
+}
+
+// ParsePermission parses "action:resource".
+func ParsePermission(s string) (Permission, error) {
+	action, resource, ok := strings.Cut(s, ":")
+	if !ok || action == "" || resource == "" {
+		return Permission{}, fmt.Errorf("rbac: bad permission %q: want action:resource", s)
+	}
+	return Permission{Action: action, Resource: resource}, nil
+}
+
+func (p Permission) String() string { return p.Action + ":" + p.Resource }
+
+// Allows reports whether p covers action on resource.
+func (p Permission) Allows(action, resource string) bool {
+	return (p.Action == "*" || p.Action == action) &&
+		(p.Resource == "*" || p.Resource == resource)
+}
+
+// ---------------------------------------------------------
+// 2. POLICY
+// ---------------------------------------------------------
+
+// RoleSpec is the JSON form of a role.
+type RoleSpec struct {
+	Label       string   `json:"label,omitempty"` // e.g. "Super User"
+	Permissions []string `json:"permissions"`
+	Inherits    []string `json:"inherits,omitempty"`
+}
+
+// Spec is the JSON form of a whole policy.
+type Spec struct {
+	Roles map[string]RoleSpec `json:"roles"`
+	Users map[string][]string `json:"users"`
+}
+
+type role struct {
+	name     string
+	label    string
+	perms    []Permission
+	inherits []string
+}
+
+// Policy is a validated, immutable RBAC policy. It is safe for
+// concurrent use.
+type Policy struct {
+	roles map[string]*role
+	users map[string][]string
+}
+
+// Load decodes and validates a JSON policy.
+func Load(r io.Reader) (*Policy, error) {
+	var spec Spec
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("rbac: decode policy: %w", err)
+	}
+	return New(spec)
+}
+
+// New validates spec and builds a Policy.
+func New(spec Spec) (*Policy, error) {
+	p := &Policy{
+		roles: make(map[string]*role, len(spec.Roles)),
+		users: make(map[string][]string, len(spec.Users)),
+	}
+	for name, rs := range spec.Roles {
+		r := &role{name: name, label: rs.Label, inherits: append([]string(nil), rs.Inherits...)}
+		for _, s := range rs.Permissions {
+			perm, err := ParsePermission(s)
+			if err != nil {
+				return nil, fmt.Errorf("rbac: role %q: %w", name, err)
+			}
+			r.perms = append(r.perms, perm)
+		}
+		p.roles[name] = r
+	}
+	for _, name := range sortedKeys(p.roles) {
+		for _, parent := range p.roles[name].inherits {
+			if _, ok := p.roles[parent]; !ok {
+				return nil, fmt.Errorf("rbac: role %q inherits unknown role %q", name, parent)
+			}
+		}
+	}
+	if err := p.checkCycles(); err != nil {
+		return nil, err
+	}
+	for user, roles := range spec.Users {
+		for _, r := range roles {
+			if _, ok := p.roles[r]; !ok {
+				return nil, fmt.Errorf("rbac: user %q is assigned unknown role %q", user, r)
+			}
+		}
+		p.users[user] = append([]string(nil), roles...)
+	}
+	return p, nil
+}
+
+// CycleError reports an inheritance cycle, e.g. [a b c a].
+type CycleError struct {
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return "rbac: inheritance cycle: " + strings.Join(e.Path, " -> ")
+}
+
+// checkCycles runs a depth-first search with the classic three colours:
+// white (unvisited), grey (on the current path), black (finished).
+// Reaching a grey role again means we walked in a circle.
+func (p *Policy) checkCycles() error {
+	const (
+		white = iota
+		grey
+		black
+	)
+	color := make(map[string]int, len(p.roles))
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		color[name] = grey
+		path = append(path, name)
+		for _, parent := range p.roles[name].inherits {
+			switch color[parent] {
+			case grey:
+				// Cut the path back to where the cycle starts.
+				start := 0
+				for i, n := range path {
+					if n == parent {
+						start = i
+					}
+				}
+				cycle := append(append([]string(nil), path[start:]...), parent)
+				return &CycleError{Path: cycle}
+			case white:
+				if err := visit(parent); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		color[name] = black
+		return nil
+	}
+
+	// Sorted so the reported cycle is deterministic.
+	for _, name := range sortedKeys(p.roles) {
+		if color[name] == white {
+			if err := visit(name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------
+// 3. DECISIONS
+// ---------------------------------------------------------
+
+// Decision is the answer to Can, with its justification.
+type Decision struct {
+	Allowed bool
+	// Chain is the role path that led to the grant, starting with the
+	// role assigned to the user: [editor viewer] means "editor, which
+	// inherits viewer". Empty when denied.
+	Chain []string
+	// Permission is the matching permission. Zero when denied.
+	Permission Permission
+	// Reason is a one-line human explanation.
+	Reason string
+}
+
+func (d Decision) String() string { return d.Reason }
+
+// Can decides whether user may perform action on resource. Roles are
+// searched breadth-first, so the explanation uses the shortest chain.
+func (p *Policy) Can(user, action, resource string) Decision {
+	assigned, ok := p.users[user]
+	if !ok || len(assigned) == 0 {
+		return Decision{Reason: fmt.Sprintf("denied: user %q has no roles", user)}
+	}
+
+	type node struct {
+		role  string
+		chain []string
+	}
+	queue := make([]node, 0, len(assigned))
+	seen := make(map[string]bool)
+	for _, r := range assigned {
+		queue = append(queue, node{role: r, chain: []string{r}})
+		seen[r] = true
+	}
+
+	var checked []string
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		checked = append(checked, n.role)
+		r := p.roles[n.role]
+		for _, perm := range r.perms {
+			if perm.Allows(action, resource) {
+				return Decision{
+					Allowed:    true,
+					Chain:      n.chain,
+					Permission: perm,
+					Reason: fmt.Sprintf("allowed: user %q has role %s, which grants %q",
+						user, describeChain(n.chain), perm),
+				}
+			}
+		}
+		for _, parent := range r.inherits {
+			if !seen[parent] {
+				seen[parent] = true
+				chain := append(append([]string(nil), n.chain...), parent)
+				queue = append(queue, node{role: parent, chain: chain})
+			}
+		}
+	}
+	return Decision{Reason: fmt.Sprintf("denied: none of %q's roles %v grants %s:%s",
+		user, checked, action, resource)}
+}
+
+func describeChain(chain []string) string {
+	if len(chain) == 1 {
+		return fmt.Sprintf("%q", chain[0])
+	}
+	parts := make([]string, len(chain))
+	for i, r := range chain {
+		parts[i] = fmt.Sprintf("%q", r)
+	}
+	return parts[0] + " (inherits " + strings.Join(parts[1:], " -> ") + ")"
+}
+
+// Roles returns the roles assigned directly to user.
+func (p *Policy) Roles(user string) []string {
+	return append([]string(nil), p.users[user]...)
+}
+
+// Label returns a role's display label, e.g. "Super User".
+func (p *Policy) Label(role string) string {
+	if r, ok := p.roles[role]; ok {
+		return r.label
+	}
+	return ""
+}
+
+// EffectivePermissions lists every permission user holds, directly or
+// through inheritance, sorted and de-duplicated.
+func (p *Policy) EffectivePermissions(user string) []Permission {
+	seen := make(map[string]bool)
+	set := make(map[Permission]bool)
+	var walk func(string)
+	walk = func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		r := p.roles[name]
+		for _, perm := range r.perms {
+			set[perm] = true
+		}
+		for _, parent := range r.inherits {
+			walk(parent)
+		}
+	}
+	for _, r := range p.users[user] {
+		walk(r)
+	}
+	out := make([]Permission, 0, len(set))
+	for perm := range set {
+		out = append(out, perm)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].String() < out[j].String() })
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
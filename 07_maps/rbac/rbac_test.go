@@ -0,0 +1,160 @@
+package rbac
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// inherits builds a Spec whose roles have no permissions, only the given
+// parents.
+func inherits(parents map[string][]string) Spec {
+	spec := Spec{Roles: make(map[string]RoleSpec)}
+	for name, ps := range parents {
+		spec.Roles[name] = RoleSpec{Inherits: ps}
+	}
+	return spec
+}
+
+func TestCycles(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		parents map[string][]string
+		want    []string // the reported cycle, nil if none
+	}{
+		{"self", map[string][]string{"a": {"a"}}, []string{"a", "a"}},
+		{"pair", map[string][]string{"a": {"b"}, "b": {"a"}}, []string{"a", "b", "a"}},
+		{"triangle", map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}}, []string{"a", "b", "c", "a"}},
+		// The search starts at a, but a is not on the cycle.
+		{"tail", map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"b"}}, []string{"b", "c", "b"}},
+		{"second parent", map[string][]string{"a": {"x", "b"}, "b": {"a"}, "x": nil}, []string{"a", "b", "a"}},
+		// Diamonds and chains reach a role twice without a cycle.
+		{"diamond", map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}, "d": nil}, nil},
+		{"chain", map[string][]string{"a": {"b"}, "b": {"c"}, "c": nil, "z": {"a", "c"}}, nil},
+	} {
+		_, err := New(inherits(tt.parents))
+		var ce *CycleError
+		switch {
+		case tt.want == nil && err != nil:
+			t.Errorf("%s: New = %v, want no error", tt.name, err)
+		case tt.want != nil && !errors.As(err, &ce):
+			t.Errorf("%s: New = %v, want a CycleError", tt.name, err)
+		case tt.want != nil && !slices.Equal(ce.Path, tt.want):
+			t.Errorf("%s: cycle %v, want %v", tt.name, ce.Path, tt.want)
+		}
+	}
+
+	_, err := New(inherits(map[string][]string{"a": {"b"}, "b": {"a"}}))
+	if got, want := err.Error(), "rbac: inheritance cycle: a -> b -> a"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, tt := range []struct {
+		spec Spec
+		want string
+	}{
+		{inherits(map[string][]string{"a": {"ghost"}}), `role "a" inherits unknown role "ghost"`},
+		{Spec{Users: map[string][]string{"ann": {"ghost"}}}, `user "ann" is assigned unknown role "ghost"`},
+		{Spec{Roles: map[string]RoleSpec{"a": {Permissions: []string{"read"}}}}, `role "a": rbac: bad permission "read"`},
+		{Spec{Roles: map[string]RoleSpec{"a": {Permissions: []string{":x"}}}}, "bad permission"},
+	} {
+		if _, err := New(tt.spec); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("New = %v, want %q", err, tt.want)
+		}
+	}
+}
+
+const policy = `{
+	"roles": {
+		"viewer": {"permissions": ["read:article", "read:comment"]},
+		"commenter": {"permissions": ["write:comment"], "inherits": ["viewer"]},
+		"editor": {"permissions": ["write:article"], "inherits": ["commenter"]},
+		"chief": {"permissions": ["publish:*"], "inherits": ["editor", "viewer"]},
+		"admin": {"label": "Super User", "permissions": ["*:*"]}
+	},
+	"users": {
+		"olena": ["editor"],
+		"taras": ["chief"],
+		"root": ["viewer", "admin"],
+		"guest": []
+	}
+}`
+
+func load(t *testing.T) *Policy {
+	t.Helper()
+	p, err := Load(strings.NewReader(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestCan(t *testing.T) {
+	p := load(t)
+	for _, tt := range []struct {
+		user, action, resource string
+		chain                  []string // nil when denied
+		perm                   string
+	}{
+		{"olena", "write", "article", []string{"editor"}, "write:article"},
+		{"olena", "write", "comment", []string{"editor", "commenter"}, "write:comment"},
+		{"olena", "read", "article", []string{"editor", "commenter", "viewer"}, "read:article"},
+		{"olena", "publish", "article", nil, ""},
+		// chief reaches viewer directly and through editor: the shortest wins.
+		{"taras", "read", "comment", []string{"chief", "viewer"}, "read:comment"},
+		{"taras", "publish", "anything", []string{"chief"}, "publish:*"},
+		// Assigned roles are searched in order before any parent.
+		{"root", "read", "article", []string{"viewer"}, "read:article"},
+		{"root", "delete", "user", []string{"admin"}, "*:*"},
+		{"guest", "read", "article", nil, ""},
+		{"nobody", "read", "article", nil, ""},
+	} {
+		d := p.Can(tt.user, tt.action, tt.resource)
+		if d.Allowed != (tt.chain != nil) || !slices.Equal(d.Chain, tt.chain) || d.Allowed && d.Permission.String() != tt.perm {
+			t.Errorf("Can(%s, %s, %s) = %v %v %v, want chain %v via %s",
+				tt.user, tt.action, tt.resource, d.Allowed, d.Chain, d.Permission, tt.chain, tt.perm)
+		}
+	}
+}
+
+func TestReason(t *testing.T) {
+	p := load(t)
+	for _, tt := range []struct {
+		user, action, resource, want string
+	}{
+		{"olena", "write", "article", `allowed: user "olena" has role "editor", which grants "write:article"`},
+		{"olena", "read", "comment", `allowed: user "olena" has role "editor" (inherits "commenter" -> "viewer"), which grants "read:comment"`},
+		{"olena", "delete", "article", `denied: none of "olena"'s roles [editor commenter viewer] grants delete:article`},
+		{"guest", "read", "article", `denied: user "guest" has no roles`},
+	} {
+		if got := p.Can(tt.user, tt.action, tt.resource).String(); got != tt.want {
+			t.Errorf("Can(%s, %s, %s):\n got %s\nwant %s", tt.user, tt.action, tt.resource, got, tt.want)
+		}
+	}
+}
+
+func TestEffectivePermissions(t *testing.T) {
+	p := load(t)
+	var got []string
+	for _, perm := range p.EffectivePermissions("taras") {
+		got = append(got, perm.String())
+	}
+	want := []string{"publish:*", "read:article", "read:comment", "write:article", "write:comment"}
+	if !slices.Equal(got, want) {
+		t.Errorf("EffectivePermissions(taras) = %v, want %v", got, want)
+	}
+	if p.Label("admin") != "Super User" || p.Label("ghost") != "" {
+		t.Error("Label is wrong")
+	}
+	roles := p.Roles("root")
+	roles[0] = "admin"
+	if p.Roles("root")[0] != "viewer" {
+		t.Error("Roles returned the policy's own slice")
+	}
+	if _, err := Load(strings.NewReader(`{"roles":{},"groups":{}}`)); err == nil {
+		t.Error("Load accepted an unknown field")
+	}
+}
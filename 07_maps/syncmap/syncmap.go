@@ -0,0 +1,204 @@
+// Package syncmap provides Map, a generic map that is safe for concurrent
+// use. The built-in maps in 07_maps are NOT: two goroutines writing the
+// same map (or one writing while another reads) is a data race, and the
+// runtime may crash with "fatal error: concurrent map writes".
+//
+// Map splits its keys over N shards, each a plain map guarded by its own
+// sync.RWMutex ("lock striping"). Goroutines working on keys in different
+// shards never wait for each other.
+package syncmap
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// DefaultShards is used when New is given a non-positive shard count.
+const DefaultShards = 32
+
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// Map is a sharded concurrent map. Create one with New; the zero value is
+// not usable.
+type Map[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []shard[K, V]
+	mask   uint64 // len(shards)-1; len is a power of two
+}
+
+// New returns a map with at least n shards (rounded up to a power of two).
+func New[K comparable, V any](n int) *Map[K, V] {
+	if n <= 0 {
+		n = DefaultShards
+	}
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	m := &Map[K, V]{
+		seed:   maphash.MakeSeed(),
+		shards: make([]shard[K, V], size),
+		mask:   uint64(size - 1),
+	}
+	for i := range m.shards {
+		m.shards[i].m = make(map[K]V)
+	}
+	return m
+}
+
+func (m *Map[K, V]) shardFor(key K) *shard[K, V] {
+	return &m.shards[maphash.Comparable(m.seed, key)&m.mask]
+}
+
+// Load returns the value for key, with the usual "comma ok".
+func (m *Map[K, V]) Load(key K) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.RLock()
+	v, ok := s.m[key]
+	s.mu.RUnlock()
+	return v, ok
+}
+
+// Store sets the value for key.
+func (m *Map[K, V]) Store(key K, value V) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	s.m[key] = value
+	s.mu.Unlock()
+}
+
+// Delete removes key and reports whether it was present.
+func (m *Map[K, V]) Delete(key K) bool {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	_, ok := s.m[key]
+	delete(s.m, key)
+	s.mu.Unlock()
+	return ok
+}
+
+// LoadAndDelete removes key and returns its previous value.
+func (m *Map[K, V]) LoadAndDelete(key K) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	v, ok := s.m[key]
+	delete(s.m, key)
+	s.mu.Unlock()
+	return v, ok
+}
+
+// ComputeIfAbsent returns the existing value for key, or calls create,
+// stores its result and returns it. The check and the store happen under
+// one lock, so create runs at most once per missing key even when many
+// goroutines race. loaded reports whether the value already existed.
+//
+// create runs while the key's shard is locked: it must be quick and must
+// not call back into m.
+func (m *Map[K, V]) ComputeIfAbsent(key K, create func() V) (value V, loaded bool) {
+	s := m.shardFor(key)
+	// Fast path: most calls find the key, and a read lock doesn't block
+	// other readers.
+	s.mu.RLock()
+	v, ok := s.m[key]
+	s.mu.RUnlock()
+	if ok {
+		return v, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[key]; ok { // someone else won the race
+		return v, true
+	}
+	v = create()
+	s.m[key] = v
+	return v, false
+}
+
+// Update atomically replaces the value for key with fn(old, exists).
+// If fn returns keep == false the key is deleted instead. The same
+// restrictions on fn apply as for ComputeIfAbsent.
+//
+//	counter.Update("hits", func(n int, _ bool) (int, bool) { return n + 1, true })
+func (m *Map[K, V]) Update(key K, fn func(old V, exists bool) (value V, keep bool)) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, exists := s.m[key]
+	v, keep := fn(old, exists)
+	if !keep {
+		delete(s.m, key)
+		var zero V
+		return zero, false
+	}
+	s.m[key] = v
+	return v, true
+}
+
+// Len returns the number of keys. Shards are counted one at a time, so
+// under concurrent writes the result is approximate (it may not match any
+// single moment).
+func (m *Map[K, V]) Len() int {
+	n := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Snapshot copies the map into a plain map. Each shard is copied under
+// its read lock; the copy is consistent per shard, not globally.
+func (m *Map[K, V]) Snapshot() map[K]V {
+	out := make(map[K]V)
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		for k, v := range s.m {
+			out[k] = v
+		}
+		s.mu.RUnlock()
+	}
+	return out
+}
+
+// Range calls fn for every entry of a snapshot taken shard by shard, so
+// fn may safely call back into m (including Store and Delete). Returning
+// false stops the iteration. Because each shard is copied before fn runs,
+// Range also works as an iter.Seq2: for k, v := range m.Range { ... }.
+func (m *Map[K, V]) Range(fn func(K, V) bool) {
+	type kv struct {
+		k K
+		v V
+	}
+	var buf []kv
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		buf = buf[:0]
+		for k, v := range s.m {
+			buf = append(buf, kv{k, v})
+		}
+		s.mu.RUnlock()
+		for _, e := range buf {
+			if !fn(e.k, e.v) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes every key.
+func (m *Map[K, V]) Clear() {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.Lock()
+		clear(s.m)
+		s.mu.Unlock()
+	}
+}
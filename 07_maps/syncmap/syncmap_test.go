@@ -0,0 +1,151 @@
+package syncmap
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestStress runs concurrent Update, ComputeIfAbsent and Range calls on
+// the same keys. Run it with go test -race: the race detector complains
+// if any access is unguarded. It checks that no increment was lost and
+// that every key's create function ran exactly once.
+func TestStress(t *testing.T) {
+	const workers, perWorker, nkeys = 16, 2_000, 100
+	keys := make([]string, nkeys)
+	for i := range keys {
+		keys[i] = "user-" + strconv.Itoa(i)
+	}
+	m := New[string, int](8)
+	created := New[string, int](8)
+	var creates [nkeys]atomic.Int32
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWorker {
+				k := i % nkeys
+				m.Update(keys[k], func(n int, _ bool) (int, bool) { return n + 1, true })
+				created.ComputeIfAbsent(keys[k], func() int {
+					creates[k].Add(1)
+					return w
+				})
+				if i%500 == 0 {
+					m.Range(func(string, int) bool { return true })
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for _, v := range m.Snapshot() {
+		total += v
+	}
+	if want := workers * perWorker; total != want {
+		t.Errorf("lost updates: total %d, want %d", total, want)
+	}
+	for i := range creates {
+		if n := creates[i].Load(); n != 1 {
+			t.Errorf("ComputeIfAbsent called create %d times for %s, want 1", n, keys[i])
+		}
+	}
+	if n := created.Len(); n != nkeys {
+		t.Errorf("ComputeIfAbsent created %d keys, want %d", n, nkeys)
+	}
+}
+
+// ---------------------------------------------------------
+// BENCHMARKS: Sharded Map vs sync.Map vs One Big Mutex
+// ---------------------------------------------------------
+// go test -bench . -cpu 1,4,8 ./07_maps/syncmap
+//
+// Each design runs a read-heavy (10% writes) and a write-heavy (50%
+// writes) mix from b.RunParallel goroutines.
+
+// mutexMap is the simplest safe map: one lock for everything.
+type mutexMap struct {
+	mu sync.RWMutex
+	m  map[string]int
+}
+
+func (m *mutexMap) Load(k string) (int, bool) {
+	m.mu.RLock()
+	v, ok := m.m[k]
+	m.mu.RUnlock()
+	return v, ok
+}
+
+func (m *mutexMap) Store(k string, v int) {
+	m.mu.Lock()
+	m.m[k] = v
+	m.mu.Unlock()
+}
+
+// store is what the benchmark needs from each implementation.
+type store interface {
+	Load(string) (int, bool)
+	Store(string, int)
+}
+
+// syncMapStore adapts sync.Map (which stores 'any') to the store interface.
+type syncMapStore struct{ m sync.Map }
+
+func (s *syncMapStore) Load(k string) (int, bool) {
+	v, ok := s.m.Load(k)
+	if !ok {
+		return 0, false
+	}
+	return v.(int), true
+}
+
+func (s *syncMapStore) Store(k string, v int) { s.m.Store(k, v) }
+
+const benchSize = 10_000
+
+var benchKeys = func() []string {
+	k := make([]string, benchSize)
+	for i := range k {
+		k[i] = "user-" + strconv.Itoa(i)
+	}
+	return k
+}()
+
+// benchMix does one write per writeEvery operations on each design.
+func benchMix(b *testing.B, writeEvery int) {
+	impls := []struct {
+		name string
+		make func() store
+	}{
+		{"sharded", func() store { return New[string, int](0) }},
+		{"sync.Map", func() store { return &syncMapStore{} }},
+		{"RWMutex", func() store { return &mutexMap{m: make(map[string]int)} }},
+	}
+	for _, impl := range impls {
+		b.Run(impl.name, func(b *testing.B) {
+			s := impl.make()
+			for i, k := range benchKeys {
+				s.Store(k, i)
+			}
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					k := benchKeys[i%benchSize]
+					if i%writeEvery == 0 {
+						s.Store(k, i)
+					} else {
+						s.Load(k)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkReadHeavy(b *testing.B)  { benchMix(b, 10) }
+func BenchmarkWriteHeavy(b *testing.B) { benchMix(b, 2) }
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/csvtok"
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/width"
+)
+
+// ---------------------------------------------------------
+// TOPIC: CSV Is Not strings.Split(line, ",")
+// ---------------------------------------------------------
+// Usage:
+//   go run ./08_strings_and_runes/csvtok/cmd/csvcheck
+//   go run ./08_strings_and_runes/csvtok/cmd/csvcheck -file export.csv
+//   go run ./08_strings_and_runes/csvtok/cmd/csvcheck -file data.tsv -comma '\t' -comment ''
+//
+// Lists every record of a file with its position, reports broken and
+// ragged rows, and checks that writing the records back gives the same
+// bytes. The embedded rates.csv has the usual trouble: a comment, a
+// quoted comma, escaped quotes, a field spanning two lines, a blank
+// line, rows with too few and too many fields, and a rate that is not a
+// number. For it, the rows are also decoded into a struct by header.
+// Exits with status 1 if the file has errors.
+
+//go:embed rates.csv
+var sample []byte
+
+// Rate is one row of rates.csv.
+type Rate struct {
+	Code string  `csv:"code,required"`
+	Name string  // matched to the "name" column by field name
+	Rate float64 `csv:"rate_uah,required"`
+	Note *string `csv:"note"`
+}
+
+func main() {
+	path := flag.String("file", "", "file to check (default: embedded rates.csv)")
+	comma := flag.String("comma", ",", `field delimiter; '\t' for TSV`)
+	comment := flag.String("comment", "#", "comment character; empty for none")
+	flag.Parse()
+
+	src := sample
+	if *path != "" {
+		b, err := os.ReadFile(*path)
+		if err != nil {
+			fail(err)
+		}
+		src = b
+	}
+	d := csvtok.Dialect{Comma: char(*comma), Comment: char(*comment)}
+	if d.Comma == 0 {
+		fail(errors.New("-comma must be one character"))
+	}
+
+	if *path == "" {
+		line := strings.Split(string(src), "\r\n")[3]
+		fmt.Printf("strings.Split(%q, \",\"):\n  %q\n\n", line, strings.Split(line, ","))
+	}
+
+	bad := list(src, d)
+	roundTrip(src, d)
+	if *path == "" {
+		decode(src, d)
+	}
+	if bad {
+		os.Exit(1)
+	}
+}
+
+// list prints one row per record and reports whether any had an error.
+func list(src []byte, d csvtok.Dialect) (bad bool) {
+	t := width.NewTable("Pos", "Kind", "Fields", "Problem")
+	t.SetMaxWidth(2, 60)
+	for rec, err := range csvtok.NewReader(bytes.NewReader(src), d).All() {
+		problem := ""
+		if err != nil {
+			problem, bad = err.Error(), true
+		}
+		var what string
+		switch rec.Kind {
+		case csvtok.Comment:
+			what = strconv.Quote(rec.Text)
+		case csvtok.Data:
+			var cells []string
+			for _, f := range rec.Fields {
+				c := strconv.Quote(f.Value)
+				if !f.Quoted {
+					c = strings.Trim(c, `"`) // show quotes only where the file had them
+				}
+				cells = append(cells, c)
+			}
+			what = strings.Join(cells, " | ")
+		}
+		pos, kind := fmt.Sprintf("%d:%d", rec.Pos.Line, rec.Pos.Column), [...]string{"data", "comment", "blank"}[rec.Kind]
+		if rec.Pos.Line == 0 {
+			pos, kind = "-", "-" // a final error comes without a record
+		}
+		t.Append(pos, kind, what, problem)
+	}
+	if err := t.Render(os.Stdout); err != nil {
+		fail(err)
+	}
+	return bad
+}
+
+// roundTrip reads src and writes every record back.
+func roundTrip(src []byte, d csvtok.Dialect) {
+	var out bytes.Buffer
+	w := csvtok.NewWriter(&out, d)
+	r := csvtok.NewReader(bytes.NewReader(src), d)
+	for rec, err := range r.All() {
+		if err != nil && !errors.Is(err, csvtok.ErrFieldCount) {
+			fmt.Println("\nround trip: skipped, the file does not parse")
+			return
+		}
+		w.Write(rec)
+	}
+	if err := w.Flush(); err != nil {
+		fail(err)
+	}
+	if bytes.Equal(out.Bytes(), src) {
+		fmt.Printf("\nround trip: identical (%d bytes)\n", len(src))
+		return
+	}
+	fmt.Printf("\nround trip: DIFFERENT (%d bytes in, %d out)\n", len(src), out.Len())
+}
+
+func decode(src []byte, d csvtok.Dialect) {
+	fmt.Println("\nDecoded into Rate:")
+	dec, err := csvtok.NewDecoder[Rate](csvtok.NewReader(bytes.NewReader(src), d))
+	if err != nil {
+		fail(err)
+	}
+	for rate, err := range dec.All() {
+		note := "<nil>"
+		if rate.Note != nil {
+			note = strconv.Quote(*rate.Note)
+		}
+		fmt.Printf("  %-3s %-22q %8.2f  note=%s", rate.Code, rate.Name, rate.Rate, note)
+		if err != nil {
+			fmt.Printf("  (%v)", err)
+		}
+		fmt.Println()
+	}
+}
+
+// char turns a flag into a rune, accepting Go escapes like \t.
+func char(s string) rune {
+	if s == "" {
+		return 0
+	}
+	if u, err := strconv.Unquote(`'` + s + `'`); err == nil {
+		s = u
+	}
+	if r, size := utf8.DecodeRuneInString(s); size == len(s) {
+		return r
+	}
+	return 0
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
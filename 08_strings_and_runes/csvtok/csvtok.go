@@ -0,0 +1,277 @@
+// Package csvtok reads and writes delimited text (CSV, TSV, ...) as
+// RFC 4180 describes it, one record at a time.
+//
+// 08_strings_and_runes splits "a,b,c,d" with strings.Split. That breaks
+// on the first real CSV line:
+//
+//	strings.Split(`1,"Kyiv, Ukraine",UAH`, ",") // 4 pieces, one of them `"Kyiv`
+//
+// RFC 4180 quotes fields that contain the delimiter, a quote or a line
+// break, and doubles quotes inside them: "say ""hi""". A quoted field can
+// span lines, so a record is not a line either.
+//
+// Compared with encoding/csv, this package keeps everything needed to
+// write the input back byte for byte (see Writer): whether each field was
+// quoted, comment lines, blank lines and the line ending of every record.
+// Records, fields and errors carry their line and column, so a bad row
+// in a partner's file can be found, and a row with the wrong number of
+// fields is reported without stopping the read. Decoder maps rows onto
+// structs by header name.
+package csvtok
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+// ---------------------------------------------------------
+// 1. RECORDS AND ERRORS
+// ---------------------------------------------------------
+
+// Pos is a position in the input. Both numbers start at 1; Column counts
+// characters (runes), not bytes, so it matches what an editor shows.
+type Pos struct {
+	Line, Column int
+}
+
+func (p Pos) String() string { return fmt.Sprintf("line %d, column %d", p.Line, p.Column) }
+
+// Kind tells data records from the lines around them.
+type Kind uint8
+
+const (
+	Data    Kind = iota
+	Comment      // a line starting with Dialect.Comment
+	Blank        // an empty line
+)
+
+// Field is one value of a record.
+type Field struct {
+	Value  string // unquoted: "say ""hi""" becomes `say "hi"`
+	Quoted bool   // the field was in quotes in the input
+	Pos    Pos    // where the field starts (its opening quote, if quoted)
+}
+
+// Record is one record of the input, or a comment or blank line.
+type Record struct {
+	Kind   Kind
+	Fields []Field // for Data
+	Text   string  // for Comment: the line after the comment character
+	// EOL is the line ending that closed the record: "\r\n", "\n", or ""
+	// for a last line without one.
+	EOL string
+	Pos Pos
+}
+
+// Values returns the field values of a Data record.
+func (r Record) Values() []string {
+	vs := make([]string, len(r.Fields))
+	for i, f := range r.Fields {
+		vs[i] = f.Value
+	}
+	return vs
+}
+
+var (
+	ErrQuote      = errors.New(`missing or extraneous '"' in quoted field`)
+	ErrBareQuote  = errors.New(`'"' in unquoted field`)
+	ErrFieldCount = errors.New("wrong number of fields")
+)
+
+// ParseError is an error at a position in the input. Err is one of the
+// Err variables above, possibly wrapped with details; use errors.Is.
+type ParseError struct {
+	Pos Pos
+	Err error
+}
+
+func (e *ParseError) Error() string { return fmt.Sprintf("csvtok: %v: %v", e.Pos, e.Err) }
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// ---------------------------------------------------------
+// 2. READER
+// ---------------------------------------------------------
+
+// Dialect describes a flavour of delimited text. The zero value is
+// RFC 4180: comma-separated, no comments.
+type Dialect struct {
+	// Comma separates fields. 0 means ','. Use '\t' for TSV or ';' for
+	// spreadsheets in locales with a decimal comma.
+	Comma rune
+	// Comment starts a comment line when it is the first character of a
+	// line. 0 disables comments.
+	Comment rune
+	// FieldsPerRecord is the number of fields every data record must
+	// have. 0 means as many as the first data record (usually the
+	// header); negative means any number.
+	FieldsPerRecord int
+}
+
+func (d Dialect) comma() rune {
+	if d.Comma == 0 {
+		return ','
+	}
+	return d.Comma
+}
+
+// Reader reads records from an input stream. It reads one physical line
+// at a time and only reads further while a quoted field is open.
+type Reader struct {
+	d     Dialect
+	comma string
+	br    *bufio.Reader
+	line  int   // number of lines read
+	end   Pos   // where the last record's line ending starts
+	want  int   // fields per data record; 0: not known yet
+	err   error // sticky syntax or I/O error
+}
+
+// NewReader returns a Reader for d reading from r.
+func NewReader(r io.Reader, d Dialect) *Reader {
+	return &Reader{d: d, comma: string(d.comma()), br: bufio.NewReader(r), want: d.FieldsPerRecord}
+}
+
+// Read returns the next record, or io.EOF after the last one.
+//
+// A data record with the wrong number of fields comes back together with
+// a *ParseError wrapping ErrFieldCount, and reading can go on. Any other
+// error is final: every later call returns it again.
+func (r *Reader) Read() (Record, error) {
+	if r.err != nil {
+		return Record{}, r.err
+	}
+	rec, err := r.read()
+	if err != nil {
+		r.err = err
+		return Record{}, err
+	}
+	if rec.Kind != Data || r.want < 0 {
+		return rec, nil
+	}
+	if r.want == 0 {
+		r.want = len(rec.Fields)
+	}
+	if n := len(rec.Fields); n != r.want {
+		at := r.end
+		if n > r.want {
+			at = rec.Fields[r.want].Pos
+		}
+		return rec, &ParseError{Pos: at, Err: fmt.Errorf("%w: got %d, want %d", ErrFieldCount, n, r.want)}
+	}
+	return rec, nil
+}
+
+// All yields every record with its error. It stops after the last record
+// or after yielding a final error; ErrFieldCount is not final.
+func (r *Reader) All() iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		for {
+			rec, err := r.Read()
+			if err == io.EOF {
+				return
+			}
+			if !yield(rec, err) || (err != nil && !errors.Is(err, ErrFieldCount)) {
+				return
+			}
+		}
+	}
+}
+
+// readLine returns the next physical line without its line ending, and
+// the ending. It returns io.EOF only when there is nothing left at all.
+func (r *Reader) readLine() (text, eol string, err error) {
+	text, err = r.br.ReadString('\n')
+	if err != nil && (err != io.EOF || text == "") {
+		return "", "", err
+	}
+	r.line++
+	switch {
+	case strings.HasSuffix(text, "\r\n"):
+		return text[:len(text)-2], "\r\n", nil
+	case strings.HasSuffix(text, "\n"):
+		return text[:len(text)-1], "\n", nil
+	}
+	return text, "", nil
+}
+
+func (r *Reader) at(text string, i int) Pos {
+	return Pos{r.line, utf8.RuneCountInString(text[:i]) + 1}
+}
+
+func (r *Reader) read() (Record, error) {
+	text, eol, err := r.readLine()
+	if err != nil {
+		return Record{}, err
+	}
+	rec := Record{Pos: Pos{r.line, 1}, EOL: eol}
+	switch {
+	case text == "" && eol != "":
+		rec.Kind = Blank
+		return rec, nil
+	case r.d.Comment != 0 && strings.HasPrefix(text, string(r.d.Comment)):
+		rec.Kind, rec.Text = Comment, text[len(string(r.d.Comment)):]
+		return rec, nil
+	}
+
+	for i := 0; ; {
+		f := Field{Pos: r.at(text, i)}
+		if i < len(text) && text[i] == '"' {
+			// Quoted: read up to the closing quote, across lines if needed.
+			var b strings.Builder
+			f.Quoted = true
+			i++
+			for {
+				j := strings.IndexByte(text[i:], '"')
+				if j < 0 {
+					if eol == "" {
+						return rec, &ParseError{Pos: f.Pos, Err: fmt.Errorf("%w: quoted field never closed", ErrQuote)}
+					}
+					b.WriteString(text[i:])
+					b.WriteString(eol)
+					if text, eol, err = r.readLine(); err == io.EOF {
+						return rec, &ParseError{Pos: f.Pos, Err: fmt.Errorf("%w: quoted field never closed", ErrQuote)}
+					} else if err != nil {
+						return rec, err
+					}
+					i = 0
+					continue
+				}
+				b.WriteString(text[i : i+j])
+				i += j + 1
+				if i < len(text) && text[i] == '"' {
+					b.WriteByte('"') // "" is an escaped quote
+					i++
+					continue
+				}
+				break
+			}
+			f.Value = b.String()
+			if i < len(text) && !strings.HasPrefix(text[i:], r.comma) {
+				return rec, &ParseError{Pos: r.at(text, i), Err: fmt.Errorf("%w: text after closing quote", ErrQuote)}
+			}
+		} else {
+			j := strings.Index(text[i:], r.comma)
+			if j < 0 {
+				j = len(text) - i
+			}
+			f.Value = text[i : i+j]
+			if q := strings.IndexByte(f.Value, '"'); q >= 0 {
+				return rec, &ParseError{Pos: r.at(text, i+q), Err: ErrBareQuote}
+			}
+			i += j
+		}
+		rec.Fields = append(rec.Fields, f)
+		if i == len(text) {
+			break
+		}
+		i += len(r.comma)
+	}
+	rec.EOL = eol
+	r.end = r.at(text, len(text))
+	return rec, nil
+}
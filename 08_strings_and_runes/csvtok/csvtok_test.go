@@ -0,0 +1,192 @@
+package csvtok
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// readAll reads every record, collecting ErrFieldCount errors, and fails
+// the test on any other error.
+func readAll(t *testing.T, in string, d Dialect) ([]Record, []error) {
+	t.Helper()
+	r := NewReader(strings.NewReader(in), d)
+	var recs []Record
+	var counts []error
+	for rec, err := range r.All() {
+		if err != nil && !errors.Is(err, ErrFieldCount) {
+			t.Fatalf("%q: %v", in, err)
+		}
+		if err != nil {
+			counts = append(counts, err)
+		}
+		recs = append(recs, rec)
+	}
+	return recs, counts
+}
+
+func TestRoundTrip(t *testing.T) {
+	hash := Dialect{Comment: '#', FieldsPerRecord: -1}
+	for _, tt := range []struct {
+		name string
+		in   string
+		d    Dialect
+	}{
+		{"plain LF", "a,b,c\n1,2,3\n", Dialect{}},
+		{"plain CRLF", "a,b,c\r\n1,2,3\r\n", Dialect{}},
+		{"mixed endings", "a,b\r\n1,2\n3,4\r\n5,6", Dialect{}},
+		{"no final newline", "a,b\n1,2", Dialect{}},
+		{"quoted when not needed", `"a",b,"c"` + "\n" + `"1","2",3` + "\n", Dialect{}},
+		{"escaped quotes", `name,quote` + "\n" + `Taras,"say ""hi"""` + "\n", Dialect{}},
+		{"multi-line field", "id,text\r\n1,\"line one\r\nline two\nline three\"\r\n2,x\r\n", Dialect{}},
+		{"delimiter in field", `city,country` + "\n" + `"Kyiv, Ukraine",UA` + "\n", Dialect{}},
+		{"trailing CR in last field", "a,b\r\r\n1,2\r", Dialect{}},
+		{"trailing CR before LF, quoted", "a,\"b\r\"\n1,2\n", Dialect{}},
+		{"lone empty quoted field", "a\n\"\"\nb\n", Dialect{}},
+		{"empty fields", "a,b,c\n,,\n\"\",,\"\"\n", Dialect{}},
+		{"comments and blank lines", "# rates\n\ncode,rate\n\n# eur\nEUR,44.1\r\n\r\n", hash},
+		{"comment char inside data", "code,note\nUAH,#1\n\"#x\",y\n", hash},
+		{"ragged rows", "a,b,c\n1,2\n1,2,3,4\n\n5\n", hash},
+		{"semicolons", "a;b\n\"1;5\";2,5\n", Dialect{Comma: ';'}},
+		{"tabs", "a\tb\n1\t\"x\ty\"\n", Dialect{Comma: '\t'}},
+		{"multi-byte delimiter", "a·b\n1·\"2·3\"\nї·є\n", Dialect{Comma: '·'}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			recs, _ := readAll(t, tt.in, tt.d)
+			var out strings.Builder
+			w := NewWriter(&out, tt.d)
+			for _, rec := range recs {
+				if err := w.Write(rec); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.in {
+				t.Errorf("round trip changed the input:\n got %q\nwant %q", out.String(), tt.in)
+			}
+		})
+	}
+}
+
+func TestRecords(t *testing.T) {
+	in := "# header\n\nid,text\n1,\"two\nlines\"\n"
+	recs, _ := readAll(t, in, Dialect{Comment: '#'})
+	if len(recs) != 4 {
+		t.Fatalf("got %d records, want 4", len(recs))
+	}
+	if r := recs[0]; r.Kind != Comment || r.Text != " header" || r.Pos != (Pos{1, 1}) {
+		t.Errorf("record 0 = %+v, want the comment", r)
+	}
+	if r := recs[1]; r.Kind != Blank || r.Pos.Line != 2 {
+		t.Errorf("record 1 = %+v, want a blank line 2", r)
+	}
+	r := recs[3]
+	if got := r.Values(); !slices.Equal(got, []string{"1", "two\nlines"}) {
+		t.Errorf("values = %q", got)
+	}
+	if f := r.Fields[1]; !f.Quoted || f.Pos != (Pos{4, 3}) {
+		t.Errorf("field = %+v, want quoted at line 4, column 3", f)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		err  error
+		want Pos
+	}{
+		{`a,"b`, ErrQuote, Pos{1, 3}},
+		{"a,b\n1,\"open\nstill open\n", ErrQuote, Pos{2, 3}},
+		{`a,"b"c`, ErrQuote, Pos{1, 6}},
+		{"a,\"b\nc\"d,e\n", ErrQuote, Pos{2, 3}},
+		{`a,b"c`, ErrBareQuote, Pos{1, 4}},
+		{`ї,єї"`, ErrBareQuote, Pos{1, 5}}, // columns count runes, not bytes
+		{"a,b\n1,2\n3,\"4\"\"\n", ErrQuote, Pos{3, 3}},
+	} {
+		r := NewReader(strings.NewReader(tt.in), Dialect{FieldsPerRecord: -1})
+		var err error
+		for err == nil {
+			_, err = r.Read()
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, tt.err) || pe.Pos != tt.want {
+			t.Errorf("%q: error %v, want %v at %v", tt.in, err, tt.err, tt.want)
+			continue
+		}
+		if _, again := r.Read(); again != err {
+			t.Errorf("%q: error is not sticky: then %v", tt.in, again)
+		}
+	}
+}
+
+func TestFieldCount(t *testing.T) {
+	in := "a,b,c\n1,2,3\n1,2,3,4\n1,2\n1,2,3\n"
+	recs, errs := readAll(t, in, Dialect{})
+	if len(recs) != 5 {
+		t.Errorf("got %d records, want all 5: ErrFieldCount must not stop the read", len(recs))
+	}
+	var want = []Pos{{3, 7}, {4, 4}} // the extra field; the end of the short row
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, err := range errs {
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Pos != want[i] {
+			t.Errorf("error %d = %v, want ErrFieldCount at %v", i, err, want[i])
+		}
+	}
+
+	if _, errs := readAll(t, "a,b\n1\n", Dialect{FieldsPerRecord: 1}); len(errs) != 1 {
+		t.Errorf("FieldsPerRecord 1: got %d errors, want the header's", len(errs))
+	}
+}
+
+func TestWriteRow(t *testing.T) {
+	var out strings.Builder
+	w := NewWriter(&out, Dialect{Comment: '#'})
+	w.WriteRow("code", "name")
+	w.WriteRow("#EUR", `say "hi"`)
+	w.WriteRow("a,b", "two\nlines")
+	w.WriteRow("")
+	w.EOL = "\n"
+	w.WriteRow("x", "ends in CR\r")
+	w.WriteRow("", "")
+	w.Flush()
+	want := "code,name\r\n" +
+		`"#EUR","say ""hi"""` + "\r\n" +
+		`"a,b","two` + "\nlines\"\r\n" +
+		`""` + "\r\n" +
+		"x,\"ends in CR\r\"\n" +
+		",\n"
+	if out.String() != want {
+		t.Errorf("WriteRow wrote\n%q\nwant\n%q", out.String(), want)
+	}
+
+	recs, _ := readAll(t, out.String(), Dialect{Comment: '#', FieldsPerRecord: -1})
+	if got := recs[4].Values(); !slices.Equal(got, []string{"x", "ends in CR\r"}) {
+		t.Errorf("read back %q", got)
+	}
+}
+
+func TestWriteCommentWithoutDialect(t *testing.T) {
+	var out strings.Builder
+	w := NewWriter(&out, Dialect{})
+	if err := w.Write(Record{Kind: Comment, Text: "note", EOL: "\n"}); err == nil {
+		t.Error("Write of a comment with Dialect.Comment 0 succeeded")
+	}
+	w.Flush()
+	if out.Len() != 0 {
+		t.Errorf("wrote %q, want nothing", out.String())
+	}
+}
+
+func TestEOF(t *testing.T) {
+	r := NewReader(strings.NewReader(""), Dialect{})
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("Read of empty input = %v, want io.EOF", err)
+	}
+}
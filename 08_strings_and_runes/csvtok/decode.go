@@ -0,0 +1,229 @@
+package csvtok
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------
+// 4. HEADER TO STRUCT
+// ---------------------------------------------------------
+
+// Decoder reads records into values of struct type T, matching the
+// header (the first data record) to struct fields:
+//
+//	type Rate struct {
+//		Code string  `csv:"code,required"`
+//		Name string  // matches a "name" or "Name" column
+//		Rate float64 `csv:"rate_uah"`
+//		Note *string `csv:"note"` // empty cell: nil
+//		Skip int     `csv:"-"`
+//	}
+//
+// A column matches the tag name if the field has one, else the field
+// name ignoring case.
+// Columns without a field are ignored; a field marked required whose
+// column is missing makes NewDecoder fail. Supported field types are
+// strings, bools, integers, floats, types implementing
+// encoding.TextUnmarshaler, and pointers to them (nil for an empty cell).
+type Decoder[T any] struct {
+	r      *Reader
+	header []string
+	cols   []column // by column index
+}
+
+type column struct {
+	name  string
+	index []int // reflect field index; nil for an ignored column
+}
+
+// NewDecoder reads the header from r and prepares to decode T.
+func NewDecoder[T any](r *Reader) (*Decoder[T], error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csvtok: Decoder needs a struct type, not %v", t)
+	}
+	var head Record
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return nil, errors.New("csvtok: no header")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if rec.Kind == Data {
+			head = rec
+			break
+		}
+	}
+
+	d := &Decoder[T]{r: r, header: head.Values(), cols: make([]column, len(head.Fields))}
+	seen := make(map[string]Pos)
+	for i, f := range head.Fields {
+		if p, dup := seen[f.Value]; dup {
+			return nil, &ParseError{Pos: f.Pos, Err: fmt.Errorf("duplicate column %q (first at %v)", f.Value, p)}
+		}
+		seen[f.Value] = f.Pos
+		d.cols[i].name = f.Value
+	}
+
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		name, opts, _ := strings.Cut(sf.Tag.Get("csv"), ",")
+		if name == "-" {
+			continue
+		}
+		if err := checkType(sf.Type); err != nil {
+			return nil, fmt.Errorf("csvtok: field %s: %w", sf.Name, err)
+		}
+		i := d.match(name, sf.Name)
+		if i < 0 {
+			if opts == "required" {
+				if name == "" {
+					name = sf.Name
+				}
+				return nil, &ParseError{Pos: head.Pos, Err: fmt.Errorf("required column %q missing from header", name)}
+			}
+			continue
+		}
+		d.cols[i].index = sf.Index
+	}
+	return d, nil
+}
+
+// match finds the column for a field: by tag, else by field name
+// ignoring case.
+func (d *Decoder[T]) match(tag, field string) int {
+	for i, c := range d.cols {
+		if tag != "" && c.name == tag || tag == "" && strings.EqualFold(c.name, field) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Header returns the column names.
+func (d *Decoder[T]) Header() []string { return d.header }
+
+// Decode reads the next data record into a T, skipping comments and
+// blank lines. It returns io.EOF at the end. A cell that does not convert
+// to its field's type gives a *ParseError at the cell. A row with the
+// wrong number of fields is still decoded (missing cells stay zero) and
+// returned with the ErrFieldCount error.
+func (d *Decoder[T]) Decode() (T, error) {
+	var v T
+	for {
+		rec, err := d.r.Read()
+		if err != nil && !errors.Is(err, ErrFieldCount) {
+			return v, err
+		}
+		if rec.Kind != Data {
+			continue
+		}
+		rv := reflect.ValueOf(&v).Elem()
+		for i, f := range rec.Fields {
+			if i >= len(d.cols) || d.cols[i].index == nil {
+				continue
+			}
+			if cerr := set(rv.FieldByIndex(d.cols[i].index), f.Value); cerr != nil {
+				return v, &ParseError{Pos: f.Pos, Err: fmt.Errorf("column %q: %w", d.cols[i].name, cerr)}
+			}
+		}
+		return v, err
+	}
+}
+
+// All yields every decoded row. Like Reader.All, it goes on after
+// ErrFieldCount and after conversion errors, and stops after any other
+// error.
+func (d *Decoder[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			v, err := d.Decode()
+			if err == io.EOF {
+				return
+			}
+			if !yield(v, err) || d.r.err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ---------------------------------------------------------
+// 5. CONVERSIONS
+// ---------------------------------------------------------
+
+var textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+func checkType(t reflect.Type) error {
+	if reflect.PointerTo(t).Implements(textUnmarshaler) {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return nil
+	case reflect.Pointer:
+		if t.Elem().Kind() != reflect.Pointer {
+			return checkType(t.Elem())
+		}
+	}
+	return fmt.Errorf("unsupported type %v", t)
+}
+
+func set(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		if s == "" {
+			v.SetZero()
+			return nil
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	if v.Kind() == reflect.String {
+		v.SetString(s)
+		return nil
+	}
+	if s == "" {
+		v.SetZero() // an empty number or bool cell is the zero value
+		return nil
+	}
+	var err error
+	switch v.Kind() {
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, v.Type().Bits())
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, v.Type().Bits())
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, v.Type().Bits())
+		v.SetFloat(f)
+	}
+	if ne, ok := err.(*strconv.NumError); ok {
+		return fmt.Errorf("%q: %w", s, ne.Err) // "invalid syntax" or "value out of range"
+	}
+	return err
+}
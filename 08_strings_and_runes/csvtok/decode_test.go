@@ -0,0 +1,148 @@
+package csvtok
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type rate struct {
+	Code   string     `csv:"code,required"`
+	Name   string     // matches "NAME" ignoring case
+	Rate   float64    `csv:"rate_uah"`
+	Units  uint16     `csv:"units"`
+	Active bool       `csv:"active"`
+	Note   *string    `csv:"note"`
+	Since  time.Time  `csv:"since"` // encoding.TextUnmarshaler
+	Until  *time.Time `csv:"until"` // pointer to one
+	Skip   int        `csv:"-"`
+	hidden string     // unexported: never matched
+}
+
+func decoder(t *testing.T, in string) *Decoder[rate] {
+	t.Helper()
+	d, err := NewDecoder[rate](NewReader(strings.NewReader(in), Dialect{Comment: '#'}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDecode(t *testing.T) {
+	in := "# exported 2026-01-01\n" +
+		"code,NAME,rate_uah,units,active,note,since,until,Skip,extra\n" +
+		"EUR,Euro,44.10,1,true,,2002-01-01T00:00:00Z,,7,ignored\n" +
+		"\n" +
+		"XAU,Gold,\"1,5\",1,false,troy ounce,2000-01-01T00:00:00Z,2030-01-01T00:00:00Z,7,x\n"
+	d := decoder(t, in)
+	if h := d.Header(); len(h) != 10 || h[1] != "NAME" {
+		t.Errorf("Header = %q", h)
+	}
+
+	eur, err := d.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	since := time.Date(2002, 1, 1, 0, 0, 0, 0, time.UTC)
+	if eur.Code != "EUR" || eur.Name != "Euro" || eur.Rate != 44.10 || eur.Units != 1 || !eur.Active ||
+		eur.Note != nil || !eur.Since.Equal(since) || eur.Until != nil || eur.Skip != 0 {
+		t.Errorf("EUR = %+v", eur)
+	}
+
+	// "1,5" is not a float: the error points at the cell and names the column.
+	_, err = d.Decode()
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Pos != (Pos{5, 10}) || !strings.Contains(err.Error(), `column "rate_uah"`) {
+		t.Errorf("bad float: %v, want a ParseError at line 5, column 10", err)
+	}
+	if _, err := d.Decode(); err != io.EOF {
+		t.Errorf("Decode at the end = %v, want io.EOF", err)
+	}
+}
+
+func TestDecodePointers(t *testing.T) {
+	d := decoder(t, "code,note,until\nXAU,troy ounce,2030-01-01T00:00:00Z\n")
+	v, err := d.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Note == nil || *v.Note != "troy ounce" {
+		t.Errorf("Note = %v, want troy ounce", v.Note)
+	}
+	if v.Until == nil || v.Until.Year() != 2030 {
+		t.Errorf("Until = %v, want 2030", v.Until)
+	}
+}
+
+func TestDecodeRagged(t *testing.T) {
+	d := decoder(t, "code,units\nUAH\nEUR,1,extra\nUSD,2\n")
+	var got []string
+	var counts int
+	for v, err := range d.All() {
+		if errors.Is(err, ErrFieldCount) {
+			counts++
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v.Code)
+	}
+	if strings.Join(got, " ") != "UAH EUR USD" || counts != 2 {
+		t.Errorf("All decoded %q with %d field-count errors, want UAH EUR USD with 2", got, counts)
+	}
+}
+
+func TestDecodeConversions(t *testing.T) {
+	for _, tt := range []struct{ row, want string }{
+		{"EUR,,,", ""}, // empty number and bool cells are zero
+		{"EUR,x,,", "invalid syntax"},
+		{"EUR,,70000,", "value out of range"},
+		{"EUR,,,maybe", "invalid syntax"},
+	} {
+		d := decoder(t, "code,rate_uah,units,active\n"+tt.row+"\n")
+		_, err := d.Decode()
+		if tt.want == "" && err != nil || tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)) {
+			t.Errorf("%s: error %v, want %q", tt.row, err, tt.want)
+		}
+	}
+}
+
+func TestDecodeTextUnmarshaler(t *testing.T) {
+	// Only a pointer field treats an empty cell as "no value"; a plain
+	// TextUnmarshaler is given the empty text, which time.Time rejects.
+	for _, tt := range []struct{ row, want string }{
+		{"EUR,,", `column "since"`},
+		{"EUR,yesterday,", "cannot parse"},
+		{"EUR,2002-01-01T00:00:00Z,soon", `column "until"`},
+	} {
+		_, err := decoder(t, "code,since,until\n"+tt.row+"\n").Decode()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error %v, want %q", tt.row, err, tt.want)
+		}
+	}
+}
+
+func TestNewDecoderErrors(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"name,rate_uah\nEuro,44\n", `required column "code" missing`},
+		{"code,code\n", `duplicate column "code"`},
+		{"# only a comment\n", "no header"},
+		{"code,\"open\n", "quoted field never closed"},
+	} {
+		_, err := NewDecoder[rate](NewReader(strings.NewReader(tt.in), Dialect{Comment: '#'}))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: error %v, want %q", tt.in, err, tt.want)
+		}
+	}
+
+	if _, err := NewDecoder[int](NewReader(strings.NewReader("a\n"), Dialect{})); err == nil {
+		t.Error("NewDecoder[int] succeeded")
+	}
+	type bad struct {
+		Tags []string `csv:"tags"`
+	}
+	if _, err := NewDecoder[bad](NewReader(strings.NewReader("tags\n"), Dialect{})); err == nil {
+		t.Error("NewDecoder with a []string field succeeded")
+	}
+}
@@ -0,0 +1,105 @@
+package csvtok
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ---------------------------------------------------------
+// 3. WRITER
+// ---------------------------------------------------------
+
+// Writer writes records in a Dialect.
+//
+// Records that came from a Reader with the same Dialect are written back
+// exactly as they were read: same quoting, same comments and blank
+// lines, same line endings. Fixing one value and writing the file back
+// therefore gives a one-line diff, not a reformatted file.
+type Writer struct {
+	// EOL ends the lines written by WriteRow. Empty means "\r\n", as
+	// RFC 4180 says.
+	EOL string
+
+	d     Dialect
+	comma string
+	bw    *bufio.Writer
+}
+
+// NewWriter returns a Writer for d writing to w. Call Flush when done.
+func NewWriter(w io.Writer, d Dialect) *Writer {
+	return &Writer{d: d, comma: string(d.comma()), bw: bufio.NewWriter(w)}
+}
+
+// Write writes rec. Fields are quoted if they were quoted when read, or
+// if their value needs it. rec.EOL is written as is: a record without
+// one must be the last. A Comment record is an error when the Dialect
+// has no comment character.
+func (w *Writer) Write(rec Record) error {
+	switch rec.Kind {
+	case Comment:
+		if w.d.Comment == 0 {
+			return errors.New("csvtok: comment record, but the dialect has no comment character")
+		}
+		w.bw.WriteRune(w.d.Comment)
+		w.bw.WriteString(rec.Text)
+	case Data:
+		for i, f := range rec.Fields {
+			if i > 0 {
+				w.bw.WriteString(w.comma)
+			}
+			w.field(f.Value, f.Quoted || w.needsQuotes(f.Value, i, len(rec.Fields), rec.EOL))
+		}
+	}
+	_, err := w.bw.WriteString(rec.EOL)
+	return err
+}
+
+// WriteRow writes a data record, quoting only the values that need it,
+// and ends it with w.EOL.
+func (w *Writer) WriteRow(values ...string) error {
+	eol := w.EOL
+	if eol == "" {
+		eol = "\r\n"
+	}
+	for i, v := range values {
+		if i > 0 {
+			w.bw.WriteString(w.comma)
+		}
+		w.field(v, w.needsQuotes(v, i, len(values), eol))
+	}
+	_, err := w.bw.WriteString(eol)
+	return err
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *Writer) Flush() error { return w.bw.Flush() }
+
+func (w *Writer) field(v string, quoted bool) {
+	if !quoted {
+		w.bw.WriteString(v)
+		return
+	}
+	w.bw.WriteByte('"')
+	w.bw.WriteString(strings.ReplaceAll(v, `"`, `""`))
+	w.bw.WriteByte('"')
+}
+
+// needsQuotes reports whether v, as field i of n in a record ending with
+// eol, would read back differently without quotes. The rules are exactly
+// the Reader's, so that a field read without quotes is written without
+// them.
+func (w *Writer) needsQuotes(v string, i, n int, eol string) bool {
+	switch {
+	case v == "":
+		return n == 1 // a lone empty field would read back as a blank line
+	case strings.Contains(v, w.comma), strings.ContainsAny(v, "\"\n"):
+		return true
+	case i == 0 && w.d.Comment != 0 && strings.HasPrefix(v, string(w.d.Comment)):
+		return true
+	case i == n-1 && eol == "\n" && strings.HasSuffix(v, "\r"):
+		return true // would merge with "\n" into a CRLF line ending
+	}
+	return false
+}
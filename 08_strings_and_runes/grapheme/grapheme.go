@@ -0,0 +1,287 @@
+// Package grapheme splits text into user-perceived characters ("extended
+// grapheme clusters") following Unicode UAX #29.
+//
+// 08_strings_and_runes shows that len("🇺🇦") is 8 and that
+// utf8.RuneCountInString says 2. Neither is what a person sees: one flag.
+// A grapheme cluster is that "one character":
+//
+//	"🇺🇦"        2 runes (two regional indicators)   -> 1 grapheme
+//	"é"         e + U+0301 COMBINING ACUTE ACCENT  -> 1 grapheme
+//	"👩‍💻"        woman + ZWJ + laptop               -> 1 grapheme
+//
+// The property tables live in graphemes.txt, embedded at build time and
+// parsed on first use. grapheme_test.go checks the segmenter against the
+// official GraphemeBreakTest cases.
+package grapheme
+
+import (
+	_ "embed"
+	"fmt"
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ---------------------------------------------------------
+// 1. PROPERTY TABLES
+// ---------------------------------------------------------
+
+// gcb is the Grapheme_Cluster_Break property.
+type gcb uint8
+
+const (
+	other gcb = iota
+	cr
+	lf
+	control
+	extend
+	zwj
+	regionalIndicator
+	prepend
+	spacingMark
+	hangulL
+	hangulV
+	hangulT
+	hangulLV
+	hangulLVT
+)
+
+// incb is the Indic_Conjunct_Break property, used by rule GB9c.
+type incb uint8
+
+const (
+	incbNone incb = iota
+	incbConsonant
+	incbExtend
+	incbLinker
+)
+
+// props is everything the break rules need to know about one rune.
+type props struct {
+	gcb     gcb
+	incb    incb
+	extPict bool // Extended_Pictographic
+}
+
+type span struct {
+	lo, hi rune
+	val    uint8
+}
+
+type tables struct {
+	gcb     []span
+	extPict []span
+	incb    []span
+}
+
+//go:embed graphemes.txt
+var data string
+
+var load = sync.OnceValue(func() *tables {
+	t, err := parse(data)
+	if err != nil {
+		panic(err) // graphemes.txt is part of the source; a bad edit is a bug
+	}
+	return t
+})
+
+func parse(src string) (*tables, error) {
+	t := &tables{}
+	byName := map[string]struct {
+		table *[]span
+		val   uint8
+	}{
+		"CR":                    {&t.gcb, uint8(cr)},
+		"LF":                    {&t.gcb, uint8(lf)},
+		"Control":               {&t.gcb, uint8(control)},
+		"Extend":                {&t.gcb, uint8(extend)},
+		"ZWJ":                   {&t.gcb, uint8(zwj)},
+		"Regional_Indicator":    {&t.gcb, uint8(regionalIndicator)},
+		"Prepend":               {&t.gcb, uint8(prepend)},
+		"SpacingMark":           {&t.gcb, uint8(spacingMark)},
+		"L":                     {&t.gcb, uint8(hangulL)},
+		"V":                     {&t.gcb, uint8(hangulV)},
+		"T":                     {&t.gcb, uint8(hangulT)},
+		"LV":                    {&t.gcb, uint8(hangulLV)},
+		"LVT":                   {&t.gcb, uint8(hangulLVT)},
+		"Extended_Pictographic": {&t.extPict, 1},
+		"InCB_Consonant":        {&t.incb, uint8(incbConsonant)},
+		"InCB_Extend":           {&t.incb, uint8(incbExtend)},
+		"InCB_Linker":           {&t.incb, uint8(incbLinker)},
+	}
+	for i, line := range strings.Split(src, "\n") {
+		if j := strings.IndexByte(line, '#'); j >= 0 {
+			line = line[:j]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		cps, name, ok := strings.Cut(line, ";")
+		if !ok {
+			return nil, fmt.Errorf("grapheme: line %d: missing ';'", i+1)
+		}
+		dst, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("grapheme: line %d: unknown property %q", i+1, strings.TrimSpace(name))
+		}
+		first, last, isRange := strings.Cut(strings.TrimSpace(cps), "..")
+		lo, err := strconv.ParseUint(first, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("grapheme: line %d: %w", i+1, err)
+		}
+		hi := lo
+		if isRange {
+			if hi, err = strconv.ParseUint(last, 16, 32); err != nil {
+				return nil, fmt.Errorf("grapheme: line %d: %w", i+1, err)
+			}
+		}
+		*dst.table = append(*dst.table, span{rune(lo), rune(hi), dst.val})
+	}
+	for _, s := range []*[]span{&t.gcb, &t.extPict, &t.incb} {
+		sort.Slice(*s, func(i, j int) bool { return (*s)[i].lo < (*s)[j].lo })
+	}
+	return t, nil
+}
+
+// find binary-searches a sorted span table; 0 means "not listed".
+func find(table []span, r rune) uint8 {
+	i := sort.Search(len(table), func(i int) bool { return table[i].hi >= r })
+	if i < len(table) && table[i].lo <= r {
+		return table[i].val
+	}
+	return 0
+}
+
+func lookup(r rune) props {
+	// Printable ASCII is by far the most common input and is all Other.
+	if r >= 0x20 && r < 0x7F {
+		return props{}
+	}
+	t := load()
+	return props{
+		gcb:     gcb(find(t.gcb, r)),
+		incb:    incb(find(t.incb, r)),
+		extPict: find(t.extPict, r) != 0,
+	}
+}
+
+// ---------------------------------------------------------
+// 2. BREAK RULES
+// ---------------------------------------------------------
+
+// state is what the rules need to remember about the cluster so far.
+type state struct {
+	prev     gcb
+	ri       int  // regional indicators in a row, ending at prev (GB12, GB13)
+	emoji    bool // prev ends ExtPict Extend* (GB11)
+	emojiZWJ bool // prev is the ZWJ in ExtPict Extend* ZWJ (GB11)
+	conjunct int  // GB9c: 0 none, 1 Consonant [Extend|Linker]*, 2 ... with a Linker
+}
+
+func (s *state) advance(p props) {
+	if p.gcb == regionalIndicator {
+		s.ri++
+	} else {
+		s.ri = 0
+	}
+
+	s.emojiZWJ = p.gcb == zwj && s.emoji
+	s.emoji = p.extPict || (s.emoji && p.gcb == extend)
+
+	switch {
+	case p.incb == incbConsonant:
+		s.conjunct = 1
+	case s.conjunct > 0 && p.incb == incbLinker:
+		s.conjunct = 2
+	case s.conjunct > 0 && p.incb == incbExtend:
+		// stays as it is
+	default:
+		s.conjunct = 0
+	}
+
+	s.prev = p.gcb
+}
+
+// breaks reports whether there is a boundary between the cluster so far
+// and a rune with properties p. Rule numbers are those of UAX #29.
+func (s *state) breaks(p props) bool {
+	switch {
+	case s.prev == cr && p.gcb == lf: // GB3
+		return false
+	case s.prev == cr || s.prev == lf || s.prev == control: // GB4
+		return true
+	case p.gcb == cr || p.gcb == lf || p.gcb == control: // GB5
+		return true
+	case s.prev == hangulL && (p.gcb == hangulL || p.gcb == hangulV || p.gcb == hangulLV || p.gcb == hangulLVT): // GB6
+		return false
+	case (s.prev == hangulLV || s.prev == hangulV) && (p.gcb == hangulV || p.gcb == hangulT): // GB7
+		return false
+	case (s.prev == hangulLVT || s.prev == hangulT) && p.gcb == hangulT: // GB8
+		return false
+	case p.gcb == extend || p.gcb == zwj: // GB9
+		return false
+	case p.gcb == spacingMark: // GB9a
+		return false
+	case s.prev == prepend: // GB9b
+		return false
+	case s.conjunct == 2 && p.incb == incbConsonant: // GB9c
+		return false
+	case s.emojiZWJ && p.extPict: // GB11
+		return false
+	case s.prev == regionalIndicator && p.gcb == regionalIndicator && s.ri%2 == 1: // GB12, GB13
+		return false
+	}
+	return true // GB999
+}
+
+// ---------------------------------------------------------
+// 3. API
+// ---------------------------------------------------------
+
+// First splits s into its first grapheme cluster and the rest. An
+// invalid UTF-8 byte is treated as one U+FFFD, as a range loop would.
+func First(s string) (cluster, rest string) {
+	if s == "" {
+		return "", ""
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	var st state
+	st.advance(lookup(r))
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		p := lookup(r)
+		if st.breaks(p) {
+			break
+		}
+		st.advance(p)
+		n += size
+	}
+	return s[:n], s[n:]
+}
+
+// Graphemes yields the grapheme clusters of s in order.
+func Graphemes(s string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for rest := s; rest != ""; {
+			var g string
+			g, rest = First(rest)
+			if !yield(g) {
+				return
+			}
+		}
+	}
+}
+
+// GraphemeCount returns the number of grapheme clusters in s: the
+// length a person would count.
+func GraphemeCount(s string) int {
+	n := 0
+	for s != "" {
+		_, s = First(s)
+		n++
+	}
+	return n
+}
@@ -0,0 +1,98 @@
+package grapheme
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestGraphemeBreak runs the official cases for Unicode 17.0.0. Each line
+// lists code points separated by ÷ (boundary) or × (no boundary); the
+// string is rebuilt, segmented and compared.
+func TestGraphemeBreak(t *testing.T) {
+	f, err := os.Open("testdata/GraphemeBreakTest.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	cases := 0
+	sc := bufio.NewScanner(f)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line, comment, _ := strings.Cut(sc.Text(), "#")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		input, want, err := parseCase(line)
+		if err != nil {
+			t.Fatalf("line %d: %v", lineNo, err)
+		}
+		cases++
+		if got := slices.Collect(Graphemes(input)); !slices.Equal(got, want) {
+			t.Errorf("line %d: %s\n want %s\n got  %s\n %s",
+				lineNo, strings.TrimSpace(line), hex(want), hex(got), strings.TrimSpace(comment))
+		}
+		if n := GraphemeCount(input); n != len(want) {
+			t.Errorf("line %d: GraphemeCount = %d, want %d", lineNo, n, len(want))
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if cases == 0 {
+		t.Fatal("no test cases read")
+	}
+}
+
+func TestGraphemesReuse(t *testing.T) {
+	seq := Graphemes("🇺🇦 é")
+	first := slices.Collect(seq)
+	second := slices.Collect(seq)
+	if len(first) != 3 || !slices.Equal(first, second) {
+		t.Errorf("ranging twice gave %q, then %q", first, second)
+	}
+}
+
+// parseCase turns "÷ 0020 × 0308 ÷ 0020 ÷" into the input string and the
+// expected clusters.
+func parseCase(line string) (string, []string, error) {
+	var input, cur strings.Builder
+	var want []string
+	for _, f := range strings.Fields(line) {
+		switch f {
+		case "÷":
+			if cur.Len() > 0 {
+				want = append(want, cur.String())
+				cur.Reset()
+			}
+		case "×":
+		default:
+			cp, err := strconv.ParseUint(f, 16, 32)
+			if err != nil {
+				return "", nil, err
+			}
+			input.WriteRune(rune(cp))
+			cur.WriteRune(rune(cp))
+		}
+	}
+	if cur.Len() > 0 {
+		return "", nil, fmt.Errorf("case does not end with ÷")
+	}
+	return input.String(), want, nil
+}
+
+func hex(clusters []string) string {
+	parts := make([]string, len(clusters))
+	for i, c := range clusters {
+		var cps []string
+		for _, r := range c {
+			cps = append(cps, fmt.Sprintf("%04X", r))
+		}
+		parts[i] = strings.Join(cps, " × ")
+	}
+	return "÷ " + strings.Join(parts, " ÷ ") + " ÷"
+}
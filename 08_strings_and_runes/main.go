@@ -44,11 +44,17 @@ func main() {
 	}
 	
 	// Note how the index jumps from 7 to 10. That's because '世' took bytes 7, 8, and 9.
+	// To see this for any input, including where broken UTF-8 turns into
+	// U+FFFD, run ./runeinfo/cmd/runeinspect.
 	fmt.Println()
 
 	// 3. COUNTING CHARACTERS
 	// To count actual human-readable characters, do not use len().
 	// Use the unicode/utf8 package.
+	// Runes are still not what users see: "🇺🇦" is 2 runes but 1 flag.
+	// For that, count grapheme clusters with ./grapheme.
+	// And a terminal draws "世界" in 4 columns, not 2: see ./width before
+	// padding a table with %-10s.
 	charCount := utf8.RuneCountInString(s)
 	fmt.Printf("Actual character count: %d\n", charCount)
 	fmt.Println()
@@ -65,6 +71,8 @@ func main() {
 
 	// ToLower / ToUpper
 	fmt.Println("Upper:", strings.ToUpper(trimmed))
+	// Lowercasing is one step of turning a title into a URL slug; ./slug
+	// does the rest, romanizing Ukrainian with ./translit on the way.
 
 	// Checking contents
 	fmt.Println("Contains 'Go':", strings.Contains(trimmed, "Go"))
@@ -72,11 +80,15 @@ func main() {
 	// Replacing
 	// -1 means replace ALL occurrences. 1 would replace only the first.
 	replaced := strings.Replace(trimmed, "Language", "Gopher", -1)
+	// For many patterns at once, chained Replace calls get slow and can
+	// rewrite each other's output; see ./multireplace.
 	fmt.Println("Replaced:", replaced)
 
 	// Splitting and Joining
 	sentence := "a,b,c,d"
 	parts := strings.Split(sentence, ",") // Returns a slice []string
+	// Fine for "a,b,c,d", wrong for real CSV: `1,"Kyiv, Ukraine"` has a
+	// comma inside quotes. ./csvtok reads CSV as RFC 4180 defines it.
 	fmt.Printf("Split: %v\n", parts)
 
 	joined := strings.Join(parts, "-")
@@ -101,6 +113,7 @@ func main() {
 //    if len(username) > 10 { ... } 
 //    This is risky if the user inputs emojis or non-English characters. 
 //    "🇺🇦" (Ukrainian flag emoji) is 8 bytes long but looks like 1 character.
+//    ./username validates names properly: NFC, grapheme length, one script.
 //
 // 3. Single quotes vs Double quotes
 //    "A" -> String (slice of bytes)
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/multireplace"
+)
+
+// ---------------------------------------------------------
+// TOPIC: One Pass for a Thousand Replacements
+// ---------------------------------------------------------
+// Usage:
+//   go run ./08_strings_and_runes/multireplace/cmd/replacedemo
+//   go test -bench . -benchmem ./08_strings_and_runes/multireplace
+//
+// Shows the matching rules on the lesson's sentence, then why one pass
+// differs from chained strings.Replace calls. The benchmarks in
+// multireplace_test.go compare chained strings.Replace,
+// strings.NewReplacer and multireplace.Replacer for 1 to 5000 patterns.
+
+func main() {
+	demo()
+}
+
+func demo() {
+	const s = "Go Language: Gophers write Go, GO and go; golang.org"
+	fmt.Println("text:", s)
+	for _, c := range []struct {
+		name string
+		opts multireplace.Options
+	}{
+		{"default", multireplace.Options{}},
+		{"IgnoreCase", multireplace.Options{IgnoreCase: true}},
+		{"WholeWord", multireplace.Options{WholeWord: true}},
+		{"both", multireplace.Options{IgnoreCase: true, WholeWord: true}},
+	} {
+		r, err := multireplace.New(c.opts, "Go", "[Go]", "Gopher", "[Gopher]", "Language", "[Language]")
+		if err != nil {
+			fail(err)
+		}
+		fmt.Printf("%-11s %s\n", c.name+":", r.Replace(s))
+	}
+
+	// Chained strings.Replace rewrites its own output: the second call
+	// finds "Go" inside the first call's "Gopher".
+	chained := strings.Replace(strings.Replace("Language", "Language", "Gopher", -1), "Go", "Golang", -1)
+	r, _ := multireplace.New(multireplace.Options{}, "Language", "Gopher", "Go", "Golang")
+	fmt.Printf("\nchained Replace: %q, one pass: %q\n", chained, r.Replace("Language"))
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,338 @@
+// Package multireplace applies many replacements to a string in one
+// pass, like strings.NewReplacer, with an Aho-Corasick automaton.
+//
+// 08_strings_and_runes calls strings.Replace once for one pattern. With
+// a thousand patterns, a thousand chained calls read the text a thousand
+// times, and a later pattern can match inside an earlier replacement.
+// A Replacer reads the text once and never looks at its own output:
+//
+//	r, _ := multireplace.New(multireplace.Options{}, "Language", "Gopher", "Go", "Golang")
+//	r.Replace("Go Language") // "Golang Gopher"
+//
+// Matching rules:
+//
+//   - Leftmost-longest: of all matches, the one that starts first wins,
+//     and of those starting there, the longest. With "Go" and "Gopher",
+//     "Gophers" becomes the replacement of "Gopher" plus "s". Matches do
+//     not overlap.
+//   - Options.IgnoreCase compares with Unicode simple case folding, so
+//     "київ" matches "КИЇВ" and "go" matches "GO".
+//   - Options.WholeWord only accepts matches with a word boundary on both
+//     sides, as \b does in package regexp: "Go" then no longer matches in
+//     "Gopher" or "Golang".
+//
+// The automaton is a DFA over byte classes: the bytes that occur in the
+// patterns get a column each and all other bytes share one, so the
+// table stays small while every input byte costs one lookup.
+package multireplace
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ---------------------------------------------------------
+// 1. BUILDING THE AUTOMATON
+// ---------------------------------------------------------
+
+// Options changes how patterns match.
+type Options struct {
+	IgnoreCase bool // Unicode simple case folding
+	WholeWord  bool // a word boundary (regexp \b) on both sides
+}
+
+var (
+	ErrOddArgs      = errors.New("multireplace: odd number of arguments")
+	ErrEmptyPattern = errors.New("multireplace: empty pattern")
+)
+
+// Replacer replaces a list of strings with replacements. It is safe for
+// concurrent use.
+type Replacer struct {
+	opts   Options
+	olds   []string // folded when IgnoreCase
+	news   []string
+	nclass int
+	class  [256]uint16
+	delta  []int32 // delta[state*nclass+class] is the next state
+	out    []int32 // pattern ending at the state, or -1
+	dict   []int32 // nearest suffix state with an output, or -1
+	depth  []int32 // length of the state's path from the root
+}
+
+// New builds a Replacer from old, new string pairs, like
+// strings.NewReplacer. If the same pattern is given twice the first
+// replacement wins. Patterns must not be empty.
+func New(opts Options, oldnew ...string) (*Replacer, error) {
+	if len(oldnew)%2 == 1 {
+		return nil, ErrOddArgs
+	}
+	r := &Replacer{opts: opts}
+	for i := 0; i < len(oldnew); i += 2 {
+		old := oldnew[i]
+		if old == "" {
+			return nil, fmt.Errorf("%w (pair %d)", ErrEmptyPattern, i/2)
+		}
+		if opts.IgnoreCase {
+			old, _ = fold(old)
+		}
+		r.olds = append(r.olds, old)
+		r.news = append(r.news, oldnew[i+1])
+	}
+	r.build()
+	return r, nil
+}
+
+// build makes the trie, then turns it into a DFA by following failure
+// links breadth-first.
+func (r *Replacer) build() {
+	// Byte classes: 0 for bytes no pattern uses.
+	for _, p := range r.olds {
+		for i := 0; i < len(p); i++ {
+			if r.class[p[i]] == 0 {
+				r.nclass++
+				r.class[p[i]] = uint16(r.nclass)
+			}
+		}
+	}
+	r.nclass++
+
+	// Trie. Missing edges are -1 until the DFA pass fills them in.
+	r.grow()
+	for i, p := range r.olds {
+		s := int32(0)
+		for j := 0; j < len(p); j++ {
+			at := int(s)*r.nclass + int(r.class[p[j]])
+			if r.delta[at] < 0 {
+				r.delta[at] = r.grow()
+				r.depth[r.delta[at]] = int32(j + 1)
+			}
+			s = r.delta[at]
+		}
+		if r.out[s] < 0 {
+			r.out[s] = int32(i)
+		}
+	}
+
+	// DFA. fail[s] is the longest proper suffix of s's path that is
+	// also a path in the trie; a missing edge goes where fail's edge goes.
+	fail := make([]int32, len(r.out))
+	queue := make([]int32, 0, len(r.out))
+	for c := range r.nclass {
+		if next := r.delta[c]; next < 0 {
+			r.delta[c] = 0
+		} else {
+			queue = append(queue, next)
+		}
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		f := fail[s]
+		if r.out[f] >= 0 {
+			r.dict[s] = f
+		} else {
+			r.dict[s] = r.dict[f]
+		}
+		for c := range r.nclass {
+			at := int(s)*r.nclass + c
+			via := r.delta[int(f)*r.nclass+c]
+			if next := r.delta[at]; next >= 0 {
+				fail[next] = via
+				queue = append(queue, next)
+			} else {
+				r.delta[at] = via
+			}
+		}
+	}
+}
+
+// grow adds a state with no edges and returns it.
+func (r *Replacer) grow() int32 {
+	for range r.nclass {
+		r.delta = append(r.delta, -1)
+	}
+	r.out = append(r.out, -1)
+	r.dict = append(r.dict, -1)
+	r.depth = append(r.depth, 0)
+	return int32(len(r.out) - 1)
+}
+
+// ---------------------------------------------------------
+// 2. MATCHING
+// ---------------------------------------------------------
+
+// Match is one replacement site: s[Start:End] is replaced by the
+// Index-th pair.
+type Match struct {
+	Start, End int
+	Index      int
+}
+
+// Matches yields the matches in s from left to right.
+func (r *Replacer) Matches(s string) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		t, orig := s, []int(nil)
+		if r.opts.IgnoreCase {
+			t, orig = fold(s)
+		}
+		at := func(i int) int {
+			if orig == nil {
+				return i
+			}
+			return orig[i]
+		}
+		accept := func(start, end int) bool {
+			return !r.opts.WholeWord || boundary(s, at(start)) && boundary(s, at(end))
+		}
+		for pos := 0; ; {
+			start, end, idx := r.find(t, pos, accept)
+			if idx < 0 || !yield(Match{at(start), at(end), idx}) {
+				return
+			}
+			pos = end
+		}
+	}
+}
+
+// find returns the leftmost-longest accepted match in t[from:], or an
+// index of -1.
+//
+// The automaton reports every match as it ends, but the leftmost-longest
+// one is only known once no longer match can still be growing from the
+// same start. The state's depth says how far back the current partial
+// match reaches; when that is past the best start, the best is final.
+// The caller resumes after the match, so text scanned beyond it is
+// scanned again from a fresh state.
+func (r *Replacer) find(t string, from int, accept func(start, end int) bool) (start, end, idx int) {
+	idx = -1
+	s := int32(0)
+	for i := from; i < len(t); i++ {
+		s = r.delta[int(s)*r.nclass+int(r.class[t[i]])]
+		if idx >= 0 && i+1-int(r.depth[s]) > start {
+			break
+		}
+		// Outputs at this position, longest (earliest start) first.
+		n := s
+		if r.out[n] < 0 {
+			n = r.dict[n]
+		}
+		for ; n >= 0; n = r.dict[n] {
+			b := i + 1 - int(r.depth[n])
+			if idx >= 0 && b > start {
+				break // not better than what we have
+			}
+			if accept(b, i+1) {
+				start, end, idx = b, i+1, int(r.out[n])
+				break
+			}
+		}
+	}
+	return start, end, idx
+}
+
+// Replace returns a copy of s with all replacements performed.
+func (r *Replacer) Replace(s string) string {
+	var b strings.Builder
+	last := 0
+	for m := range r.Matches(s) {
+		if last == 0 {
+			b.Grow(len(s))
+		}
+		b.WriteString(s[last:m.Start])
+		b.WriteString(r.news[m.Index])
+		last = m.End
+	}
+	if last == 0 {
+		return s // no match: patterns are never empty, so End > 0
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// WriteString writes s to w with all replacements performed.
+func (r *Replacer) WriteString(w io.Writer, s string) (n int, err error) {
+	last := 0
+	write := func(p string) {
+		if err == nil && p != "" {
+			var k int
+			k, err = io.WriteString(w, p)
+			n += k
+		}
+	}
+	for m := range r.Matches(s) {
+		write(s[last:m.Start])
+		write(r.news[m.Index])
+		if err != nil {
+			return n, err
+		}
+		last = m.End
+	}
+	write(s[last:])
+	return n, err
+}
+
+// ---------------------------------------------------------
+// 3. CASE AND WORDS
+// ---------------------------------------------------------
+
+// fold case-folds s. When the folded text has a different byte layout
+// (a few runes fold to a shorter or longer encoding, like U+212A KELVIN
+// SIGN to "k"), orig maps each byte offset in it back to s, with one
+// extra entry for the end. For ASCII, orig is nil: offsets are the same.
+func fold(s string) (folded string, orig []int) {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return strings.ToLower(s), nil
+	}
+	b := make([]byte, 0, len(s))
+	orig = make([]int, 0, len(s)+1)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		n := len(b)
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[i]) // keep invalid bytes as they are
+		} else {
+			b = utf8.AppendRune(b, foldRune(r))
+		}
+		for range len(b) - n {
+			orig = append(orig, i)
+		}
+		i += size
+	}
+	orig = append(orig, len(s))
+	return string(b), orig
+}
+
+// foldRune maps every rune of a case-folding orbit (K, k, U+212A) to the
+// same one.
+func foldRune(r rune) rune {
+	lowest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		lowest = min(lowest, f)
+	}
+	return unicode.ToLower(lowest)
+}
+
+// boundary reports whether s has a word boundary at byte offset i: a
+// word character on exactly one side.
+func boundary(s string, i int) bool {
+	before, _ := utf8.DecodeLastRuneInString(s[:i])
+	after, _ := utf8.DecodeRuneInString(s[i:])
+	return isWord(before, i > 0) != isWord(after, i < len(s))
+}
+
+func isWord(r rune, ok bool) bool {
+	return ok && (r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mn, unicode.Mc))
+}
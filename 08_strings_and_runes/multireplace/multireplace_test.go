@@ -0,0 +1,199 @@
+package multireplace
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------
+// BENCHMARKS: One Pass for a Thousand Replacements
+// ---------------------------------------------------------
+// go test -bench . -benchmem ./08_strings_and_runes/multireplace
+//
+// Three ways to apply N replacements to the same 16 KiB text:
+//
+//	chained   N calls to strings.Replace, as in the lesson
+//	std       strings.NewReplacer
+//	aho       Replacer
+//
+// Expect strings.Replace to win with one pattern (strings.Index is hard
+// to beat) and the automaton to pull ahead from about ten: its cost per
+// byte hardly depends on N.
+
+const textLen = 16 << 10
+
+var sink int
+
+// workload returns n old, new pairs and a text in which about a third of
+// the words are patterns. The patterns are random words of which none
+// contains another, so all three ways must agree; with overlapping
+// patterns chained Replace depends on the order of calls. The pairs are
+// sorted longest first, the order in which strings.NewReplacer also
+// picks the longest match.
+func workload(n, size int) (pairs []string, text string) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	word := func() string {
+		b := make([]byte, 5+rng.IntN(6))
+		for i := range b {
+			b[i] = 'a' + byte(rng.IntN(26))
+		}
+		return string(b)
+	}
+	var olds []string
+	for len(olds) < n {
+		w := word()
+		if !slices.ContainsFunc(olds, func(o string) bool {
+			return strings.Contains(o, w) || strings.Contains(w, o)
+		}) {
+			olds = append(olds, w)
+		}
+	}
+	slices.SortStableFunc(olds, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+	for i, o := range olds {
+		pairs = append(pairs, o, fmt.Sprintf("<%d>", i))
+	}
+
+	var b strings.Builder
+	for b.Len() < size {
+		if rng.IntN(3) == 0 {
+			b.WriteString(olds[rng.IntN(n)])
+		} else {
+			b.WriteString(word())
+		}
+		b.WriteByte(' ')
+	}
+	return pairs, b.String()
+}
+
+type way struct {
+	name    string
+	replace func(string) string
+}
+
+// ways returns the three replacers for pairs.
+func ways(tb testing.TB, pairs []string) []way {
+	chained := func(s string) string {
+		for i := 0; i < len(pairs); i += 2 {
+			s = strings.Replace(s, pairs[i], pairs[i+1], -1)
+		}
+		return s
+	}
+	aho, err := New(Options{}, pairs...)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return []way{
+		{"chained", chained},
+		{"std", strings.NewReplacer(pairs...).Replace},
+		{"aho", aho.Replace},
+	}
+}
+
+func TestAgreesWithStrings(t *testing.T) {
+	for _, n := range []int{1, 10, 100, 1000} {
+		pairs, text := workload(n, textLen)
+		ws := ways(t, pairs)
+		want := ws[0].replace(text)
+		for _, w := range ws[1:] {
+			if got := w.replace(text); got != want {
+				t.Errorf("N=%d: %s differs from chained strings.Replace", n, w.name)
+			}
+		}
+	}
+}
+
+// TestAgreesWithRegexp compares random replacers with package regexp,
+// which implements the same rules: an alternation of the patterns,
+// compiled with Longest, finds the leftmost-longest matches; (?i) folds
+// case by Unicode simple folding; \b marks a word boundary. Go's \b only
+// knows ASCII word characters, so whole-word cases use an ASCII alphabet.
+// The folding alphabet has runes whose folds differ in encoded length:
+// U+212A KELVIN SIGN and k, U+017F LONG S and s, U+1E9E and ß.
+func TestAgreesWithRegexp(t *testing.T) {
+	unicodeAlphabet := []string{"a", "b", "A", "k", "K", "\u212a", "s", "S", "\u017f", "ß", "\u1e9e", "ї", "Ї", "σ", "ς", "Σ", " "}
+	asciiAlphabet := []string{"a", "b", "A", "B", "k", "K", "_", "1", " ", "-"}
+	cases := 20_000
+	if testing.Short() {
+		cases = 2_000
+	}
+	rng := rand.New(rand.NewPCG(3, 4))
+	for _, opts := range []Options{{}, {IgnoreCase: true}, {WholeWord: true}, {IgnoreCase: true, WholeWord: true}} {
+		alphabet := unicodeAlphabet
+		if opts.WholeWord {
+			alphabet = asciiAlphabet
+		}
+		word := func(lo, hi int) string {
+			var b strings.Builder
+			for range lo + rng.IntN(hi-lo+1) {
+				b.WriteString(alphabet[rng.IntN(len(alphabet))])
+			}
+			return b.String()
+		}
+		for range cases {
+			var pairs []string
+			for i := range 1 + rng.IntN(4) {
+				pairs = append(pairs, word(1, 4), fmt.Sprintf("<%d>", i))
+			}
+			text := word(0, 24)
+			if got, want := replaceAll(t, opts, pairs, text), regexpReplace(opts, pairs, text); got != want {
+				t.Fatalf("%+v, pairs %q, text %q:\n got %q\nwant %q", opts, pairs, text, got, want)
+			}
+		}
+	}
+}
+
+func replaceAll(t *testing.T, opts Options, pairs []string, text string) string {
+	t.Helper()
+	r, err := New(opts, pairs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r.Replace(text)
+}
+
+// regexpReplace is the reference: each match is replaced by the first
+// pair whose pattern it equals, as New keeps the first of duplicates.
+func regexpReplace(opts Options, pairs []string, text string) string {
+	var alts []string
+	for i := 0; i < len(pairs); i += 2 {
+		alts = append(alts, regexp.QuoteMeta(pairs[i]))
+	}
+	expr := "(?:" + strings.Join(alts, "|") + ")"
+	if opts.WholeWord {
+		expr = `\b` + expr + `\b`
+	}
+	if opts.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+	re := regexp.MustCompile(expr)
+	re.Longest()
+	return re.ReplaceAllStringFunc(text, func(m string) string {
+		for i := 0; i < len(pairs); i += 2 {
+			if m == pairs[i] || opts.IgnoreCase && strings.EqualFold(m, pairs[i]) {
+				return pairs[i+1]
+			}
+		}
+		panic("no pattern for match " + m)
+	})
+}
+
+func BenchmarkReplace(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 1000, 5000} {
+		b.Run(fmt.Sprintf("N=%d", n), func(b *testing.B) {
+			pairs, text := workload(n, textLen)
+			for _, w := range ways(b, pairs) {
+				b.Run(w.name, func(b *testing.B) {
+					b.ReportAllocs()
+					for range b.N {
+						sink += len(w.replace(text))
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// Package norm implements Unicode canonical normalization (NFD and NFC)
+// with the standard library only.
+//
+// The same visible text can be spelled with different runes: "é" is
+// either U+00E9 or "e" + U+0301 COMBINING ACUTE ACCENT. The two strings
+// are not == in Go, so anything that compares user input (usernames,
+// search, map keys from 07_maps) should normalize first:
+//
+//	NFD  decompose everything:   "é" -> "e" + U+0301
+//	NFC  decompose, then recompose: both spellings -> U+00E9
+//
+// NFC is what the web and most operating systems produce, and the form to
+// store. The data tables live in normalization.txt and are parsed on
+// first use; norm_test.go checks both forms against the canonical
+// columns of the Unicode 17.0.0 normalization tests. Compatibility forms
+// (NFKC, NFKD) are out of scope.
+package norm
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ---------------------------------------------------------
+// 1. DATA
+// ---------------------------------------------------------
+
+type pair struct{ a, b rune }
+
+type tables struct {
+	ccc    map[rune]uint8
+	decomp map[rune][]rune
+	comp   map[pair]rune
+}
+
+//go:embed normalization.txt
+var data string
+
+var load = sync.OnceValue(func() *tables {
+	t, err := parse(data)
+	if err != nil {
+		panic(err) // normalization.txt is part of the source; a bad edit is a bug
+	}
+	return t
+})
+
+func parse(src string) (*tables, error) {
+	t := &tables{
+		ccc:    make(map[rune]uint8),
+		decomp: make(map[rune][]rune),
+		comp:   make(map[pair]rune),
+	}
+	section := ""
+	for i, line := range strings.Split(src, "\n") {
+		if j := strings.IndexByte(line, '#'); j >= 0 {
+			line = line[:j]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[]")
+			continue
+		}
+		left, right, ok := strings.Cut(line, ";")
+		if !ok {
+			return nil, fmt.Errorf("norm: line %d: missing ';'", i+1)
+		}
+		lhs, err := runes(left)
+		if err != nil {
+			return nil, fmt.Errorf("norm: line %d: %w", i+1, err)
+		}
+		switch section {
+		case "ccc":
+			class, err := strconv.ParseUint(strings.TrimSpace(right), 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("norm: line %d: %w", i+1, err)
+			}
+			lo, hi := lhs[0], lhs[0]
+			if len(lhs) == 2 {
+				hi = lhs[1]
+			}
+			for r := lo; r <= hi; r++ {
+				t.ccc[r] = uint8(class)
+			}
+		case "decomp":
+			rhs, err := runes(right)
+			if err != nil || len(lhs) != 1 {
+				return nil, fmt.Errorf("norm: line %d: bad decomposition", i+1)
+			}
+			t.decomp[lhs[0]] = rhs
+		case "comp":
+			rhs, err := runes(right)
+			if err != nil || len(lhs) != 2 || len(rhs) != 1 {
+				return nil, fmt.Errorf("norm: line %d: bad composition", i+1)
+			}
+			t.comp[pair{lhs[0], lhs[1]}] = rhs[0]
+		default:
+			return nil, fmt.Errorf("norm: line %d: data outside a section", i+1)
+		}
+	}
+	return t, nil
+}
+
+// runes parses "0041 0300" or a range "0300..0314" (returned as its two
+// ends).
+func runes(s string) ([]rune, error) {
+	var out []rune
+	for _, f := range strings.Fields(strings.ReplaceAll(s, "..", " ")) {
+		n, err := strconv.ParseUint(f, 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rune(n))
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no code points in %q", s)
+	}
+	return out, nil
+}
+
+// ---------------------------------------------------------
+// 2. HANGUL
+// ---------------------------------------------------------
+
+// Hangul syllables are composed arithmetically (Unicode 3.12) instead of
+// from a table: 11,172 syllables = 19 leading x 21 vowel x 28 trailing.
+const (
+	sBase  = 0xAC00
+	lBase  = 0x1100
+	vBase  = 0x1161
+	tBase  = 0x11A7
+	lCount = 19
+	vCount = 21
+	tCount = 28
+	nCount = vCount * tCount
+	sCount = lCount * nCount
+)
+
+func decomposeHangul(s rune, out []rune) []rune {
+	i := s - sBase
+	out = append(out, lBase+i/nCount, vBase+(i%nCount)/tCount)
+	if t := i % tCount; t != 0 {
+		out = append(out, tBase+t)
+	}
+	return out
+}
+
+func composeHangul(a, b rune) (rune, bool) {
+	switch {
+	case a >= lBase && a < lBase+lCount && b >= vBase && b < vBase+vCount:
+		return sBase + ((a-lBase)*vCount+(b-vBase))*tCount, true
+	case a >= sBase && a < sBase+sCount && (a-sBase)%tCount == 0 && b > tBase && b < tBase+tCount:
+		return a + (b - tBase), true
+	}
+	return 0, false
+}
+
+// ---------------------------------------------------------
+// 3. NFD AND NFC
+// ---------------------------------------------------------
+
+// CCC returns the Canonical_Combining_Class of r (0 for starters).
+func CCC(r rune) uint8 { return load().ccc[r] }
+
+func decompose(s string) []rune {
+	t := load()
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= sBase && r < sBase+sCount:
+			out = decomposeHangul(r, out)
+		case t.decomp[r] != nil:
+			out = append(out, t.decomp[r]...)
+		default:
+			out = append(out, r)
+		}
+	}
+	// Canonical ordering: within each run of non-starters, sort by
+	// combining class, keeping equal classes in order (a stable
+	// insertion sort; runs are short).
+	for i := 1; i < len(out); i++ {
+		c := t.ccc[out[i]]
+		if c == 0 {
+			continue
+		}
+		for j := i; j > 0; j-- {
+			p := t.ccc[out[j-1]]
+			if p == 0 || p <= c {
+				break
+			}
+			out[j-1], out[j] = out[j], out[j-1]
+		}
+	}
+	return out
+}
+
+func compose(rs []rune) []rune {
+	t := load()
+	out := rs[:0] // compose in place; the result is never longer
+	starter := -1 // index in out of the last starter
+	lastCC := -1  // class of the last rune after the starter; -1: none yet
+	for _, r := range rs {
+		cc := int(t.ccc[r])
+		// r can combine with the starter unless something in between
+		// "blocks" it: a starter, or a mark of the same or higher class.
+		if starter >= 0 && (lastCC == -1 || (lastCC != 0 && lastCC < cc)) {
+			c, ok := composeHangul(out[starter], r)
+			if !ok {
+				c, ok = t.comp[pair{out[starter], r}]
+			}
+			if ok {
+				out[starter] = c
+				continue
+			}
+		}
+		if cc == 0 {
+			starter, lastCC = len(out), -1
+		} else {
+			lastCC = cc
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// NFD returns the canonical decomposition of s. Invalid UTF-8 becomes
+// U+FFFD.
+func NFD(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	return string(decompose(s))
+}
+
+// NFC returns the canonical composition of s. Invalid UTF-8 becomes
+// U+FFFD.
+func NFC(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	return string(compose(decompose(s)))
+}
+
+// IsNFC reports whether s is already in NFC.
+func IsNFC(s string) bool {
+	return utf8.ValidString(s) && NFC(s) == s
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
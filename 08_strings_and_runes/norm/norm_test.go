@@ -0,0 +1,102 @@
+package norm
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// TestConformance runs testdata/normtest.txt: the canonical columns of
+// NormalizationTest.txt for Unicode 17.0.0, with the same rules.
+func TestConformance(t *testing.T) {
+	f, err := os.Open("testdata/normtest.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	listed := make(map[rune]bool) // code points with a line of their own
+	cases := 0
+	sc := bufio.NewScanner(f)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line, comment, _ := strings.Cut(sc.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "@") { // @Part0, @Part1, ...
+			continue
+		}
+		var c [3]string
+		fields := strings.Split(line, ";")
+		if len(fields) < len(c) {
+			t.Fatalf("line %d: want source;NFC;NFD;", lineNo)
+		}
+		for i := range c {
+			if c[i], err = parseHex(fields[i]); err != nil {
+				t.Fatalf("line %d: %v", lineNo, err)
+			}
+		}
+		if rs := []rune(c[0]); len(rs) == 1 {
+			listed[rs[0]] = true
+		}
+		cases++
+
+		// NFC(c1) = NFC(c2) = NFC(c3) = c2, NFD(c1) = NFD(c2) = NFD(c3) = c3.
+		for i, src := range c {
+			if got := NFC(src); got != c[1] {
+				t.Errorf("line %d: NFC(c%d) = %s, want %s  # %s", lineNo, i+1, hex(got), fields[1], comment)
+			}
+			if got := NFD(src); got != c[2] {
+				t.Errorf("line %d: NFD(c%d) = %s, want %s  # %s", lineNo, i+1, hex(got), fields[2], comment)
+			}
+		}
+		if !IsNFC(c[1]) || IsNFC(c[0]) != (c[0] == c[1]) {
+			t.Errorf("line %d: IsNFC disagrees with NFC", lineNo)
+		}
+		if t.Failed() && cases > 50 {
+			t.FailNow() // one bug fails thousands of lines
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) == 0 {
+		t.Fatal("no single code points read")
+	}
+
+	// Every other code point is unchanged by both forms.
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		if listed[r] || (r >= 0xD800 && r <= 0xDFFF) {
+			continue
+		}
+		if s := string(r); NFC(s) != s || NFD(s) != s {
+			t.Errorf("%04X has no line but NFC gives %s and NFD %s", r, hex(NFC(s)), hex(NFD(s)))
+		}
+	}
+}
+
+// parseHex turns "0044 0307" into the string it spells.
+func parseHex(field string) (string, error) {
+	var b strings.Builder
+	for _, f := range strings.Fields(field) {
+		cp, err := strconv.ParseUint(f, 16, 32)
+		if err != nil {
+			return "", err
+		}
+		b.WriteRune(rune(cp))
+	}
+	return b.String(), nil
+}
+
+func hex(s string) string {
+	var parts []string
+	for _, r := range s {
+		parts = append(parts, fmt.Sprintf("%04X", r))
+	}
+	return strings.Join(parts, " ")
+}
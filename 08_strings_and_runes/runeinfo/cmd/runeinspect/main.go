@@ -0,0 +1,143 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/runeinfo"
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/width"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Looking Inside a String, Byte by Byte
+// ---------------------------------------------------------
+// Usage:
+//   go run ./08_strings_and_runes/runeinfo/cmd/runeinspect
+//   go run ./08_strings_and_runes/runeinfo/cmd/runeinspect "Hello, 世界"
+//   go run ./08_strings_and_runes/runeinfo/cmd/runeinspect -file partner.csv -limit 200
+//   printf 'caf\xe9' | go run ./08_strings_and_runes/runeinfo/cmd/runeinspect -file -
+//
+// Prints one row per step of `for i, r := range s`: the byte offset i,
+// the bytes behind r, the code point, its Unicode name and category.
+// Invalid UTF-8 is marked with "!!" and the reason; range turns each bad
+// byte into U+FFFD, which is what the code point column shows.
+//
+// Without arguments it inspects the lesson's "Hello, 世界" (note the jump
+// from offset 7 to 10) and a Latin-1 "café" that was never converted.
+
+func main() {
+	file := flag.String("file", "", "read input from a file (- for stdin)")
+	limit := flag.Int("limit", 0, "stop after this many runes (0: no limit)")
+	onlyBad := flag.Bool("invalid", false, "list only invalid bytes and non-printing runes")
+	flag.Parse()
+
+	var inputs []string
+	switch {
+	case *file != "":
+		data, err := readFile(*file)
+		if err != nil {
+			fail(err)
+		}
+		inputs = []string{string(data)}
+	case flag.NArg() > 0:
+		inputs = flag.Args()
+	default:
+		inputs = []string{"Hello, 世界", "caf\xe9 \xe4\xb8"}
+	}
+
+	bad := 0
+	for i, s := range inputs {
+		if i > 0 {
+			fmt.Println()
+		}
+		bad += inspect(s, *limit, *onlyBad)
+	}
+	if bad > 0 {
+		os.Exit(1) // lets scripts check files: runeinspect -file x -invalid || ...
+	}
+}
+
+// inspect prints the table for s and returns the number of invalid
+// bytes.
+func inspect(s string, limit int, onlyBad bool) int {
+	t := width.NewTable("Offset", "Bytes", "Char", "Code point", "Category", "Name")
+	t.SetAlign(0, width.Right)
+	t.SetMaxWidth(5, 60)
+
+	runes, bad, shown := 0, 0, 0
+	for ri := range runeinfo.Inspect(s) {
+		runes++
+		if !ri.Valid() {
+			bad++
+		}
+		if onlyBad && ri.Valid() && unicode.IsGraphic(ri.Rune) {
+			continue
+		}
+		if limit > 0 && shown == limit {
+			continue // keep counting for the summary
+		}
+		shown++
+		hex := fmt.Sprintf("% x", ri.Bytes)
+		code := fmt.Sprintf("U+%04X", ri.Rune)
+		if !ri.Valid() {
+			t.Append(strconv.Itoa(ri.Offset), hex, "!!", code, "", "INVALID: "+ri.Problem)
+			continue
+		}
+		cat := runeinfo.Category(ri.Rune)
+		t.Append(strconv.Itoa(ri.Offset), hex, char(ri.Rune, cat), code, cat, runeinfo.Name(ri.Rune))
+	}
+
+	fmt.Printf("%s: %d bytes, %d runes (utf8.RuneCountInString), %d invalid byte(s)\n",
+		quote(s), len(s), runes, bad)
+	if err := t.Render(os.Stdout); err != nil {
+		fail(err)
+	}
+	if hidden := runes - shown; hidden > 0 && !onlyBad {
+		fmt.Printf("... %d more rune(s)\n", hidden)
+	}
+	if strings.Contains(s, "\uFFFD") {
+		fmt.Println("note: the input contains real U+FFFD (ef bf bd): it was damaged before it got here")
+	}
+	return bad
+}
+
+// char shows r so that it can be seen: combining marks sit on a dotted
+// circle, spaces and invisible runes are quoted rune literals.
+func char(r rune, cat string) string {
+	switch {
+	case cat == "Mn" || cat == "Me" || cat == "Mc":
+		return "◌" + string(r)
+	case !unicode.IsGraphic(r) || unicode.IsSpace(r):
+		return strconv.QuoteRune(r)
+	}
+	return string(r)
+}
+
+// quote shortens long inputs for the summary line.
+func quote(s string) string {
+	n := 0
+	for i := range s {
+		if n == 40 {
+			return strconv.Quote(s[:i]) + "..."
+		}
+		n++
+	}
+	return strconv.Quote(s)
+}
+
+func readFile(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(name)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
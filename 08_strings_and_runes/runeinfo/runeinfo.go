@@ -0,0 +1,332 @@
+// Package runeinfo looks up what a rune is and explains what a byte
+// string really contains.
+//
+// 08_strings_and_runes shows that s[7] is a byte and that range over a
+// string jumps from index 7 to 10 because 世 takes three bytes. When
+// input arrives mangled, the questions are the same but harder: which
+// bytes form which rune, what is that invisible thing at offset 41, and
+// where exactly is the UTF-8 broken? This package answers them:
+//
+//   - Name returns the Unicode character name ("CYRILLIC SMALL LETTER
+//     YI"), or a label such as "<private-use-E000>" for code points
+//     without one.
+//   - Category returns the general category ("Lu", "Mn", "Cf", ...).
+//   - Inspect walks a string the way range does and reports every rune
+//     with its offset and bytes. An invalid byte decodes as U+FFFD, as in
+//     range, and carries the reason it is invalid.
+//
+// The names (Unicode 17.0.0, about 35,000 of them) live in names.txt.gz
+// and are loaded on first use. Categories come from package unicode
+// instead, so they follow the toolchain: unicode.Version is 17.0.0 with
+// Go 1.27 but older with the Go 1.24 that go.mod allows. Built with an
+// older Go, a character added since has a Name and yet Category "Cn".
+package runeinfo
+
+import (
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ---------------------------------------------------------
+// 1. NAMES
+// ---------------------------------------------------------
+
+// span is a run of code points named PREFIX-XXXX, e.g.
+// "CJK UNIFIED IDEOGRAPH-4E16".
+type span struct {
+	lo, hi rune
+	prefix string
+}
+
+type tables struct {
+	names   map[rune]string
+	aliases map[rune]string // control characters: "LINE FEED (LF)"
+	spans   []span          // sorted by lo
+}
+
+//go:embed names.txt.gz
+var data []byte
+
+var load = sync.OnceValue(func() *tables {
+	t, err := parse(data)
+	if err != nil {
+		panic(err) // names.txt.gz is part of the source; a bad edit is a bug
+	}
+	return t
+})
+
+func parse(gz []byte) (*tables, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, fmt.Errorf("runeinfo: %w", err)
+	}
+	src, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("runeinfo: %w", err)
+	}
+	t := &tables{
+		names:   make(map[rune]string, 35000),
+		aliases: make(map[rune]string),
+	}
+	for i, line := range strings.Split(string(src), "\n") {
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		code, name, ok := strings.Cut(line, ";")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("runeinfo: line %d: want CODE;NAME", i+1)
+		}
+		first, last, isRange := strings.Cut(code, "..")
+		lo, err := strconv.ParseUint(first, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("runeinfo: line %d: %w", i+1, err)
+		}
+		if isRange {
+			hi, err := strconv.ParseUint(last, 16, 32)
+			if err != nil || hi < lo || !strings.HasSuffix(name, "-#") {
+				return nil, fmt.Errorf("runeinfo: line %d: bad range", i+1)
+			}
+			t.spans = append(t.spans, span{rune(lo), rune(hi), strings.TrimSuffix(name, "#")})
+			continue
+		}
+		if alias, ok := strings.CutPrefix(name, "<control>"); ok {
+			t.aliases[rune(lo)] = strings.TrimSpace(alias)
+			continue
+		}
+		t.names[rune(lo)] = name
+	}
+	slices.SortFunc(t.spans, func(a, b span) int { return int(a.lo - b.lo) })
+	return t, nil
+}
+
+// Name returns the Unicode name of r, e.g. "LATIN CAPITAL LETTER A" or
+// "CJK UNIFIED IDEOGRAPH-4E16".
+//
+// Code points without a name get a label in angle brackets, as Unicode
+// (section 4.8) recommends: "<control-000A>", "<private-use-E000>",
+// "<surrogate-D800>", "<noncharacter-FFFE>" or "<reserved-0378>" for
+// code points not assigned in Unicode 17. Control labels are followed by
+// the control's usual name when it has one: "<control-000A> LINE FEED
+// (LF)".
+func Name(r rune) string {
+	if n, ok := lookup(r); ok {
+		return n
+	}
+	label := fmt.Sprintf("<%s-%04X>", kind(r), r)
+	if alias := load().aliases[r]; alias != "" {
+		label += " " + alias
+	}
+	return label
+}
+
+func lookup(r rune) (string, bool) {
+	if r >= sBase && r < sBase+sCount {
+		return hangulName(r), true
+	}
+	t := load()
+	if n, ok := t.names[r]; ok {
+		return n, true
+	}
+	i, found := slices.BinarySearchFunc(t.spans, r, func(s span, r rune) int {
+		switch {
+		case r < s.lo:
+			return 1
+		case r > s.hi:
+			return -1
+		}
+		return 0
+	})
+	if found {
+		return fmt.Sprintf("%s%04X", t.spans[i].prefix, r), true
+	}
+	return "", false
+}
+
+// kind names the sort of code point r is when it has no name.
+func kind(r rune) string {
+	switch {
+	case r < 0 || r > unicode.MaxRune:
+		return "invalid"
+	case unicode.Is(unicode.Cc, r):
+		return "control"
+	case r >= 0xD800 && r <= 0xDFFF:
+		return "surrogate"
+	case r >= 0xFDD0 && r <= 0xFDEF, r&0xFFFE == 0xFFFE:
+		return "noncharacter"
+	case unicode.Is(unicode.Co, r):
+		return "private-use"
+	}
+	return "reserved"
+}
+
+// Hangul syllable names are built from their jamo (Unicode 3.12):
+// U+D55C is HAN = H + A + N, "HANGUL SYLLABLE HAN".
+const (
+	sBase  = 0xAC00
+	tCount = 28
+	nCount = 21 * tCount
+	sCount = 19 * nCount
+)
+
+var (
+	jamoL = []string{"G", "GG", "N", "D", "DD", "R", "M", "B", "BB", "S", "SS", "", "J", "JJ", "C", "K", "T", "P", "H"}
+	jamoV = []string{"A", "AE", "YA", "YAE", "EO", "E", "YEO", "YE", "O", "WA", "WAE", "OE", "YO", "U", "WEO", "WE", "WI", "YU", "EU", "YI", "I"}
+	jamoT = []string{"", "G", "GG", "GS", "N", "NJ", "NH", "D", "L", "LG", "LM", "LB", "LS", "LT", "LP", "LH", "M", "B", "BS", "S", "SS", "NG", "J", "C", "K", "T", "P", "H"}
+)
+
+func hangulName(r rune) string {
+	i := r - sBase
+	return "HANGUL SYLLABLE " + jamoL[i/nCount] + jamoV[(i%nCount)/tCount] + jamoT[i%tCount]
+}
+
+// ---------------------------------------------------------
+// 2. CATEGORIES
+// ---------------------------------------------------------
+
+// categories lists the two-letter general categories with their long
+// names. "Cn" (unassigned) has no table in package unicode; it is what
+// is left over.
+var categories = []struct {
+	code, name string
+}{
+	{"Lu", "Uppercase_Letter"}, {"Ll", "Lowercase_Letter"}, {"Lt", "Titlecase_Letter"},
+	{"Lm", "Modifier_Letter"}, {"Lo", "Other_Letter"},
+	{"Mn", "Nonspacing_Mark"}, {"Mc", "Spacing_Mark"}, {"Me", "Enclosing_Mark"},
+	{"Nd", "Decimal_Number"}, {"Nl", "Letter_Number"}, {"No", "Other_Number"},
+	{"Pc", "Connector_Punctuation"}, {"Pd", "Dash_Punctuation"}, {"Ps", "Open_Punctuation"},
+	{"Pe", "Close_Punctuation"}, {"Pi", "Initial_Punctuation"}, {"Pf", "Final_Punctuation"},
+	{"Po", "Other_Punctuation"},
+	{"Sm", "Math_Symbol"}, {"Sc", "Currency_Symbol"}, {"Sk", "Modifier_Symbol"}, {"So", "Other_Symbol"},
+	{"Zs", "Space_Separator"}, {"Zl", "Line_Separator"}, {"Zp", "Paragraph_Separator"},
+	{"Cc", "Control"}, {"Cf", "Format"}, {"Cs", "Surrogate"}, {"Co", "Private_Use"},
+}
+
+// Category returns the two-letter general category of r: "Lu" for "A",
+// "Mn" for a combining accent, "Cf" for a zero-width joiner, "Cn" for an
+// unassigned code point. It uses the tables of package unicode, which
+// may be older than the names; see the package comment.
+func Category(r rune) string {
+	for _, c := range categories {
+		if unicode.Is(unicode.Categories[c.code], r) {
+			return c.code
+		}
+	}
+	return "Cn"
+}
+
+// CategoryName returns the long name of a category code, e.g.
+// "Nonspacing_Mark" for "Mn", or "" for an unknown code.
+func CategoryName(code string) string {
+	if code == "Cn" {
+		return "Unassigned"
+	}
+	for _, c := range categories {
+		if c.code == code {
+			return c.name
+		}
+	}
+	return ""
+}
+
+// ---------------------------------------------------------
+// 3. INSPECTING BYTES
+// ---------------------------------------------------------
+
+// Rune is one step of range over a string.
+type Rune struct {
+	Offset int    // byte offset, the index range gives
+	Bytes  string // the bytes it was decoded from
+	Rune   rune   // the value range gives; utf8.RuneError for an invalid byte
+	// Problem is empty for valid UTF-8. For an invalid byte it says why,
+	// e.g. "unexpected continuation byte". range turns every such byte
+	// into its own U+FFFD and moves on by one byte.
+	Problem string
+}
+
+// Valid reports whether the rune was decoded from valid UTF-8. A U+FFFD
+// that was already in the input (bytes EF BF BD) is valid: it was broken
+// somewhere upstream.
+func (r Rune) Valid() bool { return r.Problem == "" }
+
+// Inspect yields the runes of s exactly as
+//
+//	for i, r := range s
+//
+// would, with the bytes behind each one and the reason for every invalid
+// byte.
+func Inspect(s string) iter.Seq[Rune] {
+	return func(yield func(Rune) bool) {
+		for i := 0; i < len(s); {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			ri := Rune{Offset: i, Bytes: s[i : i+size], Rune: r}
+			if r == utf8.RuneError && size == 1 {
+				ri.Problem = Problem(s[i:])
+			}
+			if !yield(ri) {
+				return
+			}
+			i += size
+		}
+	}
+}
+
+// Problem explains why the UTF-8 sequence at the start of p is invalid,
+// or returns "" if it is valid (or p is empty).
+func Problem(p string) string {
+	if p == "" {
+		return ""
+	}
+	if r, size := utf8.DecodeRuneInString(p); r != utf8.RuneError || size > 1 {
+		return ""
+	}
+	b := p[0]
+	var n int // expected length
+	switch {
+	case b < 0x80:
+		return "" // unreachable: ASCII always decodes
+	case b < 0xC0:
+		return "unexpected continuation byte"
+	case b < 0xC2:
+		return "overlong encoding of an ASCII character"
+	case b < 0xE0:
+		n = 2
+	case b < 0xF0:
+		n = 3
+	case b < 0xF5:
+		n = 4
+	default:
+		return fmt.Sprintf("byte 0x%02X never appears in UTF-8", b)
+	}
+	if len(p) < 2 {
+		return fmt.Sprintf("%d-byte sequence cut off at the end", n)
+	}
+	// The second byte has a narrower range after some lead bytes.
+	c := p[1]
+	switch {
+	case b == 0xE0 && c >= 0x80 && c < 0xA0, b == 0xF0 && c >= 0x80 && c < 0x90:
+		return "overlong encoding"
+	case b == 0xED && c >= 0xA0 && c <= 0xBF:
+		return "UTF-16 surrogate (U+D800-U+DFFF) encoded as UTF-8"
+	case b == 0xF4 && c >= 0x90 && c <= 0xBF:
+		return "code point beyond U+10FFFF"
+	}
+	for i := 1; i < n; i++ {
+		if i == len(p) {
+			return fmt.Sprintf("%d-byte sequence cut off at the end", n)
+		}
+		if p[i]&0xC0 != 0x80 {
+			return fmt.Sprintf("%d-byte sequence cut short after %d byte(s)", n, i)
+		}
+	}
+	return "invalid sequence"
+}
@@ -0,0 +1,48 @@
+package runeinfo
+
+import (
+	"testing"
+	"unicode"
+)
+
+func TestNameAndCategory(t *testing.T) {
+	for _, tc := range []struct {
+		r              rune
+		name, category string
+	}{
+		{'A', "LATIN CAPITAL LETTER A", "Lu"},
+		{'ї', "CYRILLIC SMALL LETTER YI", "Ll"},
+		{'世', "CJK UNIFIED IDEOGRAPH-4E16", "Lo"},
+		{'한', "HANGUL SYLLABLE HAN", "Lo"},
+		{'\u0301', "COMBINING ACUTE ACCENT", "Mn"},
+		{'\u200D', "ZERO WIDTH JOINER", "Cf"},
+		{'\n', "<control-000A> LINE FEED (LF)", "Cc"},
+		{'\uE000', "<private-use-E000>", "Co"},
+		{'\uFFFE', "<noncharacter-FFFE>", "Cn"},
+		{0x0378, "<reserved-0378>", "Cn"},
+	} {
+		if got := Name(tc.r); got != tc.name {
+			t.Errorf("Name(%U) = %q, want %q", tc.r, got, tc.name)
+		}
+		if got := Category(tc.r); got != tc.category {
+			t.Errorf("Category(%U) = %q, want %q", tc.r, got, tc.category)
+		}
+	}
+}
+
+// TestVersions checks that names and categories agree on which code
+// points are assigned. They can only agree when package unicode has the
+// version of names.txt.gz.
+func TestVersions(t *testing.T) {
+	if unicode.Version != "17.0.0" {
+		t.Skipf("package unicode is %s, the names are 17.0.0", unicode.Version)
+	}
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		_, named := lookup(r)
+		if cat := Category(r); named && cat == "Cn" {
+			t.Errorf("%U has the name %q but category Cn", r, Name(r))
+		} else if !named && cat != "Cn" && cat != "Cc" && cat != "Cs" && cat != "Co" {
+			t.Errorf("%U has category %s but no name", r, cat)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/slug"
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/width"
+)
+
+// ---------------------------------------------------------
+// TOPIC: URL Slugs from Real-World Titles
+// ---------------------------------------------------------
+// Usage:
+//   go run ./08_strings_and_runes/slug/cmd/slugify
+//   go run ./08_strings_and_runes/slug/cmd/slugify "Київ — столиця України!"
+//   go run ./08_strings_and_runes/slug/cmd/slugify -max 20 -sep _ "Crème brûlée, Ґорґани і Стрий"
+//
+// Without arguments it slugifies a set of titles that mix Ukrainian,
+// accented Latin, emoji and punctuation. The last one is long enough to
+// be cut at a word boundary by -max 60.
+
+func main() {
+	maxLen := flag.Int("max", slug.Default.MaxLength, "maximum slug length in bytes (0: no limit)")
+	sep := flag.String("sep", slug.Default.Separator, "word separator")
+	flag.Parse()
+	opts := slug.Options{MaxLength: *maxLen, Separator: *sep}
+
+	titles := flag.Args()
+	if len(titles) == 0 {
+		titles = []string{
+			"Київ — столиця України!",
+			"Crème brûlée: 5 рецептів (2026)",
+			"Ukrainian Hryvnia (UAH) / Гривня",
+			"П'ять причин відвідати Ґорґани",
+			"Don't panic... it's only Go 🐹",
+			"Згурський, Ярошенко & Юрій",
+			"Straße nach Łódź",
+			"東京 Tokyo",
+			"東京", // nothing to keep: empty slug, use an ID instead
+			"Чому len(\"🇺🇦\") дорівнює 8, а не 1: байти, руни та графеми в Go і не тільки",
+		}
+	}
+
+	t := width.NewTable("Title", "Slug", "Len")
+	t.SetMaxWidth(0, 40)
+	t.SetAlign(2, width.Right)
+	for _, title := range titles {
+		s := opts.Make(title)
+		t.Append(title, s, strconv.Itoa(len(s)))
+	}
+	if err := t.Render(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,124 @@
+// Package slug turns titles into URL path segments:
+//
+//	"Київ — столиця України!"         -> "kyiv-stolytsia-ukrainy"
+//	"Crème brûlée: 5 рецептів (2026)" -> "creme-brulee-5-retseptiv-2026"
+//
+// A slug contains only a-z, 0-9 and the separator, so it survives every
+// URL, file system and log line unescaped. To get there:
+//
+//  1. The text is normalized to NFC (../norm) and Ukrainian is
+//     romanized with the official system (../translit).
+//  2. Latin letters lose their accents: "é" is "e" + U+0301 in NFD, and
+//     the accent is dropped. A few letters that are not built from an
+//     accent get a spelling of their own ("ß" -> "ss", "ł" -> "l").
+//  3. Apostrophes vanish ("don't" -> "dont", "п'ять" -> "piat"); every
+//     other run of spaces, punctuation and symbols becomes one separator.
+//  4. Letters with no Latin spelling (Chinese, Arabic, ...) are dropped
+//     like punctuation. Such a title can produce an empty slug; callers
+//     should fall back to an ID.
+//  5. The result is cut to MaxLength at a word boundary.
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/norm"
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/translit"
+)
+
+// Options configures Make.
+type Options struct {
+	// MaxLength limits the slug in bytes (which are also characters: a
+	// slug is ASCII). 0 means no limit.
+	MaxLength int
+	// Separator joins words. Empty means "-".
+	Separator string
+}
+
+// Default is what the package-level Make uses: "-" between words and at
+// most 80 bytes, which keeps URLs readable.
+var Default = Options{MaxLength: 80, Separator: "-"}
+
+// Make returns the slug of s with Default options.
+func Make(s string) string { return Default.Make(s) }
+
+// latin spells letters that NFD does not split into a base letter and an
+// accent, plus non-Ukrainian Cyrillic letters that show up in names and
+// quotes.
+var latin = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d",
+	'ð': "d", 'þ': "th", 'ı': "i", 'ħ': "h", 'ŀ': "l",
+	'ё': "yo", 'ы': "y", 'э': "e", 'ъ': "", 'ў': "u",
+}
+
+// Make returns the slug of s.
+func (o Options) Make(s string) string {
+	sep := o.Separator
+	if sep == "" {
+		sep = "-"
+	}
+
+	var b strings.Builder
+	pending := false // a separator is due before the next word
+	word := func(w string) {
+		if w == "" {
+			return
+		}
+		if pending && b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		pending = false
+		b.WriteString(w)
+	}
+	for _, r := range translit.Ukrainian(s) {
+		r = unicode.ToLower(r)
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			word(string(r))
+		case r == '\'' || r == '’' || r == 'ʼ':
+			// dropped without breaking the word
+		case unicode.IsLetter(r):
+			if w, ok := ascii(r); ok {
+				word(w)
+			} else {
+				pending = true
+			}
+		case unicode.Is(unicode.Mn, r):
+			// a mark NFC could not combine: drop it, keep the word
+		default:
+			pending = true
+		}
+	}
+	return cut(b.String(), o.MaxLength, sep)
+}
+
+// ascii spells a non-ASCII letter in a-z.
+func ascii(r rune) (string, bool) {
+	if w, ok := latin[r]; ok {
+		return w, true
+	}
+	var w strings.Builder
+	for _, d := range norm.NFD(string(r)) {
+		switch {
+		case d >= 'a' && d <= 'z':
+			w.WriteRune(d)
+		case unicode.Is(unicode.Mn, d):
+		default:
+			return "", false
+		}
+	}
+	return w.String(), w.Len() > 0
+}
+
+// cut shortens s to at most n bytes, preferring to end at a separator so
+// no word is cut in half. A single word longer than n is cut anyway.
+func cut(s string, n int, sep string) string {
+	if n <= 0 || len(s) <= n {
+		return s
+	}
+	if i := strings.LastIndex(s[:min(len(s), n+len(sep))], sep); i > 0 && i <= n {
+		return s[:i]
+	}
+	return strings.TrimSuffix(s[:n], sep)
+}
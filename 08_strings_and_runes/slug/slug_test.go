@@ -0,0 +1,86 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		// The package doc's examples.
+		{"Київ — столиця України!", "kyiv-stolytsia-ukrainy"},
+		{"Crème brûlée: 5 рецептів (2026)", "creme-brulee-5-retseptiv-2026"},
+		{"Don't panic", "dont-panic"},
+		{"П'ять ЗГАДОК про Згурівку", "piat-zghadok-pro-zghurivku"},
+		// Mixed scripts in one title.
+		{"Straße nach Łódź і Їжакевич", "strasse-nach-lodz-i-yizhakevych"},
+		{"Æsir, Øresund, þing", "aesir-oresund-thing"},
+		{"Ёлка, съезд, Беларусь: ў", "yolka-sezd-belarus-u"},
+		{"Go 语言 入门", "go"},
+		{"Привіт, 世界 2026", "pryvit-2026"},
+		{"été", "ete"}, // combining accents
+		// Runs of anything else become one separator, never at the ends.
+		{"  --Hello,   World!!  ", "hello-world"},
+		{"a/b\\c|d", "a-b-c-d"},
+		{"ÀÉÎÕÜ", "aeiou"},
+		// Nothing left.
+		{"", ""},
+		{"!!! ??? ...", ""},
+		{"世界", ""},
+		{"'’ʼ", ""},
+	} {
+		if got := Make(tt.in); got != tt.want {
+			t.Errorf("Make(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMaxLength(t *testing.T) {
+	title := "Київ — столиця України" // kyiv-stolytsia-ukrainy, 22 bytes
+	for _, tt := range []struct {
+		max  int
+		want string
+	}{
+		{0, "kyiv-stolytsia-ukrainy"},
+		{22, "kyiv-stolytsia-ukrainy"},
+		{21, "kyiv-stolytsia"},
+		{15, "kyiv-stolytsia"}, // the cut falls on the separator
+		{14, "kyiv-stolytsia"},
+		{13, "kyiv"},
+		{4, "kyiv"},
+		{3, "kyi"}, // a first word longer than the limit is cut anyway
+	} {
+		if got := (Options{MaxLength: tt.max}).Make(title); got != tt.want {
+			t.Errorf("MaxLength %d: %q, want %q", tt.max, got, tt.want)
+		}
+	}
+
+	long := Make(strings.Repeat("слово ", 30))
+	if len(long) > Default.MaxLength || strings.HasSuffix(long, "-") || !strings.HasSuffix(long, "slovo") {
+		t.Errorf("Make of a long title = %q (%d bytes), want whole words within %d", long, len(long), Default.MaxLength)
+	}
+}
+
+func TestSeparator(t *testing.T) {
+	title := "Київ — столиця України"
+	for _, tt := range []struct {
+		sep  string
+		max  int
+		want string
+	}{
+		{"_", 0, "kyiv_stolytsia_ukrainy"},
+		{"--", 0, "kyiv--stolytsia--ukrainy"},
+		{"·", 0, "kyiv·stolytsia·ukrainy"}, // 2 bytes
+		{"—", 0, "kyiv—stolytsia—ukrainy"}, // 3 bytes
+		{"—", 25, "kyiv—stolytsia"},        // 16 bytes
+		{"—", 18, "kyiv—stolytsia"},        // the cut falls inside the second separator
+		{"—", 16, "kyiv—stolytsia"},
+		{"—", 15, "kyiv"},
+		{"—", 5, "kyiv"}, // the cut falls inside the first separator
+	} {
+		got := (Options{Separator: tt.sep, MaxLength: tt.max}).Make(title)
+		if got != tt.want {
+			t.Errorf("Separator %q, MaxLength %d: %q, want %q", tt.sep, tt.max, got, tt.want)
+		}
+	}
+}
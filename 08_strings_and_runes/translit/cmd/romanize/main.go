@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/translit"
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/width"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Romanizing Ukrainian
+// ---------------------------------------------------------
+// Usage:
+//   go run ./08_strings_and_runes/translit/cmd/romanize
+//   go run ./08_strings_and_runes/translit/cmd/romanize Київ "Біла Церква"
+//   go run ./08_strings_and_runes/translit/cmd/romanize -file - < names.txt
+//
+// Without arguments it checks translit.Ukrainian against the examples
+// printed in the resolution itself and exits with status 1 on any
+// mismatch. With arguments (or -file) it romanizes each argument (or
+// line).
+
+// examples are the sample names from the table in Resolution No. 55.
+var examples = [][2]string{
+	{"Алушта", "Alushta"}, {"Андрій", "Andrii"}, {"Борщагівка", "Borshchahivka"},
+	{"Борисенко", "Borysenko"}, {"Вінниця", "Vinnytsia"}, {"Володимир", "Volodymyr"},
+	{"Гадяч", "Hadiach"}, {"Богдан", "Bohdan"}, {"Згурський", "Zghurskyi"},
+	{"Ґалаґан", "Galagan"}, {"Ґорґани", "Gorgany"}, {"Донецьк", "Donetsk"},
+	{"Дмитро", "Dmytro"}, {"Рівне", "Rivne"}, {"Олег", "Oleh"}, {"Есмань", "Esman"},
+	{"Єнакієве", "Yenakiieve"}, {"Гаєвич", "Haievych"}, {"Короп'є", "Koropie"},
+	{"Житомир", "Zhytomyr"}, {"Жанна", "Zhanna"}, {"Жежелів", "Zhezheliv"},
+	{"Закарпаття", "Zakarpattia"}, {"Казимирчук", "Kazymyrchuk"}, {"Медвин", "Medvyn"},
+	{"Михайленко", "Mykhailenko"}, {"Іванків", "Ivankiv"}, {"Іващенко", "Ivashchenko"},
+	{"Їжакевич", "Yizhakevych"}, {"Кадиївка", "Kadyivka"}, {"Мар'їне", "Marine"},
+	{"Йосипівка", "Yosypivka"}, {"Стрий", "Stryi"}, {"Олексій", "Oleksii"},
+	{"Київ", "Kyiv"}, {"Коваленко", "Kovalenko"}, {"Лебедин", "Lebedyn"},
+	{"Леонід", "Leonid"}, {"Миколаїв", "Mykolaiv"}, {"Маринич", "Marynych"},
+	{"Надвірна", "Nadvirna"}, {"Наталія", "Nataliia"}, {"Одеса", "Odesa"},
+	{"Онищенко", "Onyshchenko"}, {"Полтава", "Poltava"}, {"Петро", "Petro"},
+	{"Решетилівка", "Reshetylivka"}, {"Рибчинський", "Rybchynskyi"}, {"Суми", "Sumy"},
+	{"Соломія", "Solomiia"}, {"Тернопіль", "Ternopil"}, {"Троць", "Trots"},
+	{"Ужгород", "Uzhhorod"}, {"Уляна", "Uliana"}, {"Фастів", "Fastiv"},
+	{"Філіпчук", "Filipchuk"}, {"Харків", "Kharkiv"}, {"Христина", "Khrystyna"},
+	{"Біла Церква", "Bila Tserkva"}, {"Стеценко", "Stetsenko"}, {"Чернівці", "Chernivtsi"},
+	{"Шевченко", "Shevchenko"}, {"Шостка", "Shostka"}, {"Кишеньки", "Kyshenky"},
+	{"Щербухи", "Shcherbukhy"}, {"Гоща", "Hoshcha"}, {"Гаращенко", "Harashchenko"},
+	{"Юрій", "Yurii"}, {"Корюківка", "Koriukivka"}, {"Яготин", "Yahotyn"},
+	{"Ярошенко", "Yaroshenko"}, {"Костянтин", "Kostiantyn"}, {"Знам'янка", "Znamianka"},
+	{"Феодосія", "Feodosiia"},
+	// Not from the resolution: capitals and other apostrophes.
+	{"ЩУКА", "SHCHUKA"}, {"Щука", "Shchuka"}, {"ЮРІЙ", "YURII"}, {"Я", "Ya"},
+	{"ЗГУРСЬКИЙ", "ZGHURSKYI"}, {"м’ята", "miata"}, {"пʼять", "piat"},
+}
+
+func main() {
+	file := flag.String("file", "", "romanize each line of a file (- for stdin)")
+	flag.Parse()
+
+	switch {
+	case *file != "":
+		f := os.Stdin
+		if *file != "-" {
+			var err error
+			if f, err = os.Open(*file); err != nil {
+				fail(err)
+			}
+			defer f.Close()
+		}
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			fmt.Println(translit.Ukrainian(sc.Text()))
+		}
+		if err := sc.Err(); err != nil {
+			fail(err)
+		}
+	case flag.NArg() > 0:
+		for _, s := range flag.Args() {
+			fmt.Println(translit.Ukrainian(s))
+		}
+	default:
+		check()
+	}
+}
+
+func check() {
+	t := width.NewTable("Ukrainian", "Expected", "Got", "Check")
+	failed := 0
+	for _, ex := range examples {
+		got := translit.Ukrainian(ex[0])
+		mark := "ok"
+		if got != ex[1] {
+			mark = "MISMATCH"
+			failed++
+		}
+		t.Append(ex[0], ex[1], got, mark)
+	}
+	if err := t.Render(os.Stdout); err != nil {
+		fail(err)
+	}
+	fmt.Printf("\n%d examples, %d mismatches\n", len(examples), failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
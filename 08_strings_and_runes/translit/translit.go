@@ -0,0 +1,121 @@
+// Package translit romanizes Ukrainian text with the official national
+// system (Resolution of the Cabinet of Ministers of Ukraine No. 55 of
+// 27 January 2010), the one used in passports and on road signs:
+//
+//	Київ -> Kyiv    Згурський -> Zghurskyi    Знам'янка -> Znamianka
+//
+// Most letters map one to one, but a few depend on where they stand:
+//
+//   - Є, Ї, Й, Ю, Я start with "Y" at the beginning of a word (Yenakiieve,
+//     Yizhakevych, Yosypivka, Yurii, Yahotyn) and become "ie", "i", "i",
+//     "iu", "ia" inside it (Haievych, Kyiv, Stryi, Koriukivka, Kostiantyn).
+//   - "зг" is "zgh", so that it does not read as "ж" (zh).
+//   - Ь and the apostrophe are dropped.
+//
+// The rules are written as a map lookup plus a look at the neighbours of
+// each rune, the same walk as range over a string with a little state.
+package translit
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/norm"
+)
+
+// ---------------------------------------------------------
+// 1. TABLE
+// ---------------------------------------------------------
+
+// letter is the romanization of a lowercase Ukrainian letter: initial is
+// used at the start of a word, medial everywhere else.
+type letter struct {
+	initial, medial string
+}
+
+var letters = map[rune]letter{
+	'а': {"a", "a"}, 'б': {"b", "b"}, 'в': {"v", "v"}, 'г': {"h", "h"},
+	'ґ': {"g", "g"}, 'д': {"d", "d"}, 'е': {"e", "e"}, 'є': {"ye", "ie"},
+	'ж': {"zh", "zh"}, 'з': {"z", "z"}, 'и': {"y", "y"}, 'і': {"i", "i"},
+	'ї': {"yi", "i"}, 'й': {"y", "i"}, 'к': {"k", "k"}, 'л': {"l", "l"},
+	'м': {"m", "m"}, 'н': {"n", "n"}, 'о': {"o", "o"}, 'п': {"p", "p"},
+	'р': {"r", "r"}, 'с': {"s", "s"}, 'т': {"t", "t"}, 'у': {"u", "u"},
+	'ф': {"f", "f"}, 'х': {"kh", "kh"}, 'ц': {"ts", "ts"}, 'ч': {"ch", "ch"},
+	'ш': {"sh", "sh"}, 'щ': {"shch", "shch"}, 'ь': {"", ""}, 'ю': {"yu", "iu"},
+	'я': {"ya", "ia"},
+}
+
+// isApostrophe reports whether r is one of the ways Ukrainian text spells
+// the apostrophe in "м'ята": ASCII ', U+2019 and U+02BC MODIFIER LETTER
+// APOSTROPHE (the one the orthography recommends).
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’' || r == 'ʼ'
+}
+
+// ---------------------------------------------------------
+// 2. ROMANIZATION
+// ---------------------------------------------------------
+
+// Ukrainian romanizes the Ukrainian letters in s and leaves everything
+// else (Latin, digits, punctuation, other Cyrillic letters such as ы or
+// ё) as it is. The input is normalized to NFC first, so "й" typed as
+// "и" + U+0306 is still one letter.
+//
+// Case follows the source: "Щука" -> "Shchuka", "ЩУКА" -> "SHCHUKA".
+func Ukrainian(s string) string {
+	rs := []rune(norm.NFC(s))
+	var b strings.Builder
+	b.Grow(len(s))
+	inWord := false
+	for i, r := range rs {
+		lower := unicode.ToLower(r)
+		l, ok := letters[lower]
+		switch {
+		case ok:
+		case isApostrophe(r) && inWord && i+1 < len(rs) && isUkrainian(rs[i+1]):
+			continue // "п'ять" -> "piat": dropped, and the word goes on
+		default:
+			b.WriteRune(r)
+			inWord = unicode.IsLetter(r) || unicode.Is(unicode.Mn, r)
+			continue
+		}
+
+		out := l.medial
+		if !inWord {
+			out = l.initial
+		}
+		if lower == 'г' && i > 0 && unicode.ToLower(rs[i-1]) == 'з' {
+			out = "gh" // зг -> zgh
+		}
+		if r != lower && out != "" {
+			out = upper(out, allCaps(rs, i))
+		}
+		b.WriteString(out)
+		inWord = true
+	}
+	return b.String()
+}
+
+func isUkrainian(r rune) bool {
+	_, ok := letters[unicode.ToLower(r)]
+	return ok
+}
+
+// allCaps guesses whether the capital at rs[i] is part of a word in
+// capitals ("ЩУКА") or just starts one ("Щука"): it looks at the next
+// letter, or at the previous one when rs[i] ends the word.
+func allCaps(rs []rune, i int) bool {
+	if i+1 < len(rs) && unicode.IsLetter(rs[i+1]) {
+		return unicode.IsUpper(rs[i+1])
+	}
+	return i > 0 && unicode.IsUpper(rs[i-1])
+}
+
+// upper capitalizes the romanization of a capital letter: "Shch" or
+// "SHCH".
+func upper(s string, all bool) string {
+	if all {
+		return strings.ToUpper(s)
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
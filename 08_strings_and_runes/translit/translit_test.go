@@ -0,0 +1,94 @@
+package translit
+
+import "testing"
+
+// official holds the examples from the table in Resolution No. 55.
+var official = []struct{ in, want string }{
+	{"Алушта", "Alushta"}, {"Андрій", "Andrii"},
+	{"Борщагівка", "Borshchahivka"}, {"Борисенко", "Borysenko"},
+	{"Вінниця", "Vinnytsia"}, {"Володимир", "Volodymyr"},
+	{"Гадяч", "Hadiach"}, {"Богдан", "Bohdan"}, {"Згурський", "Zghurskyi"},
+	{"Ґалаґан", "Galagan"}, {"Ґорґани", "Gorgany"},
+	{"Донецьк", "Donetsk"}, {"Дмитро", "Dmytro"},
+	{"Рівне", "Rivne"}, {"Олег", "Oleh"}, {"Есмань", "Esman"},
+	{"Єнакієве", "Yenakiieve"}, {"Гаєвич", "Haievych"}, {"Короп'є", "Koropie"},
+	{"Житомир", "Zhytomyr"}, {"Жанна", "Zhanna"}, {"Жежелів", "Zhezheliv"},
+	{"Закарпаття", "Zakarpattia"}, {"Казимирчук", "Kazymyrchuk"},
+	{"Медвин", "Medvyn"}, {"Михайленко", "Mykhailenko"},
+	{"Іванків", "Ivankiv"}, {"Іващенко", "Ivashchenko"},
+	{"Їжакевич", "Yizhakevych"}, {"Кадиївка", "Kadyivka"}, {"Мар'їне", "Marine"},
+	{"Йосипівка", "Yosypivka"}, {"Стрий", "Stryi"}, {"Олексій", "Oleksii"},
+	{"Київ", "Kyiv"}, {"Коваленко", "Kovalenko"},
+	{"Лебедин", "Lebedyn"}, {"Леонід", "Leonid"},
+	{"Миколаїв", "Mykolaiv"}, {"Маринич", "Marynych"},
+	{"Ніжин", "Nizhyn"}, {"Наталія", "Nataliia"},
+	{"Одеса", "Odesa"}, {"Онищенко", "Onyshchenko"},
+	{"Полтава", "Poltava"}, {"Петро", "Petro"},
+	{"Решетилівка", "Reshetylivka"}, {"Рибчинський", "Rybchynskyi"},
+	{"Суми", "Sumy"}, {"Соломія", "Solomiia"},
+	{"Тернопіль", "Ternopil"}, {"Троць", "Trots"},
+	{"Ужгород", "Uzhhorod"}, {"Уляна", "Uliana"},
+	{"Фастів", "Fastiv"}, {"Філіпчук", "Filipchuk"},
+	{"Харків", "Kharkiv"}, {"Христина", "Khrystyna"},
+	{"Біла Церква", "Bila Tserkva"}, {"Стеценко", "Stetsenko"},
+	{"Чернівці", "Chernivtsi"}, {"Шевченко", "Shevchenko"},
+	{"Шостка", "Shostka"}, {"Кишеньки", "Kyshenky"},
+	{"Щербухи", "Shcherbukhy"}, {"Гоща", "Hoshcha"}, {"Гаращенко", "Harashchenko"},
+	{"Юрій", "Yurii"}, {"Корюківка", "Koriukivka"},
+	{"Яготин", "Yahotyn"}, {"Ярошенко", "Yaroshenko"},
+	{"Костянтин", "Kostiantyn"}, {"Знам'янка", "Znamianka"}, {"Феодосія", "Feodosiia"},
+}
+
+func TestOfficial(t *testing.T) {
+	for _, tt := range official {
+		if got := Ukrainian(tt.in); got != tt.want {
+			t.Errorf("Ukrainian(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPosition(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		// Є Ї Й Ю Я start a word with Y, also after a hyphen or a bracket.
+		{"Єва їсть йогурт юшку яблуко", "Yeva yist yohurt yushku yabluko"},
+		{"Ярмарок-ювілей", "Yarmarok-yuvilei"},
+		{"(Яна)", "(Yana)"},
+		// Inside a word, and after an apostrophe, which does not end it.
+		{"з'їзд м’ята подвір’я сімʼя", "zizd miata podviria simia"},
+		{"'Яна'", "'Yana'"}, // quotes around a word are kept
+		// зг is zgh; other г and жг stay h.
+		{"Розгон зг Зг ЗГ", "Rozghon zgh Zgh ZGH"},
+		// Ь is dropped, also at the start of a capitalized stretch.
+		{"Львів ЛЬВІВ", "Lviv LVIV"},
+	} {
+		if got := Ukrainian(tt.in); got != tt.want {
+			t.Errorf("Ukrainian(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCase(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"Щука", "Shchuka"}, {"ЩУКА", "SHCHUKA"}, {"щука", "shchuka"},
+		{"ЮРІЙ", "YURII"}, {"ЄНАКІЄВЕ", "YENAKIIEVE"},
+		{"ЧЕРНІВЦІ", "CHERNIVTSI"}, {"КиЇВ", "KyIV"},
+		{"Я", "Ya"}, {"ЖК", "ZHK"}, {"ОЩ", "OSHCH"},
+	} {
+		if got := Ukrainian(tt.in); got != tt.want {
+			t.Errorf("Ukrainian(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPassThrough(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"Kyiv 2026!", "Kyiv 2026!"},
+		{"Ёлка и съезд", "Ёlka y sъezd"}, // ё and ъ are not Ukrainian letters
+		{"\u0438\u0306од", "yod"},        // й as и + combining breve
+		{"", ""},
+	} {
+		if got := Ukrainian(tt.in); got != tt.want {
+			t.Errorf("Ukrainian(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/username"
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/width"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Validating Usernames Beyond len()
+// ---------------------------------------------------------
+// Usage:
+//   go run ./08_strings_and_runes/username/cmd/checkname
+//   go run ./08_strings_and_runes/username/cmd/checkname -scripts Latin,Greek olena αλφα
+//
+// Without arguments it runs a set of examples: the lesson's emoji flag,
+// look-alike Cyrillic letters, decomposed accents, and names that collide
+// once case-folded. Each name is validated and registered in a map keyed
+// by username.Key, the way a signup service would.
+
+func main() {
+	scripts := flag.String("scripts", "Latin,Cyrillic", "comma-separated allowed scripts")
+	maxLen := flag.Int("max", 20, "maximum length in characters")
+	flag.Parse()
+
+	policy := username.DefaultPolicy()
+	policy.Scripts = strings.Split(*scripts, ",")
+	policy.MaxLength = *maxLen
+
+	names := flag.Args()
+	if len(names) == 0 {
+		names = []string{
+			"olena_k",
+			"Олена",
+			"Olena",
+			"OLENA",        // collides with "Olena"
+			"pаypal",       // Cyrillic а in a Latin name
+			"асе",          // all-Cyrillic, reads as "ace"
+			"Zoe\u0308",    // Zoë with a combining diaeresis: NFC turns 4 runes into 3
+			"🇺🇦patriot",    // the lesson's flag: 8 bytes, 1 character, but not a letter
+			"ab",           // too short
+			"john..smith",  // doubled punctuation
+			"admin\u200b",  // zero-width space hiding at the end
+			"δέλτα",        // Greek is not allowed by default
+			"user\xffname", // broken UTF-8
+		}
+	}
+
+	taken := make(map[string]string) // username.Key -> display name
+	for _, name := range names {
+		fmt.Print(width.Pad(strconv.Quote(name), 22, width.Left), " ")
+		display, err := policy.Validate(name)
+		var uerr *username.Error
+		switch {
+		case errors.As(err, &uerr):
+			fmt.Printf("REJECT [%s] %v\n", uerr.Reason, err)
+			continue
+		case err != nil:
+			fail(err)
+		}
+		key := username.Key(display)
+		if owner, ok := taken[key]; ok {
+			fmt.Printf("TAKEN  %q looks the same as %q (key %q)\n", display, owner, key)
+			continue
+		}
+		taken[key] = display
+		fmt.Printf("OK     stored as %q, key %q\n", display, key)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,336 @@
+// Package username validates and normalizes usernames, fixing pitfall 2
+// of 08_strings_and_runes ("using len() for text validation") and the
+// pitfalls hiding behind it:
+//
+//   - Normalization: "é" typed on two keyboards can be two different
+//     strings. Names are stored in NFC (see ../norm).
+//   - Length is counted in grapheme clusters (../grapheme), the
+//     characters a person sees, not bytes or runes.
+//   - Only letters of allowed scripts, ASCII digits and a little
+//     punctuation are accepted.
+//   - One script per name: "pаypal" with a Cyrillic "а" is rejected,
+//     because it looks exactly like "paypal".
+//   - Key case-folds and maps look-alike letters, so "Admin", "admin"
+//     and "аdmin" all collide when checking uniqueness. The digits 0
+//     and 1 count as look-alikes of o and l, so "user1" and "userl"
+//     collide too: on purpose, as "paypa1" is a classic spoof.
+//
+// Every rejection is an *Error whose Reason says what went wrong and
+// whose message says where.
+package username
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/grapheme"
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/norm"
+)
+
+// ---------------------------------------------------------
+// 1. ERRORS
+// ---------------------------------------------------------
+
+// Reason classifies a rejection.
+type Reason int
+
+const (
+	InvalidUTF8 Reason = iota + 1
+	TooShort
+	TooLong
+	DisallowedChar
+	DisallowedScript
+	BadPunctuation
+	MixedScript
+	WholeScriptConfusable
+)
+
+func (r Reason) String() string {
+	switch r {
+	case InvalidUTF8:
+		return "invalid UTF-8"
+	case TooShort:
+		return "too short"
+	case TooLong:
+		return "too long"
+	case DisallowedChar:
+		return "disallowed character"
+	case DisallowedScript:
+		return "disallowed script"
+	case BadPunctuation:
+		return "bad punctuation"
+	case MixedScript:
+		return "mixed scripts"
+	case WholeScriptConfusable:
+		return "confusable with Latin"
+	}
+	return "unknown"
+}
+
+// Error explains why a name was rejected. Use errors.As to get at it.
+type Error struct {
+	Reason Reason
+	Name   string // the name after NFC normalization
+	Offset int    // byte offset in Name of the offending rune, or -1
+	Rune   rune   // the offending rune, if Offset >= 0
+	Detail string // human explanation
+}
+
+func (e *Error) Error() string {
+	if e.Offset >= 0 {
+		return fmt.Sprintf("username: %s at byte %d (%s): %s", e.Reason, e.Offset, describe(e.Rune), e.Detail)
+	}
+	return fmt.Sprintf("username: %s: %s", e.Reason, e.Detail)
+}
+
+func describe(r rune) string {
+	if unicode.IsPrint(r) {
+		return fmt.Sprintf("%q U+%04X", r, r)
+	}
+	return fmt.Sprintf("U+%04X", r)
+}
+
+// ---------------------------------------------------------
+// 2. POLICY
+// ---------------------------------------------------------
+
+// Policy is a set of username rules.
+type Policy struct {
+	MinLength int // in grapheme clusters
+	MaxLength int // in grapheme clusters; 0 means no limit
+	// Scripts lists the allowed scripts by their names in
+	// unicode.Scripts, e.g. "Latin", "Cyrillic", "Greek", "Han".
+	Scripts []string
+	// Punctuation lists the allowed separators. They may not start or
+	// end a name or appear twice in a row.
+	Punctuation string
+	// AllowConfusable accepts single-script names whose letters all look
+	// like Latin ones, such as Cyrillic "асе" ("ace"). Off by default.
+	AllowConfusable bool
+}
+
+// DefaultPolicy allows 3-20 Latin or Cyrillic characters, ASCII digits
+// and ". _ -".
+func DefaultPolicy() Policy {
+	return Policy{
+		MinLength:   3,
+		MaxLength:   20,
+		Scripts:     []string{"Latin", "Cyrillic"},
+		Punctuation: "._-",
+	}
+}
+
+// Validate normalizes name to NFC and checks it against the policy. It
+// returns the normalized name, which is what should be stored and shown.
+// Any error is an *Error.
+func (p Policy) Validate(name string) (string, error) {
+	if !utf8.ValidString(name) {
+		i := 0
+		for i < len(name) {
+			r, size := utf8.DecodeRuneInString(name[i:])
+			if r == utf8.RuneError && size == 1 {
+				break
+			}
+			i += size
+		}
+		return "", &Error{Reason: InvalidUTF8, Name: name, Offset: i, Rune: utf8.RuneError,
+			Detail: fmt.Sprintf("byte 0x%02X is not valid UTF-8", name[i])}
+	}
+	name = norm.NFC(name)
+
+	n := grapheme.GraphemeCount(name)
+	if n < p.MinLength {
+		return "", &Error{Reason: TooShort, Name: name, Offset: -1,
+			Detail: fmt.Sprintf("%d characters, need at least %d", n, p.MinLength)}
+	}
+	if p.MaxLength > 0 && n > p.MaxLength {
+		return "", &Error{Reason: TooLong, Name: name, Offset: -1,
+			Detail: fmt.Sprintf("%d characters, at most %d allowed", n, p.MaxLength)}
+	}
+
+	if err := p.checkRunes(name); err != nil {
+		return "", err
+	}
+	if err := p.checkScripts(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+func (p Policy) checkRunes(name string) error {
+	fail := func(reason Reason, at int, r rune, detail string) error {
+		return &Error{Reason: reason, Name: name, Offset: at, Rune: r, Detail: detail}
+	}
+	offset := 0
+	prevPunct := false
+	for g := range grapheme.Graphemes(name) {
+		for i, r := range g {
+			at := offset + i
+			isPunct := strings.ContainsRune(p.Punctuation, r)
+			switch {
+			case unicode.IsLetter(r):
+				if s := scriptOf(r); !slices.Contains(p.Scripts, s) {
+					return fail(DisallowedScript, at, r, fmt.Sprintf("%s letters are not allowed (allowed: %s)",
+						s, strings.Join(p.Scripts, ", ")))
+				}
+			case unicode.In(r, unicode.Mn, unicode.Mc):
+				// Combining marks are fine on a letter (й = и + U+0306 before
+				// NFC); a mark on its own or on a digit is not.
+				if i == 0 || !unicode.IsLetter([]rune(g)[0]) {
+					return fail(DisallowedChar, at, r, "combining mark without a letter to attach to")
+				}
+			case r >= '0' && r <= '9':
+			case unicode.IsDigit(r):
+				return fail(DisallowedChar, at, r, "only ASCII digits 0-9 are allowed")
+			case isPunct:
+				switch {
+				case at == 0:
+					return fail(BadPunctuation, at, r, "a name cannot start with punctuation")
+				case at+len(g) == len(name):
+					return fail(BadPunctuation, at, r, "a name cannot end with punctuation")
+				case prevPunct:
+					return fail(BadPunctuation, at, r, "punctuation cannot repeat")
+				}
+			case unicode.IsSpace(r):
+				return fail(DisallowedChar, at, r, "spaces are not allowed")
+			case unicode.In(r, unicode.Cf, unicode.Cc):
+				return fail(DisallowedChar, at, r, "invisible and control characters are not allowed")
+			default:
+				return fail(DisallowedChar, at, r, fmt.Sprintf("only letters, digits and %q are allowed", p.Punctuation))
+			}
+			prevPunct = isPunct
+		}
+		offset += len(g)
+	}
+	return nil
+}
+
+func (p Policy) checkScripts(name string) error {
+	first, firstAt := "", 0
+	confusable := true
+	for i, r := range name {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		s := scriptOf(r)
+		if first == "" {
+			first, firstAt = s, i
+		}
+		if s != first {
+			detail := fmt.Sprintf("%s letter after %s letter %s at byte %d; use one script per name",
+				s, first, describe([]rune(name[firstAt:])[0]), firstAt)
+			if l, ok := lookalike(r); ok {
+				detail = fmt.Sprintf("%s letter that looks like Latin %q; use one script per name", s, l)
+			}
+			return &Error{Reason: MixedScript, Name: name, Offset: i, Rune: r, Detail: detail}
+		}
+		if _, ok := lookalike(fold(r)); !ok {
+			confusable = false
+		}
+	}
+	if first != "" && first != "Latin" && confusable && !p.AllowConfusable && slices.Contains(p.Scripts, "Latin") {
+		return &Error{Reason: WholeScriptConfusable, Name: name, Offset: -1,
+			Detail: fmt.Sprintf("every %s letter looks like a Latin one; %q reads as %q", first, name, Key(name))}
+	}
+	return nil
+}
+
+// scriptOf names the script of r, "Common" or "Inherited" included.
+func scriptOf(r rune) string {
+	for _, name := range scriptNames {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return name
+		}
+	}
+	return "Unknown"
+}
+
+// scriptNames is sorted with the common scripts first, so scriptOf
+// usually answers after one or two checks.
+var scriptNames = func() []string {
+	names := make([]string, 0, len(unicode.Scripts))
+	for name := range unicode.Scripts {
+		names = append(names, name)
+	}
+	first := []string{"Latin", "Cyrillic", "Greek", "Common", "Inherited"}
+	slices.SortFunc(names, func(a, b string) int {
+		ia, ib := slices.Index(first, a), slices.Index(first, b)
+		switch {
+		case ia >= 0 && ib >= 0:
+			return ia - ib
+		case ia >= 0:
+			return -1
+		case ib >= 0:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	return names
+}()
+
+// ---------------------------------------------------------
+// 3. UNIQUENESS KEY
+// ---------------------------------------------------------
+
+// confusables maps lowercase Cyrillic and Greek letters (and digits) to
+// the Latin letter they are drawn like. It is a small, hand-picked subset
+// of the Unicode confusables data (UTS #39) that covers the classic
+// phishing substitutions. The digits only matter to Key: checkScripts
+// looks at letters alone, so "user1" is still a valid Latin name.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ї': 'i',
+	'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'һ': 'h', 'ӏ': 'l', 'ԛ': 'q', 'ԝ': 'w',
+	'ь': 'b',
+	// Greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o',
+	'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'γ': 'y',
+	// Digits that pass for letters
+	'0': 'o', '1': 'l',
+}
+
+func lookalike(r rune) (rune, bool) {
+	l, ok := confusables[unicode.ToLower(r)]
+	return l, ok
+}
+
+// fold returns the simple case folding of r: the same rune for "K",
+// "k" and U+212A KELVIN SIGN. Full folding ("ß" -> "ss") is not done.
+func fold(r rune) rune {
+	lowest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		lowest = min(lowest, f)
+	}
+	return unicode.ToLower(lowest)
+}
+
+// Key returns the string to compare when checking that a name is not
+// taken: NFC, case-folded, with look-alike letters and the digits 0 and
+// 1 replaced by their Latin twins. Store it in a unique column next to
+// the display name.
+//
+//	Key("Admin") == Key("admin") == Key("аdmin") == "admin"
+//	Key("user1") == Key("userl") == Key("USERL") == "userl"
+//
+// So once "user1" is taken, "userl" is too. That is intended: the two
+// look the same in many fonts. Other digits are kept, so "user2" and
+// "user3" stay distinct.
+func Key(name string) string {
+	name = norm.NFC(name)
+	var b strings.Builder
+	for _, r := range name {
+		r = fold(r)
+		if l, ok := confusables[r]; ok {
+			r = l
+		}
+		b.WriteRune(r)
+	}
+	return norm.NFC(b.String())
+}
+
+// Validate checks name against DefaultPolicy.
+func Validate(name string) (string, error) { return DefaultPolicy().Validate(name) }
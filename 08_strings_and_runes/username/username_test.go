@@ -0,0 +1,55 @@
+package username
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	for _, group := range [][]string{
+		{"Admin", "admin", "ADMIN", "аdmin"}, // the last "а" is Cyrillic
+		{"user1", "userl", "USERL", "USER1"},
+		{"g00gle", "google", "gооgle"},
+		{"Café", "café", "CAFÉ"},
+	} {
+		want := Key(group[0])
+		for _, name := range group[1:] {
+			if got := Key(name); got != want {
+				t.Errorf("Key(%q) = %q, want %q as for %q", name, got, want, group[0])
+			}
+		}
+	}
+	for _, pair := range [][2]string{{"user2", "user3"}, {"user2", "userz"}, {"ivan", "іван"}} {
+		if Key(pair[0]) == Key(pair[1]) {
+			t.Errorf("Key(%q) == Key(%q) = %q, want them distinct", pair[0], pair[1], Key(pair[0]))
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want Reason // 0: valid
+	}{
+		{"user1", 0},
+		{"taras_shevchenko", 0},
+		{"Шевченко", 0},
+		{"ab", TooShort},
+		{"pаypal", MixedScript},
+		{"асе", WholeScriptConfusable},
+		{"john..doe", BadPunctuation},
+		{"john doe", DisallowedChar},
+		{"\xffuser", InvalidUTF8},
+	} {
+		_, err := Validate(tc.name)
+		var e *Error
+		switch {
+		case tc.want == 0 && err != nil:
+			t.Errorf("Validate(%q) = %v, want it accepted", tc.name, err)
+		case tc.want != 0 && !errors.As(err, &e):
+			t.Errorf("Validate(%q) = %v, want an *Error with %v", tc.name, err, tc.want)
+		case tc.want != 0 && e.Reason != tc.want:
+			t.Errorf("Validate(%q) reason = %v, want %v", tc.name, e.Reason, tc.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/width"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Display Width and Aligned Tables
+// ---------------------------------------------------------
+// Usage:
+//   go run ./08_strings_and_runes/width/cmd/widthtable
+//   go run ./08_strings_and_runes/width/cmd/widthtable -max 8
+//   go run ./08_strings_and_runes/width/cmd/widthtable -ambiguous-wide
+//   printf 'name\tcity\nОлена\t東京\n' | go run ./08_strings_and_runes/width/cmd/widthtable -tsv
+//
+// Part 1 compares bytes, runes and columns for tricky strings. Part 2
+// prints a table that stays aligned; compare it with the %-12s version
+// printed underneath, which pads by runes and falls apart.
+
+func main() {
+	maxw := flag.Int("max", 0, "truncate cells to this many columns (0 = no limit)")
+	ambWide := flag.Bool("ambiguous-wide", false, "treat East Asian Ambiguous characters as two columns")
+	tsv := flag.Bool("tsv", false, "read a tab-separated table (first line is the header) from stdin")
+	flag.Parse()
+	opts := width.Options{AmbiguousWide: *ambWide}
+
+	if *tsv {
+		var t *width.Table
+		sc := bufio.NewScanner(os.Stdin)
+		first := true
+		for sc.Scan() {
+			cells := strings.Split(sc.Text(), "\t")
+			if first {
+				t = width.NewTable(cells...)
+				t.Options = opts
+				limit(t, *maxw, len(cells))
+				first = false
+				continue
+			}
+			t.Append(cells...)
+		}
+		if err := sc.Err(); err != nil {
+			fail(err)
+		}
+		if t == nil {
+			return // empty input
+		}
+		if err := t.Render(os.Stdout); err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	fmt.Println("--- 1. Bytes vs runes vs columns ---")
+	samples := []string{"Hello, 世界", "Привіт", "🇺🇦", "👩‍💻", "❤️", "é", "ｱｲｳ", "①②"}
+	m := width.NewTable("text", "bytes", "runes", "columns")
+	m.Options = opts
+	for c := 1; c <= 3; c++ {
+		m.SetAlign(c, width.Right)
+	}
+	for _, s := range samples {
+		m.Append(s, fmt.Sprint(len(s)), fmt.Sprint(utf8.RuneCountInString(s)), fmt.Sprint(opts.Width(s)))
+	}
+	if err := m.Render(os.Stdout); err != nil {
+		fail(err)
+	}
+
+	fmt.Println("\n--- 2. A table that stays aligned ---")
+	rows := [][]string{
+		{"Kyiv", "Київ", "2952301", "столиця України"},
+		{"Tokyo", "東京", "14094034", "🇯🇵 首都"},
+		{"Seoul", "서울", "9386034", "👩‍💻 IT hub"},
+		{"Zürich", "Zürich", "443037", "é is one column"},
+	}
+	t := width.NewTable("City", "Local", "Population", "Note")
+	t.Options = opts
+	t.SetAlign(2, width.Right)
+	limit(t, *maxw, 4)
+	for _, r := range rows {
+		t.Append(r...)
+	}
+	if err := t.Render(os.Stdout); err != nil {
+		fail(err)
+	}
+
+	fmt.Printf("\n--- 3. The same with fmt's %%-12s (pads by runes) ---\n")
+	for _, r := range rows {
+		fmt.Printf("%-12s| %-12s| %s\n", r[0], r[1], r[3])
+	}
+}
+
+func limit(t *width.Table, w, ncol int) {
+	if w <= 0 {
+		return
+	}
+	for c := range ncol {
+		t.SetMaxWidth(c, w)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
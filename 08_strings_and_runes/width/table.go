@@ -0,0 +1,141 @@
+package width
+
+import (
+	"io"
+	"strings"
+)
+
+// ---------------------------------------------------------
+// 3. TABLE PRINTER
+// ---------------------------------------------------------
+
+// Table prints rows as aligned columns:
+//
+//	City | Population | Note
+//	-----+------------+--------
+//	Київ |    2952301 | столиця
+//	東京 |   14094034 | 🇯🇵
+//
+// Columns are sized in display columns, not bytes or runes, so CJK,
+// Cyrillic and emoji line up. Cells wider than a column's limit are
+// truncated with Ellipsis. Borders are plain ASCII on purpose: box
+// drawing characters are East Asian Ambiguous and break alignment in CJK
+// terminals.
+type Table struct {
+	// Options controls how cells are measured.
+	Options Options
+	// Ellipsis ends truncated cells. Empty means "…".
+	Ellipsis string
+
+	header []string
+	rows   [][]string
+	align  []Align
+	limit  []int
+}
+
+// NewTable returns a table with the given column headers.
+func NewTable(header ...string) *Table {
+	return &Table{header: header}
+}
+
+// Append adds a row. Missing cells are blank; extra cells add columns.
+func (t *Table) Append(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// SetAlign sets the alignment of column col (0-based).
+func (t *Table) SetAlign(col int, a Align) {
+	for len(t.align) <= col {
+		t.align = append(t.align, Left)
+	}
+	t.align[col] = a
+}
+
+// SetMaxWidth limits column col to w display columns; 0 removes the
+// limit.
+func (t *Table) SetMaxWidth(col, w int) {
+	for len(t.limit) <= col {
+		t.limit = append(t.limit, 0)
+	}
+	t.limit[col] = w
+}
+
+// Render writes the table to w.
+func (t *Table) Render(w io.Writer) error {
+	ncol := len(t.header)
+	for _, r := range t.rows {
+		ncol = max(ncol, len(r))
+	}
+	ellipsis := t.Ellipsis
+	if ellipsis == "" {
+		ellipsis = "…"
+	}
+
+	// Clean and truncate every cell first, then size the columns.
+	prepare := func(row []string) []string {
+		out := make([]string, ncol)
+		for i := range out {
+			if i < len(row) {
+				out[i] = clean(row[i])
+			}
+			if i < len(t.limit) && t.limit[i] > 0 {
+				out[i] = t.Options.Truncate(out[i], t.limit[i], ellipsis)
+			}
+		}
+		return out
+	}
+	header := prepare(t.header)
+	rows := make([][]string, len(t.rows))
+	for i, r := range t.rows {
+		rows[i] = prepare(r)
+	}
+	widths := make([]int, ncol)
+	for _, r := range append([][]string{header}, rows...) {
+		for i, c := range r {
+			widths[i] = max(widths[i], t.Options.Width(c))
+		}
+	}
+
+	var b strings.Builder
+	line := func(cells []string, align bool) {
+		for i, c := range cells {
+			if i > 0 {
+				b.WriteString(" | ")
+			}
+			a := Left
+			if align && i < len(t.align) {
+				a = t.align[i]
+			}
+			if i == len(cells)-1 && a == Left {
+				b.WriteString(c) // no trailing spaces
+				continue
+			}
+			b.WriteString(t.Options.Pad(c, widths[i], a))
+		}
+		b.WriteByte('\n')
+	}
+	if len(t.header) > 0 {
+		line(header, false)
+		for i, wd := range widths {
+			if i > 0 {
+				b.WriteString("-+-")
+			}
+			b.WriteString(strings.Repeat("-", wd))
+		}
+		b.WriteByte('\n')
+	}
+	for _, r := range rows {
+		line(r, true)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// clean replaces line breaks and tabs, which would tear the table apart,
+// with spaces.
+func clean(s string) string {
+	if !strings.ContainsAny(s, "\r\n\t") {
+		return s
+	}
+	return strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ", "\t", " ").Replace(s)
+}
@@ -0,0 +1,254 @@
+// Package width measures how many terminal columns text occupies.
+//
+// 08_strings_and_runes counts "Hello, 世界" as 9 runes, but a terminal
+// draws it in 11 columns: CJK ideographs are two columns wide. Bytes and
+// runes both get table alignment wrong; columns are what matter.
+//
+// Width works per grapheme cluster (see ../grapheme):
+//
+//   - East_Asian_Width W and F are two columns (世, Ａ); halfwidth ｱ is one.
+//   - Combining marks, format characters and controls are zero.
+//   - Emoji are two columns, including text-default emoji followed by
+//     U+FE0F VARIATION SELECTOR-16 (❤ vs ❤️) and flag pairs (🇺🇦).
+//   - Ambiguous characters (①, “, the ellipsis …, box drawing, and most
+//     Greek and Cyrillic letters: А..я, Ё and ё) are one column
+//     unless Options.AmbiguousWide is set, which matches terminals
+//     configured for CJK. With it "Привіт" is 11 columns, not 6: і, ї,
+//     є and ґ lie outside А..я and stay narrow.
+//
+// Terminals disagree on some scripts (Indic conjuncts in particular);
+// the package follows the common wcwidth behaviour.
+package width
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/grapheme"
+)
+
+// ---------------------------------------------------------
+// 1. EAST ASIAN WIDTH TABLE
+// ---------------------------------------------------------
+
+type class uint8
+
+const (
+	narrow class = iota
+	wide
+	ambiguous
+)
+
+type span struct {
+	lo, hi rune
+	class  class
+}
+
+//go:embed eastasianwidth.txt
+var data string
+
+var table = sync.OnceValue(func() []span {
+	t, err := parse(data)
+	if err != nil {
+		panic(err) // eastasianwidth.txt is part of the source; a bad edit is a bug
+	}
+	return t
+})
+
+func parse(src string) ([]span, error) {
+	var t []span
+	for i, line := range strings.Split(src, "\n") {
+		if j := strings.IndexByte(line, '#'); j >= 0 {
+			line = line[:j]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		cps, name, ok := strings.Cut(line, ";")
+		if !ok {
+			return nil, fmt.Errorf("width: line %d: missing ';'", i+1)
+		}
+		var c class
+		switch strings.TrimSpace(name) {
+		case "W", "F":
+			c = wide
+		case "A":
+			c = ambiguous
+		default:
+			return nil, fmt.Errorf("width: line %d: unknown class %q", i+1, strings.TrimSpace(name))
+		}
+		first, last, isRange := strings.Cut(strings.TrimSpace(cps), "..")
+		lo, err := strconv.ParseUint(first, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("width: line %d: %w", i+1, err)
+		}
+		hi := lo
+		if isRange {
+			if hi, err = strconv.ParseUint(last, 16, 32); err != nil {
+				return nil, fmt.Errorf("width: line %d: %w", i+1, err)
+			}
+		}
+		t = append(t, span{rune(lo), rune(hi), c})
+	}
+	sort.Slice(t, func(i, j int) bool { return t[i].lo < t[j].lo })
+	return t, nil
+}
+
+func lookup(r rune) class {
+	t := table()
+	i := sort.Search(len(t), func(i int) bool { return t[i].hi >= r })
+	if i < len(t) && t[i].lo <= r {
+		return t[i].class
+	}
+	return narrow
+}
+
+// ---------------------------------------------------------
+// 2. MEASURING
+// ---------------------------------------------------------
+
+// Options tunes measurement. The zero value suits most Western
+// terminals.
+type Options struct {
+	// AmbiguousWide counts East_Asian_Width=A characters as two columns,
+	// like terminals in CJK locales do.
+	AmbiguousWide bool
+}
+
+// Default is the Options used by the package-level functions.
+var Default Options
+
+const (
+	vs16 = '\uFE0F' // VARIATION SELECTOR-16: "show as emoji"
+	riLo = '\U0001F1E6'
+	riHi = '\U0001F1FF'
+)
+
+// RuneWidth returns the columns a single rune occupies on its own: 0, 1
+// or 2.
+func (o Options) RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7F:
+		return 0 // C0 controls
+	case r < 0x7F:
+		return 1 // printable ASCII, the fast path
+	case r < 0xA0:
+		return 0 // C1 controls
+	case r == '\u00AD':
+		return 1 // SOFT HYPHEN is Cf but terminals show it
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Cc),
+		r >= 0x1160 && r <= 0x11FF, r >= 0xD7B0 && r <= 0xD7FF: // Hangul medial vowels and final consonants join the syllable
+		return 0
+	case r >= riLo && r <= riHi:
+		return 2
+	}
+	switch lookup(r) {
+	case wide:
+		return 2
+	case ambiguous:
+		if o.AmbiguousWide {
+			return 2
+		}
+	}
+	return 1
+}
+
+// ClusterWidth returns the columns one grapheme cluster occupies. The
+// first rune with a non-zero width decides, so combining marks, ZWJ
+// emoji sequences and skin-tone modifiers do not add up; a VS16 makes
+// the cluster an emoji, two columns wide.
+func (o Options) ClusterWidth(g string) int {
+	w := 0
+	for _, r := range g {
+		if r == vs16 {
+			return 2
+		}
+		if w == 0 {
+			w = o.RuneWidth(r)
+		}
+	}
+	return w
+}
+
+// Width returns the columns s occupies.
+func (o Options) Width(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] >= 0x7F {
+			// Not plain ASCII: measure the rest cluster by cluster.
+			for g := range grapheme.Graphemes(s[i:]) {
+				n += o.ClusterWidth(g)
+			}
+			return n
+		}
+		n++
+	}
+	return n
+}
+
+// Truncate shortens s to at most w columns, cutting only between
+// grapheme clusters and appending tail (usually "…") when something was
+// removed. The tail counts towards w. If s already fits it is returned
+// unchanged.
+func (o Options) Truncate(s string, w int, tail string) string {
+	if o.Width(s) <= w {
+		return s
+	}
+	budget := w - o.Width(tail)
+	if budget < 0 {
+		return ""
+	}
+	used, end := 0, 0
+	for g := range grapheme.Graphemes(s) {
+		gw := o.ClusterWidth(g)
+		if used+gw > budget {
+			break
+		}
+		used += gw
+		end += len(g)
+	}
+	return s[:end] + tail
+}
+
+// Align says where Pad puts the text.
+type Align int
+
+const (
+	Left Align = iota
+	Right
+	Center
+)
+
+// Pad fills s with spaces to exactly w columns. Text wider than w is
+// returned unchanged; use Truncate first.
+func (o Options) Pad(s string, w int, a Align) string {
+	gap := w - o.Width(s)
+	if gap <= 0 {
+		return s
+	}
+	switch a {
+	case Right:
+		return strings.Repeat(" ", gap) + s
+	case Center:
+		left := gap / 2
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", gap-left)
+	}
+	return s + strings.Repeat(" ", gap)
+}
+
+// RuneWidth calls Default.RuneWidth.
+func RuneWidth(r rune) int { return Default.RuneWidth(r) }
+
+// Width calls Default.Width.
+func Width(s string) int { return Default.Width(s) }
+
+// Truncate calls Default.Truncate.
+func Truncate(s string, w int, tail string) string { return Default.Truncate(s, w, tail) }
+
+// Pad calls Default.Pad.
+func Pad(s string, w int, a Align) string { return Default.Pad(s, w, a) }
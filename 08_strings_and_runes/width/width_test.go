@@ -0,0 +1,32 @@
+package width
+
+import "testing"
+
+func TestWidth(t *testing.T) {
+	wide := Options{AmbiguousWide: true}
+	for _, tc := range []struct {
+		s          string
+		narrow, aw int // Width with the zero Options and with AmbiguousWide
+	}{
+		{"Hello, 世界", 11, 11},
+		{"ｱＡ", 3, 3},
+		{"é", 1, 1},
+		{"❤", 1, 1},
+		{"❤️", 2, 2},
+		{"🇺🇦", 2, 2},
+		{"①…“”", 4, 8},
+		// Cyrillic А..я, Ё and ё are East_Asian_Width=A; і, ї, є and ґ
+		// are not.
+		{"Привіт", 6, 11},
+		{"Ёж", 2, 4},
+		{"їжак ґава", 9, 15},
+		{"Ωμέγα", 5, 9}, // accented έ is not ambiguous either
+	} {
+		if got := Width(tc.s); got != tc.narrow {
+			t.Errorf("Width(%q) = %d, want %d", tc.s, got, tc.narrow)
+		}
+		if got := wide.Width(tc.s); got != tc.aw {
+			t.Errorf("AmbiguousWide Width(%q) = %d, want %d", tc.s, got, tc.aw)
+		}
+	}
+}
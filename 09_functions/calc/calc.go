@@ -0,0 +1,146 @@
+// Package calc is a small calculator language with variables and
+// functions. It is built like a compiler front end: a tokenizer turns
+// text into tokens, a parser turns tokens into a syntax tree (the AST),
+// and an evaluator walks the tree.
+//
+//	> rate = 41.5
+//	> uah(usd) = usd * rate
+//	> uah(100)
+//	4150
+//	> sum(1, 2, 3) / 2
+//	3
+//	> 1 / (2 - 2)
+//	calc: column 3: division by zero
+//
+// The lesson's ideas all show up in the language. Functions take
+// parameters and return a value. Builtins like sum, min and max are
+// variadic like sumAll. User functions are values that are stored and
+// called later.
+//
+// Numbers are float64. Errors carry the column where they happened, so
+// a REPL can point at it; see cmd/calc.
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ---------------------------------------------------------
+// 1. ERRORS
+// ---------------------------------------------------------
+
+// ErrDivisionByZero is wrapped by the Error for x / 0 and x % 0.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// Error is a syntax or evaluation error at a position.
+type Error struct {
+	// Func is the user function whose body failed, or "" if the error is
+	// in the line itself. Pos is then a column of that function's Source.
+	Func string
+	Pos  int // 1-based column, in runes
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Func != "" {
+		return fmt.Sprintf("calc: in %s, column %d: %v", e.Func, e.Pos, e.Err)
+	}
+	return fmt.Sprintf("calc: column %d: %v", e.Pos, e.Err)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+func errorAt(pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Err: fmt.Errorf(format, args...)}
+}
+
+// ---------------------------------------------------------
+// 2. TOKENIZER
+// ---------------------------------------------------------
+
+type kind int
+
+const (
+	tEOF kind = iota
+	tNum
+	tIdent
+	tPunct // one of + - * / % ^ ( ) , =
+)
+
+type token struct {
+	kind kind
+	text string
+	pos  int // 1-based column
+}
+
+func (t token) is(punct string) bool { return t.kind == tPunct && t.text == punct }
+
+func (t token) String() string {
+	if t.kind == tEOF {
+		return "end of input"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// tokenize splits src into tokens, ending with tEOF. Numbers are decimal
+// with an optional fraction and exponent: 3, .5, 1.5e-3. Names start with
+// a letter or _ and go on with letters, digits and _.
+func tokenize(src string) ([]token, error) {
+	var toks []token
+	col := 1
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		start, startCol := i, col
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r >= '0' && r <= '9' || r == '.':
+			i = number(src, i)
+			toks = append(toks, token{tNum, src[start:i], startCol})
+		case r == '_' || unicode.IsLetter(r):
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				i += size
+			}
+			toks = append(toks, token{tIdent, src[start:i], startCol})
+		case strings.ContainsRune("+-*/%^(),=", r):
+			i += size
+			toks = append(toks, token{tPunct, src[start:i], startCol})
+		case r == utf8.RuneError && size == 1:
+			return nil, errorAt(col, "invalid UTF-8 byte %#02x", src[i])
+		default:
+			return nil, errorAt(col, "unexpected character %q", r)
+		}
+		col += utf8.RuneCountInString(src[start:i])
+	}
+	return append(toks, token{tEOF, "", col}), nil
+}
+
+// number returns the end of the number starting at src[i]. Malformed
+// numbers like 1.2.3 end up in the token and fail in strconv.
+func number(src string, i int) int {
+	digits := func() {
+		for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+			i++
+		}
+	}
+	digits()
+	if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+		j := i + 1
+		if j < len(src) && (src[j] == '+' || src[j] == '-') {
+			j++
+		}
+		if j < len(src) && src[j] >= '0' && src[j] <= '9' {
+			i = j
+			digits()
+		}
+	}
+	return i
+}
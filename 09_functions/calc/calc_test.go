@@ -0,0 +1,228 @@
+package calc
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// env returns an Env that has run setup, failing the test on any error.
+func env(t *testing.T, setup ...string) *Env {
+	t.Helper()
+	e := NewEnv()
+	for _, line := range setup {
+		if _, err := e.Exec(line); err != nil {
+			t.Fatalf("%s: %v", line, err)
+		}
+	}
+	return e
+}
+
+func TestValues(t *testing.T) {
+	e := env(t,
+		"rate = 41.5",
+		"uah(usd) = usd * rate",
+		"sq(x) = x * x",
+		"hyp(a, b) = sqrt(sq(a) + sq(b))",
+		"late(x) = x + later", // later is looked up at call time
+		"later = 100",
+	)
+	for _, tt := range []struct {
+		src  string
+		want float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"2 * 3 % 4", 2},
+		{"2 ^ 3 ^ 2", 512},
+		{"(2 ^ 3) ^ 2", 64},
+		{"-2 ^ 2", -4},
+		{"(-2) ^ 2", 4},
+		{"-2 * 3", -6},
+		{"2 ^ -1", 0.5},
+		{"--3", 3},
+		{"+-+3", -3},
+		{"-7 % 3", -1},
+		{".5 + 1.5e1 + 2E-1", 15.7},
+		{"sum()", 0},
+		{"sum(1, 2, 3) / 2", 3},
+		{"avg(1, 2, 3, 6)", 3},
+		{"min(3, -1, 2) + max(3, -1, 2)", 2},
+		{"pow(2, 10)", 1024},
+		{"round(pi * 100) / 100", 3.14},
+		{"ln(e)", 1},
+		{"uah(100)", 4150},
+		{"hyp(3, 4)", 5},
+		{"late(1)", 101},
+		{"x = 2 ^ 10", 1024},
+	} {
+		res, err := e.Exec(tt.src)
+		if err != nil {
+			t.Errorf("%s: %v", tt.src, err)
+			continue
+		}
+		if math.Abs(res.Value-tt.want) > 1e-12 {
+			t.Errorf("%s = %v, want %v", tt.src, res.Value, tt.want)
+		}
+	}
+	if v, _ := e.Var("x"); v != 1024 {
+		t.Errorf("x = %v after assignment, want 1024", v)
+	}
+	if v, _ := e.Var("ans"); v != 101 {
+		t.Errorf("ans = %v, want the last expression's 101", v)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	e := env(t,
+		"f(x) = x / 0",
+		"g(x) = f(x) + 1",
+		"loop(x) = loop(x) + 1",
+		"ping(x) = pong(x)",
+		"pong(x) = ping(x)",
+	)
+	for _, tt := range []struct {
+		src  string
+		fn   string // Error.Func
+		pos  int
+		want string
+	}{
+		{"1 / (2 - 2)", "", 3, "division by zero"},
+		{"5 % 0", "", 3, "division by zero"},
+		{"2 +", "", 4, "unexpected end of input"},
+		{"2 + * 3", "", 5, `unexpected "*"`},
+		{"(1 + 2", "", 7, "expected ) to close ( at column 1, found end of input"},
+		{"1 + 2)", "", 6, `unexpected ")"`},
+		{"max(1 2)", "", 7, "expected , or ) in call to max"},
+		{"1.2.3", "", 1, "malformed number 1.2.3"},
+		{"1e999", "", 1, "number 1e999 out of range"},
+		{"2 $ 3", "", 3, `unexpected character '$'`},
+		{"ї + 1", "", 1, "undefined variable ї"},
+		{"1 + ї $", "", 7, `unexpected character '$'`}, // columns count runes
+		{"nope(1)", "", 1, "undefined function nope"},
+		{"sqrt(1, 2)", "", 1, "sqrt takes 1 argument, got 2"},
+		{"min()", "", 1, "min takes at least 1 argument, got 0"},
+		{"f(1, 2)", "", 1, "f takes 1 argument, got 2"},
+		{"sqrt(-1)", "", 1, "sqrt(-1) is not a number"},
+		{"(-8) ^ (1 / 3)", "", 6, "is not a number"},
+		{"1 + f(2)", "f", 10, "division by zero"},
+		{"g(2)", "f", 10, "division by zero"},
+		{"loop(1)", "loop", 11, "loop calls itself"},
+		{"ping(1)", "pong", 11, "ping calls itself"},
+		{"2 = 3", "", 3, "cannot assign to 2"},
+		{"f(x, 1) = x", "", 6, "parameter must be a name, not 1"},
+		{"f(x, x) = x", "", 6, "duplicate parameter x"},
+		{"sum(x) = x", "", 1, "cannot redefine builtin sum"},
+		{"pi = 3", "", 1, "cannot assign to constant pi"},
+	} {
+		_, err := e.Exec(tt.src)
+		var ce *Error
+		if !errors.As(err, &ce) {
+			t.Errorf("%s: error %v, want a *calc.Error", tt.src, err)
+			continue
+		}
+		if ce.Func != tt.fn || ce.Pos != tt.pos || !strings.Contains(ce.Err.Error(), tt.want) {
+			t.Errorf("%s: error in %q at column %d: %v; want in %q at column %d: %s",
+				tt.src, ce.Func, ce.Pos, ce.Err, tt.fn, tt.pos, tt.want)
+		}
+	}
+
+	_, err := e.Exec("1 / 0")
+	if !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("1 / 0: %v, want ErrDivisionByZero", err)
+	}
+	if got, want := err.Error(), "calc: column 3: division by zero"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	_, err = e.Exec("g(1)")
+	if got, want := err.Error(), "calc: in f, column 10: division by zero"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	// A recursion error leaves no function marked as running.
+	if _, err := e.Exec("loop(1)"); err == nil || len(e.running) != 0 {
+		t.Errorf("recursion left running = %v", e.running)
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, tt := range []struct{ src, want string }{
+		{"1+2*3", "1 + 2 * 3"},
+		{"(1+2)*3", "(1 + 2) * 3"},
+		{"1-(2-3)", "1 - (2 - 3)"},
+		{"(1-2)-3", "1 - 2 - 3"},
+		{"2^3^2", "2 ^ 3 ^ 2"},
+		{"(2^3)^2", "(2 ^ 3) ^ 2"},
+		{"-2^2", "-2 ^ 2"},
+		{"(-2)^2", "(-2) ^ 2"},
+		{"-(2*3)", "-(2 * 3)"},
+		{"-(2+3)", "-(2 + 3)"},
+		{"2^-x", "2 ^ (-x)"},
+		{"10/(2*5)", "10 / (2 * 5)"},
+		{"((x))", "x"},
+		{"max(1,(2+3)*4, y)", "max(1, (2 + 3) * 4, y)"},
+		{"1.50e3 + .5", "1.50e3 + .5"},
+		{"x=(1+2)", "x = 1 + 2"},
+		{"area( w ,h )=w*h", "area(w, h) = w * h"},
+		{"now()=1", "now() = 1"},
+	} {
+		s, err := Parse(tt.src)
+		if err != nil {
+			t.Errorf("%s: %v", tt.src, err)
+			continue
+		}
+		got := s.String()
+		if got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.src, got, tt.want)
+		}
+		// Printing and parsing again must give the same tree.
+		again, err := Parse(got)
+		if err != nil || again.String() != got {
+			t.Errorf("round trip of %q gave %v, %v", got, again, err)
+		}
+	}
+}
+
+func TestRoundTripValues(t *testing.T) {
+	e := env(t, "x = 3", "y = -2")
+	for _, src := range []string{
+		"-x ^ 2 - (y - x) * 2 ^ -y",
+		"x % (y + 5) / (1 - -x)",
+		"(x - y) - (x - (y - 1)) ^ (1 / 2) ^ 2",
+		"-(-x) - +(+y)",
+	} {
+		s, err := Parse(src)
+		if err != nil {
+			t.Fatalf("%s: %v", src, err)
+		}
+		again, err := Parse(s.String())
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		v1, err1 := e.Eval(s.X)
+		v2, err2 := e.Eval(again.X)
+		if err1 != nil || err2 != nil || v1 != v2 {
+			t.Errorf("%s = %v (%v), but its print %s = %v (%v)", src, v1, err1, again, v2, err2)
+		}
+	}
+}
+
+func TestEnv(t *testing.T) {
+	e := env(t, "b = 2", "a = 1", "f(x) = x", "sq(x) = x * x", "sq(y) = y * y * y")
+	if got := strings.Join(e.Vars(), " "); got != "a b" {
+		t.Errorf("Vars = %s, want a b", got)
+	}
+	if got := strings.Join(e.Funcs(), " "); got != "f sq" {
+		t.Errorf("Funcs = %s, want f sq", got)
+	}
+	if f, ok := e.Func("sq"); !ok || f.String() != "sq(y) = y * y * y" {
+		t.Errorf("sq = %v, want the redefinition", f)
+	}
+	if v, ok := e.Var("pi"); !ok || v != math.Pi {
+		t.Errorf("pi = %v, %v", v, ok)
+	}
+	if b := Builtins(); len(b) == 0 || b[0] != "abs" {
+		t.Errorf("Builtins = %v, want a sorted list starting with abs", b)
+	}
+}
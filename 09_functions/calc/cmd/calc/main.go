@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ViKing-py/lets-go-in-go/08_strings_and_runes/width"
+	"github.com/ViKing-py/lets-go-in-go/09_functions/calc"
+)
+
+// ---------------------------------------------------------
+// TOPIC: A Calculator With Variables and Functions
+// ---------------------------------------------------------
+// Usage:
+//   go run ./09_functions/calc/cmd/calc                      (interactive)
+//   go run ./09_functions/calc/cmd/calc -history ~/.calc_history
+//   go run ./09_functions/calc/cmd/calc 'r = 2' 'pi * r^2'
+//
+// Each line is an expression, an assignment or a function definition:
+//
+//   > add(x, y) = x + y
+//     defined add(x, y) = x + y
+//   > add(2, 3) * 4
+//     20
+//   > sum(1, 2, 3) / (ans - 20)
+//                  ^
+//   calc: column 14: division by zero
+//
+// Lines starting with : are commands (:help lists them). !! repeats the
+// last line and !n line n of :history. With -history, the history is
+// loaded from the file at start and every line is appended to it.
+
+const help = `  expressions:  + - * / %% ^ and ( ), e.g. -2^2 * (1 + 3) %% 5
+  variables:    x = 2 * pi; ans is the last result
+  functions:    f(a, b) = a * b + x, then f(2, 3)
+  builtins:     %s
+  commands:     :vars  :funcs  :history  :help  !!  !n  quit
+`
+
+func main() {
+	histPath := flag.String("history", "", "file to load history from and append it to")
+	flag.Parse()
+
+	env := calc.NewEnv()
+
+	// One-shot mode: every argument is a line.
+	if flag.NArg() > 0 {
+		for _, line := range flag.Args() {
+			if !run(env, line, os.Stderr, true) {
+				os.Exit(1)
+			}
+		}
+		return
+	}
+
+	h, err := openHistory(*histPath)
+	if err != nil {
+		fail(err)
+	}
+	defer h.Close()
+
+	// Typed lines are on screen above the output; piped ones are not, so
+	// they are echoed to keep the ^ under the right column.
+	fi, _ := os.Stdin.Stat()
+	tty := fi != nil && fi.Mode()&os.ModeCharDevice != 0
+	if tty {
+		fmt.Println("calc: type an expression, :help, or quit.")
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		if tty {
+			fmt.Print("> ")
+		}
+		if !scanner.Scan() {
+			if tty {
+				fmt.Println()
+			}
+			return
+		}
+		line := strings.TrimSpace(scanner.Text())
+		echo := !tty
+		if strings.HasPrefix(line, "!") {
+			expanded, err := h.expand(line)
+			if err != nil {
+				fmt.Println(" ", err)
+				continue
+			}
+			line, echo = expanded, true
+		}
+		switch {
+		case line == "":
+			continue
+		case line == "quit" || line == "exit" || line == ":quit":
+			return
+		case strings.HasPrefix(line, ":"):
+			command(env, h, line)
+			continue
+		}
+		if err := h.add(line); err != nil {
+			fmt.Fprintln(os.Stderr, "history:", err)
+		}
+		run(env, line, os.Stdout, echo)
+	}
+}
+
+// run executes one line and prints the result, or the error with a ^
+// under its column. It reports whether the line succeeded.
+func run(env *calc.Env, line string, errOut io.Writer, echo bool) bool {
+	if echo {
+		fmt.Println(">", line)
+	}
+	res, err := env.Exec(line)
+	if err != nil {
+		var ce *calc.Error
+		if !errors.As(err, &ce) {
+			fmt.Fprintln(errOut, err)
+			return false
+		}
+		src := line
+		if f, ok := env.Func(ce.Func); ok && ce.Func != "" {
+			// The error is in a function body: show its definition.
+			src = f.Source
+			fmt.Fprintln(errOut, " ", src)
+		}
+		fmt.Fprintf(errOut, "  %s^\n", pad(src, ce.Pos))
+		fmt.Fprintln(errOut, err)
+		return false
+	}
+	switch s := res.Stmt; s.Kind {
+	case calc.DefStmt:
+		fmt.Println("  defined", s)
+	case calc.AssignStmt:
+		fmt.Printf("  %s = %s\n", s.Name, format(res.Value))
+	default:
+		fmt.Println(" ", format(res.Value))
+	}
+	return true
+}
+
+// pad returns the blanks that move the cursor to column pos of src,
+// keeping tabs and counting wide characters twice.
+func pad(src string, pos int) string {
+	var b strings.Builder
+	for _, r := range []rune(src)[:min(pos-1, len([]rune(src)))] {
+		if r == '\t' {
+			b.WriteByte('\t')
+			continue
+		}
+		b.WriteString(strings.Repeat(" ", width.RuneWidth(r)))
+	}
+	return b.String()
+}
+
+func format(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+
+func command(env *calc.Env, h *history, line string) {
+	switch line {
+	case ":help":
+		fmt.Printf(help, strings.Join(calc.Builtins(), " "))
+	case ":vars":
+		for _, name := range env.Vars() {
+			v, _ := env.Var(name)
+			fmt.Printf("  %s = %s\n", name, format(v))
+		}
+	case ":funcs":
+		for _, name := range env.Funcs() {
+			f, _ := env.Func(name)
+			fmt.Println(" ", f)
+		}
+	case ":history":
+		for i, l := range h.lines {
+			fmt.Printf("  %4d  %s\n", i+1, l)
+		}
+	default:
+		fmt.Printf("  unknown command %s; try :help\n", line)
+	}
+}
+
+// ---------------------------------------------------------
+// HISTORY
+// ---------------------------------------------------------
+
+// history keeps every line run, and appends it to a file if one is set.
+type history struct {
+	lines []string
+	file  *os.File
+}
+
+// maxLoad caps how many lines are read back from the history file.
+const maxLoad = 1000
+
+func openHistory(path string) (*history, error) {
+	h := &history{}
+	if path == "" {
+		return h, nil
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			h.lines = append(h.lines, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	h.lines = h.lines[max(0, len(h.lines)-maxLoad):]
+	h.file = f
+	return h, nil
+}
+
+// add records line, skipping a repeat of the previous one.
+func (h *history) add(line string) error {
+	if n := len(h.lines); n > 0 && h.lines[n-1] == line {
+		return nil
+	}
+	h.lines = append(h.lines, line)
+	if h.file == nil {
+		return nil
+	}
+	_, err := fmt.Fprintln(h.file, line)
+	return err
+}
+
+// expand turns !! and !n into the line they name.
+func (h *history) expand(s string) (string, error) {
+	n := len(h.lines)
+	if s != "!!" {
+		var err error
+		if n, err = strconv.Atoi(s[1:]); err != nil {
+			return "", fmt.Errorf("%s: want !! or !n", s)
+		}
+	}
+	if n < 1 || n > len(h.lines) {
+		return "", fmt.Errorf("%s: no such line in history (%d lines)", s, len(h.lines))
+	}
+	return h.lines[n-1], nil
+}
+
+func (h *history) Close() error {
+	if h.file == nil {
+		return nil
+	}
+	return h.file.Close()
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,303 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ---------------------------------------------------------
+// 5. BUILTINS
+// ---------------------------------------------------------
+
+// builtin is a function written in Go. Variadic ones have max -1 and get
+// their arguments as a slice, like sumAll's nums.
+type builtin struct {
+	min, max int
+	fn       func(args []float64) float64
+}
+
+func fn1(f func(float64) float64) builtin {
+	return builtin{1, 1, func(a []float64) float64 { return f(a[0]) }}
+}
+
+func fn2(f func(float64, float64) float64) builtin {
+	return builtin{2, 2, func(a []float64) float64 { return f(a[0], a[1]) }}
+}
+
+var builtins = map[string]builtin{
+	"abs": fn1(math.Abs), "sqrt": fn1(math.Sqrt), "cbrt": fn1(math.Cbrt),
+	"exp": fn1(math.Exp), "ln": fn1(math.Log), "log10": fn1(math.Log10), "log2": fn1(math.Log2),
+	"sin": fn1(math.Sin), "cos": fn1(math.Cos), "tan": fn1(math.Tan),
+	"floor": fn1(math.Floor), "ceil": fn1(math.Ceil), "round": fn1(math.Round), "trunc": fn1(math.Trunc),
+	"pow": fn2(math.Pow), "hypot": fn2(math.Hypot), "atan2": fn2(math.Atan2),
+
+	"sum": {0, -1, func(a []float64) float64 {
+		total := 0.0
+		for _, v := range a {
+			total += v
+		}
+		return total
+	}},
+	"avg": {1, -1, func(a []float64) float64 {
+		total := 0.0
+		for _, v := range a {
+			total += v
+		}
+		return total / float64(len(a))
+	}},
+	"min": {1, -1, func(a []float64) float64 { return slices.Min(a) }},
+	"max": {1, -1, func(a []float64) float64 { return slices.Max(a) }},
+}
+
+var constants = map[string]float64{"pi": math.Pi, "e": math.E}
+
+// Builtins returns the names of the builtin functions, sorted.
+func Builtins() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ---------------------------------------------------------
+// 6. EVALUATOR
+// ---------------------------------------------------------
+
+// Func is a user-defined function.
+type Func struct {
+	Name   string
+	Params []string
+	Body   Node
+	Source string // the defining line; Error.Pos points into it
+}
+
+func (f *Func) String() string {
+	return f.Name + "(" + strings.Join(f.Params, ", ") + ") = " + f.Body.String()
+}
+
+// Env holds variables and user functions between lines. The zero Env is
+// not ready; use NewEnv.
+//
+// Function bodies see their parameters and the variables of the Env,
+// looked up when the function is called, so a function may use a
+// variable that is assigned later. They do not see the caller's
+// parameters.
+type Env struct {
+	vars    map[string]float64
+	funcs   map[string]*Func
+	running map[string]bool // functions being called, to catch recursion
+}
+
+// NewEnv returns an Env with no variables besides the constants pi and e.
+func NewEnv() *Env {
+	return &Env{vars: make(map[string]float64), funcs: make(map[string]*Func), running: make(map[string]bool)}
+}
+
+// Result is what Exec did with a line.
+type Result struct {
+	Stmt  *Stmt
+	Value float64 // the value of an expression or assignment
+}
+
+// Exec parses and runs one line. An expression's value is also stored in
+// the variable ans. A definition replaces any earlier function of the
+// same name.
+func (e *Env) Exec(src string) (Result, error) {
+	s, err := Parse(src)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{Stmt: s}
+	switch s.Kind {
+	case DefStmt:
+		if _, ok := builtins[s.Name]; ok {
+			return res, errorAt(1, "cannot redefine builtin %s", s.Name)
+		}
+		e.funcs[s.Name] = &Func{Name: s.Name, Params: s.Params, Body: s.X, Source: src}
+		return res, nil
+	case AssignStmt:
+		if _, ok := constants[s.Name]; ok {
+			return res, errorAt(1, "cannot assign to constant %s", s.Name)
+		}
+	}
+	v, err := e.Eval(s.X)
+	if err != nil {
+		return res, err
+	}
+	res.Value = v
+	name := s.Name
+	if s.Kind == ExprStmt {
+		name = "ans"
+	}
+	e.vars[name] = v
+	return res, nil
+}
+
+// Eval evaluates an expression against the Env's variables.
+func (e *Env) Eval(n Node) (float64, error) {
+	return e.eval(n, nil)
+}
+
+// Var returns the value of a variable or constant.
+func (e *Env) Var(name string) (float64, bool) {
+	if v, ok := constants[name]; ok {
+		return v, true
+	}
+	v, ok := e.vars[name]
+	return v, ok
+}
+
+// Vars returns the names of the variables, sorted.
+func (e *Env) Vars() []string {
+	names := make([]string, 0, len(e.vars))
+	for name := range e.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Func returns a user function.
+func (e *Env) Func(name string) (*Func, bool) {
+	f, ok := e.funcs[name]
+	return f, ok
+}
+
+// Funcs returns the names of the user functions, sorted.
+func (e *Env) Funcs() []string {
+	names := make([]string, 0, len(e.funcs))
+	for name := range e.funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// eval evaluates n. locals holds the parameters of the function whose
+// body n is in, or is nil at the top level.
+func (e *Env) eval(n Node, locals map[string]float64) (float64, error) {
+	switch n := n.(type) {
+	case *Num:
+		return n.Value, nil
+	case *Var:
+		if v, ok := locals[n.Name]; ok {
+			return v, nil
+		}
+		if v, ok := e.Var(n.Name); ok {
+			return v, nil
+		}
+		return 0, errorAt(n.pos, "undefined variable %s", n.Name)
+	case *Unary:
+		x, err := e.eval(n.X, locals)
+		if n.Op == '-' {
+			x = -x
+		}
+		return x, err
+	case *Binary:
+		l, err := e.eval(n.L, locals)
+		if err != nil {
+			return 0, err
+		}
+		r, err := e.eval(n.R, locals)
+		if err != nil {
+			return 0, err
+		}
+		switch n.Op {
+		case '+':
+			return l + r, nil
+		case '-':
+			return l - r, nil
+		case '*':
+			return l * r, nil
+		case '^':
+			v := math.Pow(l, r)
+			if math.IsNaN(v) {
+				return 0, errorAt(n.pos, "%v ^ %v is not a number", l, r) // (-8)^(1/3)
+			}
+			return v, nil
+		}
+		if r == 0 {
+			return 0, &Error{Pos: n.pos, Err: ErrDivisionByZero}
+		}
+		if n.Op == '/' {
+			return l / r, nil
+		}
+		return math.Mod(l, r), nil // keeps the sign of l, like Go's %
+	case *Call:
+		args := make([]float64, len(n.Args))
+		for i, a := range n.Args {
+			v, err := e.eval(a, locals)
+			if err != nil {
+				return 0, err
+			}
+			args[i] = v
+		}
+		return e.call(n, args)
+	}
+	panic(fmt.Sprintf("calc: unknown node %T", n))
+}
+
+func (e *Env) call(n *Call, args []float64) (float64, error) {
+	if b, ok := builtins[n.Func]; ok {
+		if len(args) < b.min || b.max >= 0 && len(args) > b.max {
+			return 0, errorAt(n.pos, "%s takes %s, got %d", n.Func, arity(b.min, b.max), len(args))
+		}
+		v := b.fn(args)
+		if math.IsNaN(v) {
+			return 0, errorAt(n.pos, "%s(%s) is not a number", n.Func, join(args))
+		}
+		return v, nil
+	}
+
+	f, ok := e.funcs[n.Func]
+	if !ok {
+		return 0, errorAt(n.pos, "undefined function %s", n.Func)
+	}
+	if len(args) != len(f.Params) {
+		return 0, errorAt(n.pos, "%s takes %s, got %d", f.Name, arity(len(f.Params), len(f.Params)), len(args))
+	}
+	// There is no if, so a function that calls itself never stops.
+	if e.running[f.Name] {
+		return 0, errorAt(n.pos, "%s calls itself; recursion never ends without a condition", f.Name)
+	}
+	e.running[f.Name] = true
+	defer delete(e.running, f.Name)
+
+	locals := make(map[string]float64, len(args))
+	for i, p := range f.Params {
+		locals[p] = args[i]
+	}
+	v, err := e.eval(f.Body, locals)
+	if ce := (*Error)(nil); errors.As(err, &ce) && ce.Func == "" {
+		ce.Func = f.Name // the error is in f's body, not in the caller's line
+	}
+	return v, err
+}
+
+// arity describes an argument count; min == max unless max is -1.
+func arity(min, max int) string {
+	plural := func(n int) string {
+		if n == 1 {
+			return "1 argument"
+		}
+		return fmt.Sprintf("%d arguments", n)
+	}
+	if max < 0 {
+		return "at least " + plural(min)
+	}
+	return plural(min)
+}
+
+func join(args []float64) string {
+	s := make([]string, len(args))
+	for i, a := range args {
+		s[i] = fmt.Sprint(a)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,312 @@
+package calc
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------
+// 3. SYNTAX TREE
+// ---------------------------------------------------------
+
+// Node is an expression in the syntax tree: *Num, *Var, *Unary, *Binary
+// or *Call. String prints it back with only the parentheses it needs.
+type Node interface {
+	Pos() int // column of the node's first token, or of its operator
+	String() string
+}
+
+// Num is a number literal.
+type Num struct {
+	Value float64
+	Text  string // as written
+	pos   int
+}
+
+// Var is a variable, or a parameter inside a function body.
+type Var struct {
+	Name string
+	pos  int
+}
+
+// Unary is -X or +X.
+type Unary struct {
+	Op  byte
+	X   Node
+	pos int
+}
+
+// Binary is L Op R. Its Pos is the operator's column, which is where a
+// division by zero is reported.
+type Binary struct {
+	Op   byte
+	L, R Node
+	pos  int
+}
+
+// Call is Func(Args...).
+type Call struct {
+	Func string
+	Args []Node
+	pos  int
+}
+
+func (n *Num) Pos() int    { return n.pos }
+func (n *Var) Pos() int    { return n.pos }
+func (n *Unary) Pos() int  { return n.pos }
+func (n *Binary) Pos() int { return n.pos }
+func (n *Call) Pos() int   { return n.pos }
+
+func (n *Num) String() string { return n.Text }
+func (n *Var) String() string { return n.Name }
+
+func (n *Unary) String() string {
+	return string(n.Op) + wrap(n.X, precUnary+1)
+}
+
+func (n *Binary) String() string {
+	op := binary[n.Op]
+	// Parentheses are needed where the parser would group differently:
+	// for a child that binds looser, and for an equal one on the side
+	// the operator does not associate to.
+	l, r := op.prec, op.prec+1
+	if op.right {
+		l, r = op.prec+1, op.prec
+	}
+	return wrap(n.L, l) + " " + string(n.Op) + " " + wrap(n.R, r)
+}
+
+func (n *Call) String() string {
+	args := make([]string, len(n.Args))
+	for i, a := range n.Args {
+		args[i] = a.String()
+	}
+	return n.Func + "(" + strings.Join(args, ", ") + ")"
+}
+
+// wrap prints n, in parentheses if it binds looser than prec.
+func wrap(n Node, prec int) string {
+	p := precAtom
+	switch n := n.(type) {
+	case *Binary:
+		p = binary[n.Op].prec
+	case *Unary:
+		p = precUnary
+	}
+	if p < prec {
+		return "(" + n.String() + ")"
+	}
+	return n.String()
+}
+
+// StmtKind tells the three kinds of line apart.
+type StmtKind int
+
+const (
+	ExprStmt   StmtKind = iota // 2 * x
+	AssignStmt                 // x = 2
+	DefStmt                    // f(x, y) = x * y
+)
+
+// Stmt is one parsed line.
+type Stmt struct {
+	Kind   StmtKind
+	Name   string   // the variable or function defined; "" for ExprStmt
+	Params []string // DefStmt only
+	X      Node     // the expression, value or function body
+	Source string   // the line as given
+}
+
+func (s *Stmt) String() string {
+	switch s.Kind {
+	case AssignStmt:
+		return s.Name + " = " + s.X.String()
+	case DefStmt:
+		return s.Name + "(" + strings.Join(s.Params, ", ") + ") = " + s.X.String()
+	}
+	return s.X.String()
+}
+
+// ---------------------------------------------------------
+// 4. PARSER (precedence climbing)
+// ---------------------------------------------------------
+// Grammar:
+//   stmt    = expr [ "=" expr ]       (left side: name or name(params))
+//   expr    = unary { binop unary }   (grouped by the table below)
+//   unary   = ( "-" | "+" ) unary | primary
+//   primary = number | name | name "(" [ expr { "," expr } ] ")" | "(" expr ")"
+//
+// bigcalc in 03_basic_types has one function per precedence level. Here
+// one loop handles every binary operator: expr(min) parses operators that
+// bind at least as tightly as min, and for each one parses its right
+// side with a higher minimum, so tighter operators nest deeper.
+
+var binary = map[byte]struct {
+	prec  int
+	right bool // right-associative: 2^3^2 is 2^(3^2)
+}{
+	'+': {1, false}, '-': {1, false},
+	'*': {2, false}, '/': {2, false}, '%': {2, false},
+	'^': {4, true},
+}
+
+const (
+	precUnary = 3 // -2^2 is -(2^2), but -2*3 is (-2)*3
+	precAtom  = 5
+)
+
+type parser struct {
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tEOF {
+		p.i++
+	}
+	return t
+}
+
+// Parse parses one line: an expression, an assignment "x = expr", or a
+// function definition "f(a, b) = expr".
+func Parse(src string) (*Stmt, error) {
+	toks, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	x, err := p.expr(1)
+	if err != nil {
+		return nil, err
+	}
+	s := &Stmt{Kind: ExprStmt, X: x, Source: src}
+	if eq := p.peek(); eq.is("=") {
+		p.next()
+		if err := s.setTarget(x, eq); err != nil {
+			return nil, err
+		}
+		if s.X, err = p.expr(1); err != nil {
+			return nil, err
+		}
+	}
+	if t := p.peek(); t.kind != tEOF {
+		return nil, errorAt(t.pos, "unexpected %v", t)
+	}
+	return s, nil
+}
+
+// setTarget checks the left side of "=" and fills in Kind, Name and
+// Params.
+func (s *Stmt) setTarget(lhs Node, eq token) error {
+	switch lhs := lhs.(type) {
+	case *Var:
+		s.Kind, s.Name = AssignStmt, lhs.Name
+		return nil
+	case *Call:
+		s.Kind, s.Name, s.Params = DefStmt, lhs.Func, []string{}
+		for _, a := range lhs.Args {
+			v, ok := a.(*Var)
+			if !ok {
+				return errorAt(a.Pos(), "parameter must be a name, not %s", a)
+			}
+			for _, prev := range s.Params {
+				if prev == v.Name {
+					return errorAt(v.pos, "duplicate parameter %s", v.Name)
+				}
+			}
+			s.Params = append(s.Params, v.Name)
+		}
+		return nil
+	}
+	return errorAt(eq.pos, "cannot assign to %s", lhs)
+}
+
+func (p *parser) expr(min int) (Node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tPunct {
+			return left, nil
+		}
+		op, ok := binary[t.text[0]]
+		if !ok || op.prec < min {
+			return left, nil
+		}
+		p.next()
+		next := op.prec + 1
+		if op.right {
+			next = op.prec
+		}
+		right, err := p.expr(next)
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: t.text[0], L: left, R: right, pos: t.pos}
+	}
+}
+
+func (p *parser) unary() (Node, error) {
+	if t := p.peek(); t.is("-") || t.is("+") {
+		p.next()
+		x, err := p.expr(precUnary + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: t.text[0], X: x, pos: t.pos}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (Node, error) {
+	t := p.next()
+	switch {
+	case t.kind == tNum:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, errorAt(t.pos, "number %s out of range", t.text)
+		}
+		if err != nil {
+			return nil, errorAt(t.pos, "malformed number %s", t.text)
+		}
+		return &Num{Value: v, Text: t.text, pos: t.pos}, nil
+	case t.kind == tIdent && p.peek().is("("):
+		p.next()
+		c := &Call{Func: t.text, pos: t.pos}
+		if p.peek().is(")") {
+			p.next()
+			return c, nil
+		}
+		for {
+			a, err := p.expr(1)
+			if err != nil {
+				return nil, err
+			}
+			c.Args = append(c.Args, a)
+			switch sep := p.next(); {
+			case sep.is(")"):
+				return c, nil
+			case !sep.is(","):
+				return nil, errorAt(sep.pos, "expected , or ) in call to %s, found %v", c.Func, sep)
+			}
+		}
+	case t.kind == tIdent:
+		return &Var{Name: t.text, pos: t.pos}, nil
+	case t.is("("):
+		x, err := p.expr(1)
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); !c.is(")") {
+			return nil, errorAt(c.pos, "expected ) to close ( at column %d, found %v", t.pos, c)
+		}
+		return x, nil
+	}
+	return nil, errorAt(t.pos, "unexpected %v", t)
+}
@@ -0,0 +1,142 @@
+// Package checked does integer arithmetic that reports overflow and
+// division by zero as errors instead of wrapping around or panicking.
+//
+// The lesson's divide(17, 0) panics, and sumAll over large numbers wraps
+// around silently: Go integers are fixed-size, and math.MaxInt + 1 is
+// math.MinInt. Each function here returns (T, error), the second return
+// value pattern from section 2 of the lesson:
+//
+//	q, err := checked.Div(17, 0)                // 0, ErrDivisionByZero
+//	s, err := checked.Sum[int8](100, 27, 1)     // 0, ErrOverflow
+//	n, err := checked.Mul[uint32](70000, 70000) // 0, ErrOverflow
+//
+// Errors wrap ErrOverflow or ErrDivisionByZero, so errors.Is tells them
+// apart, and their text names the operation: "checked: integer overflow:
+// 127 + 1 (int8)". On error the value is always 0.
+//
+// The functions are generic over every integer type, including named
+// ones like time.Duration. checked_test.go fuzzes them against math/big
+// (go test -fuzz FuzzMul), and cmd/arithcheck runs the same comparison
+// as a program.
+package checked
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ---------------------------------------------------------
+// 1. TYPES AND ERRORS
+// ---------------------------------------------------------
+
+// Integer is any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+var (
+	ErrOverflow       = errors.New("checked: integer overflow")
+	ErrDivisionByZero = errors.New("checked: division by zero")
+)
+
+func overflow[T Integer](a T, op string, b T) error {
+	return fmt.Errorf("%w: %d %s %d (%T)", ErrOverflow, a, op, b, a)
+}
+
+func divByZero[T Integer](a T, op string) error {
+	return fmt.Errorf("%w: %d %s 0 (%T)", ErrDivisionByZero, a, op, a)
+}
+
+// signed reports whether T is a signed type: only then is ^0 negative.
+func signed[T Integer]() bool { return ^T(0) < 0 }
+
+// ---------------------------------------------------------
+// 2. OPERATIONS
+// ---------------------------------------------------------
+// Each check runs the plain operation (which wraps around) and then asks
+// whether the wrapped result can be the true one.
+
+// Add returns a + b.
+func Add[T Integer](a, b T) (T, error) {
+	c := a + b
+	// Signed: adding two numbers of the same sign cannot flip the sign.
+	// Unsigned: the sum cannot be smaller than an operand.
+	if signed[T]() && (a < 0) == (b < 0) && (c < 0) != (a < 0) || !signed[T]() && c < a {
+		return 0, overflow(a, "+", b)
+	}
+	return c, nil
+}
+
+// Sub returns a - b.
+func Sub[T Integer](a, b T) (T, error) {
+	c := a - b
+	// Signed: a - b overflows only when a and b differ in sign and the
+	// result has b's sign. Unsigned: b must not exceed a.
+	if signed[T]() && (a < 0) != (b < 0) && (c < 0) != (a < 0) || !signed[T]() && b > a {
+		return 0, overflow(a, "-", b)
+	}
+	return c, nil
+}
+
+// Mul returns a * b.
+func Mul[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	// A wrapped product no longer divides back. The one case division
+	// misses is MinInt * -1, which wraps to MinInt with the wrong sign.
+	if c/b != a || signed[T]() && (c < 0) != ((a < 0) != (b < 0)) {
+		return 0, overflow(a, "*", b)
+	}
+	return c, nil
+}
+
+// Div returns a / b, truncated toward zero like Go's /.
+func Div[T Integer](a, b T) (T, error) {
+	if b == 0 {
+		return 0, divByZero(a, "/")
+	}
+	q := a / b
+	// MinInt / -1 is MaxInt + 1; Go wraps it back to MinInt.
+	if signed[T]() && b == ^T(0) && a < 0 && q < 0 {
+		return 0, overflow(a, "/", b)
+	}
+	return q, nil
+}
+
+// Mod returns the remainder a % b, with the sign of a like Go's %. It
+// never overflows: MinInt % -1 is 0.
+func Mod[T Integer](a, b T) (T, error) {
+	if b == 0 {
+		return 0, divByZero(a, "%")
+	}
+	return a % b, nil
+}
+
+// DivMod returns a / b and a % b together, like the lesson's divide but
+// with an error instead of a panic.
+func DivMod[T Integer](a, b T) (q, r T, err error) {
+	if q, err = Div(a, b); err != nil {
+		return 0, 0, err
+	}
+	return q, a % b, nil
+}
+
+// Sum returns the sum of nums, or an error as soon as a partial sum
+// overflows. The empty sum is 0.
+//
+// Like sumAll's loop, it checks every partial sum, so order matters:
+// Sum[int8](100, 100, -100) fails at 100 + 100 although the total, 100,
+// fits, while Sum[int8](100, -100, 100) succeeds.
+func Sum[T Integer](nums ...T) (T, error) {
+	var total T
+	for _, n := range nums {
+		var err error
+		if total, err = Add(total, n); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
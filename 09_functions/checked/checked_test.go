@@ -0,0 +1,146 @@
+package checked
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// Each fuzz target gets two int64s and checks the operation on every
+// integer type the values convert to, against math/big, which never
+// overflows.
+
+func FuzzAdd(f *testing.F) {
+	fuzzOp(f, Add[int8], Add[int16], Add[int32], Add[int64], Add[uint8], Add[uint16], Add[uint32], Add[uint64], false, (*big.Int).Add)
+}
+func FuzzSub(f *testing.F) {
+	fuzzOp(f, Sub[int8], Sub[int16], Sub[int32], Sub[int64], Sub[uint8], Sub[uint16], Sub[uint32], Sub[uint64], false, (*big.Int).Sub)
+}
+func FuzzMul(f *testing.F) {
+	fuzzOp(f, Mul[int8], Mul[int16], Mul[int32], Mul[int64], Mul[uint8], Mul[uint16], Mul[uint32], Mul[uint64], false, (*big.Int).Mul)
+}
+
+func FuzzDiv(f *testing.F) {
+	quo := func(z, x, y *big.Int) *big.Int { return z.Quo(x, y) } // truncated, like Go
+	fuzzOp(f, Div[int8], Div[int16], Div[int32], Div[int64], Div[uint8], Div[uint16], Div[uint32], Div[uint64], true, quo)
+}
+
+func FuzzMod(f *testing.F) {
+	rem := func(z, x, y *big.Int) *big.Int { return z.Rem(x, y) }
+	fuzzOp(f, Mod[int8], Mod[int16], Mod[int32], Mod[int64], Mod[uint8], Mod[uint16], Mod[uint32], Mod[uint64], true, rem)
+}
+
+type op[T Integer] func(a, b T) (T, error)
+
+func fuzzOp(f *testing.F,
+	i8 op[int8], i16 op[int16], i32 op[int32], i64 op[int64],
+	u8 op[uint8], u16 op[uint16], u32 op[uint32], u64 op[uint64],
+	div bool, exact func(z, x, y *big.Int) *big.Int,
+) {
+	for _, v := range [][2]int64{
+		{0, 0}, {1, 0}, {1, 1}, {-1, 1}, {math.MaxInt64, 1}, {math.MinInt64, -1},
+		{math.MaxInt32, 2}, {math.MinInt8, -1}, {127, 127}, {-128, 255}, {1 << 32, 1 << 32},
+	} {
+		f.Add(v[0], v[1])
+	}
+	f.Fuzz(func(t *testing.T, a, b int64) {
+		checkOp(t, i8, int8(a), int8(b), div, exact)
+		checkOp(t, i16, int16(a), int16(b), div, exact)
+		checkOp(t, i32, int32(a), int32(b), div, exact)
+		checkOp(t, i64, a, b, div, exact)
+		checkOp(t, u8, uint8(a), uint8(b), div, exact)
+		checkOp(t, u16, uint16(a), uint16(b), div, exact)
+		checkOp(t, u32, uint32(a), uint32(b), div, exact)
+		checkOp(t, u64, uint64(a), uint64(b), div, exact)
+	})
+}
+
+// checkOp compares f(a, b) with exact; for a division (div) by zero it
+// wants ErrDivisionByZero.
+func checkOp[T Integer](t *testing.T, f op[T], a, b T, div bool, exact func(z, x, y *big.Int) *big.Int) {
+	t.Helper()
+	got, err := f(a, b)
+	if div && b == 0 {
+		if !errors.Is(err, ErrDivisionByZero) || got != 0 {
+			t.Errorf("%T(%d), %T(%d) = %d, %v; want 0, %v", a, a, b, b, got, err, ErrDivisionByZero)
+		}
+		return
+	}
+	want := exact(new(big.Int), toBig(a), toBig(b))
+	check(t, got, err, want, fits[T](want), "%T(%d), %T(%d)", a, a, b, b)
+}
+
+func FuzzSum(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{100, 100, 0x9c}) // int8: 100, 100, -100
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f, 1, 0, 0, 0, 0, 0, 0, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		i8 := make([]int8, len(data))
+		u8 := make([]uint8, len(data))
+		for i, c := range data {
+			i8[i], u8[i] = int8(c), c
+		}
+		var i64 []int64
+		var u64 []uint64
+		for b := data; len(b) >= 8; b = b[8:] {
+			v := binary.LittleEndian.Uint64(b)
+			i64, u64 = append(i64, int64(v)), append(u64, v)
+		}
+		checkSum(t, i8)
+		checkSum(t, u8)
+		checkSum(t, i64)
+		checkSum(t, u64)
+	})
+}
+
+func checkSum[T Integer](t *testing.T, nums []T) {
+	t.Helper()
+	total, ok := new(big.Int), true
+	for _, n := range nums {
+		total.Add(total, toBig(n))
+		ok = ok && fits[T](total) // Sum checks every partial sum
+	}
+	got, err := Sum(nums...)
+	check(t, got, err, total, ok, "Sum(%v)", nums)
+}
+
+// check wants got == want and no error if ok, else 0 and ErrOverflow.
+func check[T Integer](t *testing.T, got T, err error, want *big.Int, ok bool, format string, args ...any) {
+	t.Helper()
+	if !ok {
+		if !errors.Is(err, ErrOverflow) || got != 0 {
+			t.Errorf(format+" = %d, %v; want 0, %v", append(args, got, err, ErrOverflow)...)
+		}
+		return
+	}
+	if err != nil || toBig(got).Cmp(want) != 0 {
+		t.Errorf(format+" = %d, %v; want %v", append(args, got, err, want)...)
+	}
+}
+
+func fits[T Integer](v *big.Int) bool {
+	lo, hi := limits[T]()
+	return v.Cmp(lo) >= 0 && v.Cmp(hi) <= 0
+}
+
+func limits[T Integer]() (lo, hi *big.Int) {
+	bits := 0
+	for v := ^T(0); v != 0; v <<= 1 {
+		bits++
+	}
+	if signed[T]() {
+		hi = new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		return new(big.Int).Neg(hi), hi.Sub(hi, big.NewInt(1))
+	}
+	hi = new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	return new(big.Int), hi.Sub(hi, big.NewInt(1))
+}
+
+func toBig[T Integer](v T) *big.Int {
+	if v < 0 {
+		return big.NewInt(int64(v))
+	}
+	return new(big.Int).SetUint64(uint64(v))
+}
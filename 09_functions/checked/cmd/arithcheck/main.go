@@ -0,0 +1,224 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/09_functions/checked"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Checking Checked Arithmetic Against math/big
+// ---------------------------------------------------------
+// Usage:
+//   go run ./09_functions/checked/cmd/arithcheck
+//   go run ./09_functions/checked/cmd/arithcheck -n 1000000 -seed 42
+//
+// math/big integers never overflow, so they are the oracle: for every
+// integer type, each operation is run on the edge values (0, ±1, MinInt,
+// MaxInt and their neighbours) crossed with each other, then on -n random
+// pairs, and on random slices for Sum. The big.Int result either fits
+// in the type, and checked must return it, or it does not, and checked
+// must return ErrOverflow. Exits with status 1 on any disagreement.
+
+func main() {
+	n := flag.Int("n", 200_000, "random cases per type and operation")
+	seed := flag.Uint64("seed", 1, "random seed")
+	flag.Parse()
+
+	demo()
+
+	rng := rand.New(rand.NewPCG(*seed, *seed))
+	fmt.Printf("\n%-14s %12s %10s\n", "type", "cases", "failures")
+	failed := 0
+	for _, c := range []func() (string, int, int){
+		run[int8](rng, *n), run[int16](rng, *n), run[int32](rng, *n), run[int64](rng, *n), run[int](rng, *n),
+		run[uint8](rng, *n), run[uint16](rng, *n), run[uint32](rng, *n), run[uint64](rng, *n), run[uint](rng, *n),
+		run[uintptr](rng, *n), run[time.Duration](rng, *n),
+	} {
+		name, cases, failures := c()
+		failed += failures
+		fmt.Printf("%-14s %12d %10d\n", name, cases, failures)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func demo() {
+	fmt.Println("The lesson's functions, checked:")
+	q, r, err := checked.DivMod(17, 5)
+	fmt.Printf("  DivMod(17, 5)             = %d, %d, %v\n", q, r, err)
+	q, r, err = checked.DivMod(17, 0)
+	fmt.Printf("  DivMod(17, 0)             = %d, %d, %v\n", q, r, err)
+	s, err := checked.Sum(100, 200, 300)
+	fmt.Printf("  Sum(100, 200, 300)        = %d, %v\n", s, err)
+	s, err = checked.Sum(math.MaxInt, 1)
+	fmt.Printf("  Sum(math.MaxInt, 1)       = %d, %v (plain +: %d)\n", s, err, wrap(math.MaxInt, 1))
+	d, err := checked.Mul(time.Duration(math.MaxInt64/2), 3)
+	fmt.Printf("  Mul(MaxInt64/2 ns, 3)     = %v, %v\n", d, err)
+	if errors.Is(err, checked.ErrOverflow) {
+		fmt.Println("  errors.Is(err, checked.ErrOverflow): true")
+	}
+}
+
+// wrap adds without checking; a function so that the constant expression
+// math.MaxInt + 1 does not stop the compiler.
+func wrap(a, b int) int { return a + b }
+
+// ---------------------------------------------------------
+// THE ORACLE
+// ---------------------------------------------------------
+
+// run returns a test of all operations for T.
+func run[T checked.Integer](rng *rand.Rand, n int) func() (string, int, int) {
+	return func() (string, int, int) {
+		lo, hi := limits[T]()
+		o := oracle[T]{lo: lo, hi: hi}
+
+		edges := []T{0, 1, 2, 3}
+		for _, v := range []*big.Int{lo, hi} {
+			for d := int64(-2); d <= 2; d++ {
+				w := new(big.Int).Add(v, big.NewInt(d))
+				if w.Cmp(lo) >= 0 && w.Cmp(hi) <= 0 {
+					edges = append(edges, o.fromBig(w))
+				}
+			}
+		}
+		if lo.Sign() < 0 {
+			edges = append(edges, o.neg(1), o.neg(2), o.neg(3))
+		}
+		for _, a := range edges {
+			for _, b := range edges {
+				o.pair(a, b)
+			}
+		}
+
+		// Random pairs: full-width values overflow almost always, so mix
+		// in small values and values of half the width.
+		random := func() T {
+			switch v := rng.Uint64(); rng.IntN(3) {
+			case 0:
+				return T(v)
+			case 1:
+				return T(int64(v) >> 32 >> rng.IntN(32))
+			default:
+				return T(int64(v) % 1000)
+			}
+		}
+		for range n {
+			o.pair(random(), random())
+		}
+		for range n / 10 {
+			nums := make([]T, rng.IntN(6))
+			for i := range nums {
+				nums[i] = random()
+			}
+			o.sum(nums)
+		}
+		return fmt.Sprintf("%T", T(0)), o.cases, o.failures
+	}
+}
+
+type oracle[T checked.Integer] struct {
+	lo, hi          *big.Int
+	cases, failures int
+}
+
+// limits returns the range of T, found by setting every bit.
+func limits[T checked.Integer]() (lo, hi *big.Int) {
+	bits := 0
+	for v := ^T(0); v != 0; v <<= 1 {
+		bits++
+	}
+	if ^T(0) < 0 { // signed
+		hi = new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		lo = new(big.Int).Neg(hi)
+		return lo, hi.Sub(hi, big.NewInt(1))
+	}
+	hi = new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	return new(big.Int), hi.Sub(hi, big.NewInt(1))
+}
+
+func (o *oracle[T]) toBig(v T) *big.Int {
+	if v < 0 {
+		return big.NewInt(int64(v))
+	}
+	return new(big.Int).SetUint64(uint64(v))
+}
+
+func (o *oracle[T]) fromBig(b *big.Int) T {
+	if b.Sign() < 0 {
+		return T(b.Int64())
+	}
+	return T(b.Uint64())
+}
+
+func (o *oracle[T]) neg(v int64) T { return o.fromBig(big.NewInt(-v)) }
+
+func (o *oracle[T]) pair(a, b T) {
+	x, y := o.toBig(a), o.toBig(b)
+	o.check("+", a, b, checked.Add[T], new(big.Int).Add(x, y))
+	o.check("-", a, b, checked.Sub[T], new(big.Int).Sub(x, y))
+	o.check("*", a, b, checked.Mul[T], new(big.Int).Mul(x, y))
+	var q, r *big.Int // nil: division by zero
+	if y.Sign() != 0 {
+		q, r = new(big.Int).QuoRem(x, y, new(big.Int)) // truncated, like Go
+	}
+	o.check("/", a, b, checked.Div[T], q)
+	o.check("%", a, b, checked.Mod[T], r)
+}
+
+func (o *oracle[T]) sum(nums []T) {
+	total, want := new(big.Int), error(nil)
+	for _, n := range nums {
+		total.Add(total, o.toBig(n))
+		if !o.fits(total) {
+			want = checked.ErrOverflow // checked stops at the first bad partial sum
+		}
+	}
+	got, err := checked.Sum(nums...)
+	o.compare(fmt.Sprintf("Sum%v", nums), got, err, total, want)
+}
+
+func (o *oracle[T]) fits(v *big.Int) bool { return v.Cmp(o.lo) >= 0 && v.Cmp(o.hi) <= 0 }
+
+// check compares op(a, b) with the exact result; nil exact means
+// division by zero.
+func (o *oracle[T]) check(sym string, a, b T, op func(a, b T) (T, error), exact *big.Int) {
+	var want error
+	switch {
+	case exact == nil:
+		want = checked.ErrDivisionByZero
+	case !o.fits(exact):
+		want = checked.ErrOverflow
+	}
+	got, err := op(a, b)
+	o.compare(fmt.Sprintf("%d %s %d", a, sym, b), got, err, exact, want)
+}
+
+// compare wants got == exact and no error, or 0 and an error wrapping
+// want.
+func (o *oracle[T]) compare(what string, got T, err error, exact *big.Int, want error) {
+	o.cases++
+	ok := got == 0 && err != nil && want != nil && errors.Is(err, want)
+	if want == nil {
+		ok = err == nil && o.toBig(got).Cmp(exact) == 0
+	}
+	if !ok {
+		o.fail(what, got, err, exact, want)
+	}
+}
+
+func (o *oracle[T]) fail(what string, got T, err error, exact *big.Int, want error) {
+	o.failures++
+	if o.failures <= 5 {
+		fmt.Printf("  %T: %s = %d, %v; want %v, %v\n", got, what, got, err, exact, want)
+	}
+}
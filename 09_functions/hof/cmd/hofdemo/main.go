@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+	"github.com/ViKing-py/lets-go-in-go/09_functions/hof"
+)
+
+// ---------------------------------------------------------
+// TOPIC: Functions as Values
+// ---------------------------------------------------------
+// Usage:
+//   go run ./09_functions/hof/cmd/hofdemo
+//
+// Runs every helper in hof once. Memoize counts how often the wrapped
+// function really runs. Debounce and Throttle get the same burst of
+// keystrokes on a clock.Fake, so the two seconds of typing replay
+// instantly and the same way every time. Retry uses the real clock with
+// millisecond waits against a function that fails twice.
+
+func add(x, y int) int { return x + y }
+
+func main() {
+	composition()
+	memoize()
+	once()
+	typing()
+	retry()
+}
+
+func composition() {
+	fmt.Println("--- Compose, Pipe, Partial ---")
+	inc := hof.Partial(add, 1)
+	double := func(x int) int { return x * 2 }
+	fmt.Println("Pipe(inc, double)(5)    =", hof.Pipe(inc, double)(5))    // double(inc(5))
+	fmt.Println("Compose(inc, double)(5) =", hof.Compose(inc, double)(5)) // inc(double(5))
+
+	square := hof.PartialRight(math.Pow, 2)
+	label := hof.Pipe2(square, func(f float64) string { return fmt.Sprintf("%.0f m²", f) })
+	fmt.Println("Pipe2(square, label)(7) =", label(7))
+	shout := hof.Compose(strings.ToUpper, strings.TrimSpace, hof.PartialRight(strings.TrimSuffix, "!"))
+	fmt.Printf("Compose(ToUpper, TrimSpace, TrimSuffix \"!\")(%q) = %q\n", "  hi there!", shout("  hi there!"))
+}
+
+func memoize() {
+	fmt.Println("\n--- Memoize ---")
+	// fib calls itself through the memoized value, so every n is
+	// computed once: 91 calls instead of about 9.3e18.
+	calls := 0
+	var fib func(n int) uint64
+	fib = hof.Memoize(func(n int) uint64 {
+		calls++
+		if n < 2 {
+			return uint64(n)
+		}
+		return fib(n-1) + fib(n-2)
+	}, hof.MemoOptions{})
+	fmt.Printf("fib(90) = %d after %d calls\n", fib(90), calls)
+
+	fake := clock.NewFake(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	lookups := 0
+	rate := hof.Memoize(func(code string) float64 {
+		lookups++
+		return map[string]float64{"USD": 41.5, "EUR": 48.3, "PLN": 11.4}[code]
+	}, hof.MemoOptions{MaxEntries: 2, TTL: time.Minute, Clock: fake})
+	for _, step := range []struct {
+		advance time.Duration
+		code    string
+	}{
+		{0, "USD"}, {0, "USD"}, {0, "EUR"}, {0, "PLN"}, // PLN pushes out USD
+		{0, "USD"}, {2 * time.Minute, "USD"}, // expired
+	} {
+		fake.Advance(step.advance)
+		before := lookups
+		v := rate(step.code)
+		how := "cached"
+		if lookups > before {
+			how = "looked up"
+		}
+		fmt.Printf("  +%-4v rate(%s) = %.1f  %s\n", fake.Now().Sub(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)), step.code, v, how)
+	}
+}
+
+func once() {
+	fmt.Println("\n--- Once ---")
+	tries := 0
+	connect := hof.Once(func() (string, error) {
+		tries++
+		if tries < 3 {
+			return "", fmt.Errorf("connection refused (try %d)", tries)
+		}
+		return "db-conn-1", nil
+	})
+	for range 4 {
+		conn, err := connect()
+		fmt.Printf("  connect() = %q, %v\n", conn, err)
+	}
+	fmt.Println("  f ran", tries, "times; the fourth call got the stored result")
+}
+
+// typing sends the same keystrokes to a Debounced and a Throttled save.
+func typing() {
+	fmt.Println("\n--- Debounce and Throttle (500ms, fake clock) ---")
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	keys := []struct {
+		at   time.Duration
+		text string
+	}{
+		{0, "h"}, {100 * time.Millisecond, "he"}, {200 * time.Millisecond, "hel"},
+		{300 * time.Millisecond, "hell"}, {400 * time.Millisecond, "hello"},
+		{1500 * time.Millisecond, "hello w"}, {1600 * time.Millisecond, "hello wo"},
+		{1700 * time.Millisecond, "hello wor"},
+	}
+	for _, mode := range []string{"debounce", "throttle"} {
+		fake := clock.NewFake(start)
+		save := func(text string) {
+			fmt.Printf("  %-8s saved %-12q at +%v\n", mode, text, fake.Now().Sub(start))
+		}
+		var call func(string)
+		if mode == "debounce" {
+			call = hof.Debounce(fake, 500*time.Millisecond, save).Call
+		} else {
+			call = hof.Throttle(fake, 500*time.Millisecond, save).Call
+		}
+		for _, k := range keys {
+			fake.Set(start.Add(k.at)) // fires the saves due on the way
+			call(k.text)
+		}
+		fake.Advance(time.Second)
+	}
+	fmt.Printf("  %d keystrokes; debounce saves after each pause, throttle during typing too\n", len(keys))
+}
+
+func retry() {
+	fmt.Println("\n--- Retry ---")
+	b := hof.Backoff{
+		Attempts: 5, Initial: 10 * time.Millisecond, Jitter: 0.2,
+		Rand: func() float64 { return 0.75 }, // fixed, so the output is too
+		OnRetry: func(n int, err error, wait time.Duration) {
+			fmt.Printf("  attempt %d: %v; waiting %v\n", n, err, wait)
+		},
+	}
+	var delays []string
+	for n := 1; n < b.Attempts; n++ {
+		delays = append(delays, b.Delay(n).String())
+	}
+	fmt.Println("  delays:", strings.Join(delays, ", "))
+
+	calls := 0
+	flaky := func(context.Context) (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, errors.New("503 Service Unavailable")
+		}
+		return 200, nil
+	}
+	status, err := hof.Retry(context.Background(), b, flaky)
+	fmt.Printf("  Retry(flaky) = %d, %v\n", status, err)
+
+	errNotFound := errors.New("404 Not Found")
+	_, err = hof.Retry(context.Background(), b, func(context.Context) (int, error) {
+		return 0, hof.Permanent(errNotFound)
+	})
+	fmt.Printf("  Retry(Permanent 404) = %v; errors.Is(err, errNotFound) = %t\n", err, errors.Is(err, errNotFound))
+
+	b.OnRetry = nil
+	ctx, cancel := context.WithTimeout(context.Background(), 25*time.Millisecond)
+	defer cancel()
+	_, err = hof.Retry(ctx, b, func(context.Context) (int, error) { return 0, errors.New("timeout") })
+	fmt.Printf("  Retry(always failing, 25ms deadline) = %v\n", err)
+}
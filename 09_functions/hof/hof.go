@@ -0,0 +1,109 @@
+// Package hof collects higher-order functions: functions that take
+// functions as arguments or return them. The lesson stops at variadic
+// parameters, but in Go a function is a value like any other. It has a
+// type (func(int) int), it can be stored in a variable or a struct, and
+// a closure keeps the variables it captured alive.
+//
+// Everything here is generic and safe for concurrent use:
+//
+//	inc := hof.Partial(add, 1)              // func(y int) int { return add(1, y) }
+//	square := func(x int) int { return x * x }
+//	hof.Pipe(square, inc)(3)                // inc(square(3)) = 10
+//	get := hof.Memoize(lookup, hof.MemoOptions{MaxEntries: 1000, TTL: time.Minute})
+//	save := hof.Debounce(clk, time.Second, writeFile) // one write per burst of edits
+//	v, err := hof.Retry(ctx, hof.Backoff{Attempts: 5}, fetch)
+//
+// Functions that wait (Debounce, Throttle, Retry, MemoOptions with a
+// TTL) take their time from a clock from 04_control_flow/clock, so a
+// clock.Fake can drive them without sleeping. cmd/hofdemo runs each
+// one, Debounce and Throttle on a fake clock.
+package hof
+
+import "sync"
+
+// ---------------------------------------------------------
+// 1. COMPOSITION
+// ---------------------------------------------------------
+
+// Compose returns the function that applies fs from right to left, as in
+// maths: Compose(f, g)(x) is f(g(x)). With no functions it returns x.
+func Compose[T any](fs ...func(T) T) func(T) T {
+	return func(x T) T {
+		for i := len(fs) - 1; i >= 0; i-- {
+			x = fs[i](x)
+		}
+		return x
+	}
+}
+
+// Pipe returns the function that applies fs from left to right, in the
+// order they are read: Pipe(f, g)(x) is g(f(x)).
+func Pipe[T any](fs ...func(T) T) func(T) T {
+	return func(x T) T {
+		for _, f := range fs {
+			x = f(x)
+		}
+		return x
+	}
+}
+
+// Compose2 is Compose for two functions whose types differ: it turns an
+// A into a B with g, then the B into a C with f.
+func Compose2[A, B, C any](f func(B) C, g func(A) B) func(A) C {
+	return func(a A) C { return f(g(a)) }
+}
+
+// Pipe2 is Pipe for two functions whose types differ: Pipe2(f, g) is
+// Compose2(g, f).
+func Pipe2[A, B, C any](f func(A) B, g func(B) C) func(A) C {
+	return func(a A) C { return g(f(a)) }
+}
+
+// ---------------------------------------------------------
+// 2. PARTIAL APPLICATION
+// ---------------------------------------------------------
+
+// Partial fixes the first argument of f: Partial(add, 10) is a function
+// that adds 10.
+func Partial[A, B, R any](f func(A, B) R, a A) func(B) R {
+	return func(b B) R { return f(a, b) }
+}
+
+// PartialRight fixes the second argument of f: PartialRight(math.Pow, 2)
+// squares its argument.
+func PartialRight[A, B, R any](f func(A, B) R, b B) func(A) R {
+	return func(a A) R { return f(a, b) }
+}
+
+// ---------------------------------------------------------
+// 3. ONCE
+// ---------------------------------------------------------
+
+// Once returns a function that calls f until it succeeds, then returns
+// that result forever without calling f again. Concurrent callers wait
+// for a call in progress instead of starting their own.
+//
+// sync.OnceValues is the same for an f that cannot fail, or whose error
+// should stick: it remembers the first result, error or not. Once suits
+// lazy setup that can fail for a while, like a first connection.
+func Once[T any](f func() (T, error)) func() (T, error) {
+	var (
+		mu    sync.Mutex
+		done  bool
+		value T
+	)
+	return func() (T, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if done {
+			return value, nil
+		}
+		v, err := f()
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		value, done = v, true
+		return value, nil
+	}
+}
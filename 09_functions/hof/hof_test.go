@@ -0,0 +1,373 @@
+package hof
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+)
+
+var start = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestComposition(t *testing.T) {
+	inc := func(x int) int { return x + 1 }
+	square := func(x int) int { return x * x }
+	if got := Compose(inc, square)(3); got != 10 {
+		t.Errorf("Compose(inc, square)(3) = %d, want 10", got)
+	}
+	if got := Pipe(inc, square)(3); got != 16 {
+		t.Errorf("Pipe(inc, square)(3) = %d, want 16", got)
+	}
+	if got := Compose[int]()(3); got != 3 {
+		t.Errorf("Compose()(3) = %d, want 3", got)
+	}
+	if got := Compose2(strconv.Itoa, square)(4); got != "16" {
+		t.Errorf("Compose2 = %q, want 16", got)
+	}
+	if got := Pipe2(square, strconv.Itoa)(5); got != "25" {
+		t.Errorf("Pipe2 = %q, want 25", got)
+	}
+	if got := Partial(math.Pow, 2)(10); got != 1024 {
+		t.Errorf("Partial(Pow, 2)(10) = %v, want 1024", got)
+	}
+	if got := PartialRight(math.Pow, 2)(10); got != 100 {
+		t.Errorf("PartialRight(Pow, 2)(10) = %v, want 100", got)
+	}
+}
+
+func TestOnce(t *testing.T) {
+	calls := 0
+	connect := Once(func() (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errors.New("refused")
+		}
+		return "conn-" + strconv.Itoa(calls), nil
+	})
+	for i := 1; i <= 2; i++ {
+		if v, err := connect(); err == nil || v != "" {
+			t.Errorf("call %d = %q, %v, want an error", i, v, err)
+		}
+	}
+	for range 3 {
+		if v, err := connect(); err != nil || v != "conn-3" {
+			t.Errorf("connect() = %q, %v, want conn-3, nil", v, err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("f ran %d times, want 3: it must stop after the first success", calls)
+	}
+}
+
+// ---------------------------------------------------------
+// DEBOUNCE AND THROTTLE
+// ---------------------------------------------------------
+
+// call is one run of f: the value it got and when, after start.
+type call struct {
+	v  int
+	at time.Duration
+}
+
+type recorder struct {
+	clk   clock.Clock
+	mu    sync.Mutex
+	calls []call
+}
+
+func (r *recorder) f(v int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call{v, r.clk.Now().Sub(start)})
+}
+
+func (r *recorder) take() []call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.calls
+	r.calls = nil
+	return c
+}
+
+// burst is the package doc's example: a key pressed every 100ms from 0
+// to 400ms, then a second of quiet.
+func burst(fake *clock.Fake, call func(int)) {
+	for i := range 5 {
+		call(i)
+		if i < 4 {
+			fake.Advance(100 * time.Millisecond)
+		}
+	}
+	fake.Advance(time.Second)
+}
+
+func equalCalls(a, b []call) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDebounce(t *testing.T) {
+	fake := clock.NewFake(start)
+	r := &recorder{clk: fake}
+	d := Debounce(fake, 500*time.Millisecond, r.f)
+	burst(fake, d.Call)
+	if got, want := r.take(), []call{{4, 900 * time.Millisecond}}; !equalCalls(got, want) {
+		t.Errorf("Debounce ran %v, want %v", got, want)
+	}
+
+	d.Call(7)
+	fake.Advance(499 * time.Millisecond)
+	if !d.Flush() {
+		t.Error("Flush found nothing pending")
+	}
+	if got := r.take(); len(got) != 1 || got[0].v != 7 {
+		t.Errorf("Flush ran %v, want one call with 7", got)
+	}
+	fake.Advance(time.Second)
+	if got := r.take(); len(got) != 0 || d.Flush() {
+		t.Errorf("flushed call ran again: %v", got)
+	}
+
+	d.Call(8)
+	if !d.Cancel() || d.Cancel() {
+		t.Error("Cancel should report the pending call once")
+	}
+	fake.Advance(time.Second)
+	if got := r.take(); len(got) != 0 {
+		t.Errorf("cancelled call ran: %v", got)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	fake := clock.NewFake(start)
+	r := &recorder{clk: fake}
+	th := Throttle(fake, 500*time.Millisecond, r.f)
+	burst(fake, th.Call)
+	want := []call{{0, 0}, {4, 500 * time.Millisecond}}
+	if got := r.take(); !equalCalls(got, want) {
+		t.Errorf("Throttle ran %v, want %v", got, want)
+	}
+
+	// Quiet for longer than the interval: the next call runs at once.
+	th.Call(9)
+	if got := r.take(); len(got) != 1 || got[0] != (call{9, 1400 * time.Millisecond}) {
+		t.Errorf("call after quiet ran %v, want 9 at 1.4s", got)
+	}
+	th.Call(10)
+	if !th.Cancel() || th.Cancel() {
+		t.Error("Cancel should report the trailing call once")
+	}
+	fake.Advance(time.Second)
+	if got := r.take(); len(got) != 0 {
+		t.Errorf("cancelled trailing call ran: %v", got)
+	}
+}
+
+// ---------------------------------------------------------
+// RETRY
+// ---------------------------------------------------------
+
+// waitClock is a clock.Fake that reports each wait once its timer is
+// set, so a test can advance the clock exactly when Retry sleeps.
+type waitClock struct {
+	*clock.Fake
+	waits chan time.Duration
+}
+
+func newWaitClock() *waitClock {
+	return &waitClock{clock.NewFake(start), make(chan time.Duration)}
+}
+
+func (c *waitClock) AfterFunc(d time.Duration, f func()) clock.Timer {
+	t := c.Fake.AfterFunc(d, f)
+	c.waits <- d
+	return t
+}
+
+type result struct {
+	v   int
+	err error
+}
+
+// retry runs Retry in a goroutine and returns a channel for its result.
+func retry(ctx context.Context, b Backoff, f func(context.Context) (int, error)) <-chan result {
+	out := make(chan result, 1)
+	go func() {
+		v, err := Retry(ctx, b, f)
+		out <- result{v, err}
+	}()
+	return out
+}
+
+func TestRetry(t *testing.T) {
+	clk := newWaitClock()
+	errBusy := errors.New("busy")
+	calls := 0
+	done := retry(context.Background(), Backoff{Attempts: 4, Clock: clk}, func(context.Context) (int, error) {
+		calls++
+		return 0, errBusy
+	})
+	for _, want := range []time.Duration{100, 200, 400} {
+		if got := <-clk.waits; got != want*time.Millisecond {
+			t.Errorf("wait = %v, want %v", got, want*time.Millisecond)
+		}
+		clk.Advance(want * time.Millisecond)
+	}
+	res := <-done
+	if calls != 4 || !errors.Is(res.err, errBusy) {
+		t.Errorf("Retry = %v after %d calls, want errBusy after 4", res.err, calls)
+	}
+
+	calls = 0
+	done = retry(context.Background(), Backoff{Clock: clk}, func(context.Context) (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, errBusy
+		}
+		return 42, nil
+	})
+	clk.Advance(<-clk.waits)
+	clk.Advance(<-clk.waits)
+	if res := <-done; res.v != 42 || res.err != nil || calls != 3 {
+		t.Errorf("Retry = %d, %v after %d calls, want 42, nil after 3", res.v, res.err, calls)
+	}
+}
+
+func TestRetryPermanent(t *testing.T) {
+	errNotFound := errors.New("404")
+	calls := 0
+	_, err := Retry(context.Background(), Backoff{Attempts: 5, Clock: newWaitClock()}, func(context.Context) (int, error) {
+		calls++
+		return 0, Permanent(errNotFound)
+	})
+	if calls != 1 || !errors.Is(err, errNotFound) || err.Error() != "404" {
+		t.Errorf("Retry = %v after %d calls, want the 404 after 1", err, calls)
+	}
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+}
+
+func TestRetryCancel(t *testing.T) {
+	clk := newWaitClock()
+	errBusy := errors.New("busy")
+	ctx, cancel := context.WithCancel(context.Background())
+	var retries []int
+	done := retry(ctx, Backoff{Attempts: 10, Clock: clk, OnRetry: func(n int, _ error, _ time.Duration) {
+		retries = append(retries, n)
+	}}, func(context.Context) (int, error) { return 0, errBusy })
+
+	clk.Advance(<-clk.waits)
+	<-clk.waits // waiting after attempt 2
+	cancel()
+	res := <-done
+	if !errors.Is(res.err, context.Canceled) || !errors.Is(res.err, errBusy) {
+		t.Errorf("Retry = %v, want both context.Canceled and errBusy", res.err)
+	}
+	if len(retries) != 2 {
+		t.Errorf("OnRetry saw attempts %v, want [1 2]", retries)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second}
+	for n, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		if got := b.Delay(n + 1); got != want*time.Millisecond {
+			t.Errorf("Delay(%d) = %v, want %v", n+1, got, want*time.Millisecond)
+		}
+	}
+	if got := b.Delay(10_000); got != time.Second {
+		t.Errorf("Delay(10000) = %v, want the 1s cap", got)
+	}
+	if got := (Backoff{Multiplier: 3}).Delay(3); got != 900*time.Millisecond {
+		t.Errorf("Delay(3) with Multiplier 3 = %v, want 900ms", got)
+	}
+
+	b.Jitter = 0.25
+	for _, tt := range []struct {
+		rand float64
+		want time.Duration
+	}{
+		{0, 750 * time.Millisecond},
+		{0.5, time.Second},
+		{0.75, 1125 * time.Millisecond},
+	} {
+		b.Rand = func() float64 { return tt.rand }
+		if got := b.Delay(5); got != tt.want {
+			t.Errorf("Delay(5) with Rand %v = %v, want %v", tt.rand, got, tt.want)
+		}
+	}
+}
+
+// ---------------------------------------------------------
+// MEMOIZE
+// ---------------------------------------------------------
+
+func TestMemoizeBounds(t *testing.T) {
+	calls := make(map[int]int)
+	square := Memoize(func(x int) int {
+		calls[x]++
+		return x * x
+	}, MemoOptions{MaxEntries: 2})
+	for _, x := range []int{1, 2, 1, 3, 1, 2} {
+		if got := square(x); got != x*x {
+			t.Errorf("square(%d) = %d", x, got)
+		}
+	}
+	// 3 pushed out 2 (1 had just been used); then 2 pushed out 3.
+	if calls[1] != 1 || calls[2] != 2 || calls[3] != 1 {
+		t.Errorf("calls = %v, want 1:1 2:2 3:1", calls)
+	}
+}
+
+func TestMemoizeTTL(t *testing.T) {
+	fake := clock.NewFake(start)
+	calls := 0
+	now := Memoize(func(string) int {
+		calls++
+		return calls
+	}, MemoOptions{TTL: time.Minute, Clock: fake})
+	now("rate")
+	fake.Advance(59 * time.Second)
+	if got := now("rate"); got != 1 {
+		t.Errorf("at 59s got %d, want the remembered 1", got)
+	}
+	fake.Advance(time.Second)
+	if got := now("rate"); got != 2 {
+		t.Errorf("at 60s got %d, want a fresh 2", got)
+	}
+}
+
+func TestMemoizeErr(t *testing.T) {
+	calls := 0
+	parse := MemoizeErr(func(s string) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errors.New("flaky")
+		}
+		return strconv.Atoi(s)
+	}, MemoOptions{})
+	if _, err := parse("7"); err == nil {
+		t.Error("first call did not fail")
+	}
+	for range 2 {
+		if v, err := parse("7"); v != 7 || err != nil {
+			t.Errorf("parse(7) = %d, %v, want 7, nil", v, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("f ran %d times, want 2: the error must not be remembered", calls)
+	}
+}
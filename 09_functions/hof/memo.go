@@ -0,0 +1,53 @@
+package hof
+
+import (
+	"context"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+	"github.com/ViKing-py/lets-go-in-go/07_maps/cache"
+)
+
+// ---------------------------------------------------------
+// 4. MEMOIZE
+// ---------------------------------------------------------
+
+// MemoOptions bounds a memoized function's cache. The zero value
+// remembers every result forever.
+type MemoOptions struct {
+	// MaxEntries bounds the cache, dropping the least recently used
+	// result first; 0 means unbounded.
+	MaxEntries int
+	// TTL is how long a result is remembered; 0 means forever.
+	TTL time.Duration
+	// Clock measures the TTL. nil means clock.Real{}.
+	Clock clock.Clock
+}
+
+// Memoize returns a function that remembers f's result for each
+// argument. f must be pure: same argument, same result, and nothing else
+// happening that a caller would miss.
+//
+// The results live in a 07_maps/cache.Cache, so concurrent calls with
+// the same uncached argument share one call to f.
+func Memoize[K comparable, V any](f func(K) V, opts MemoOptions) func(K) V {
+	g := MemoizeErr(func(k K) (V, error) { return f(k), nil }, opts)
+	return func(k K) V {
+		v, _ := g(k)
+		return v
+	}
+}
+
+// MemoizeErr is Memoize for a function that can fail. Errors are not
+// remembered: the next call with that argument calls f again.
+func MemoizeErr[K comparable, V any](f func(K) (V, error), opts MemoOptions) func(K) (V, error) {
+	c := cache.New(cache.Options[K, V]{
+		MaxEntries: opts.MaxEntries,
+		TTL:        opts.TTL,
+		Clock:      opts.Clock,
+		Loader:     func(_ context.Context, k K) (V, error) { return f(k) },
+	})
+	return func(k K) (V, error) {
+		return c.GetOrLoad(context.Background(), k)
+	}
+}
@@ -0,0 +1,133 @@
+package hof
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+)
+
+// ---------------------------------------------------------
+// 6. RETRY
+// ---------------------------------------------------------
+
+// Backoff says how often and how patiently Retry tries. The zero value
+// makes 3 attempts, waiting 100ms and then 200ms, with no jitter.
+type Backoff struct {
+	// Attempts is the number of calls, the first one included; 0 means 3.
+	Attempts int
+	// Initial is the wait after the first failure; 0 means 100ms.
+	Initial time.Duration
+	// Multiplier grows the wait after each failure; 0 means 2.
+	Multiplier float64
+	// Max caps one wait; 0 means no cap.
+	Max time.Duration
+	// Jitter spreads each wait by up to this fraction either way: 0.25
+	// waits between 75% and 125% of it. Clients that failed together
+	// then retry at different times instead of all at once.
+	Jitter float64
+	// OnRetry, if set, is called after each failure that will be
+	// retried, with the wait before the next attempt.
+	OnRetry func(attempt int, err error, wait time.Duration)
+	// Clock does the waiting. nil means clock.Real{}.
+	Clock clock.TimerClock
+	// Rand returns numbers in [0, 1) for the jitter. nil means
+	// math/rand/v2.Float64.
+	Rand func() float64
+}
+
+// Delay returns the wait after failed attempt n (from 1), with jitter.
+func (b Backoff) Delay(n int) time.Duration {
+	d := float64(b.Initial)
+	if d == 0 {
+		d = float64(100 * time.Millisecond)
+	}
+	mult := b.Multiplier
+	if mult == 0 {
+		mult = 2
+	}
+	for range n - 1 {
+		d *= mult
+		if b.Max > 0 && d >= float64(b.Max) {
+			break // also keeps d from overflowing when n is large
+		}
+	}
+	if b.Max > 0 {
+		d = min(d, float64(b.Max))
+	}
+	if b.Jitter > 0 {
+		random := b.Rand
+		if random == nil {
+			random = rand.Float64
+		}
+		d *= 1 + b.Jitter*(2*random()-1)
+	}
+	return time.Duration(d)
+}
+
+// permanent marks an error that retrying cannot fix.
+type permanent struct{ err error }
+
+func (p *permanent) Error() string { return p.err.Error() }
+func (p *permanent) Unwrap() error { return p.err }
+
+// Permanent wraps err so that Retry gives up at once and returns it. The
+// wrapper keeps err's text, and errors.Is and errors.As see through it.
+// Use it for failures that will not go away, like a 404 or bad input.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanent{err}
+}
+
+// Retry calls f until it succeeds, returns a Permanent error, or has been
+// called b.Attempts times, waiting b.Delay between calls. After the last
+// attempt the error wraps f's last error. If ctx ends during a wait,
+// Retry stops and the error wraps both ctx.Err() and f's last error.
+func Retry[T any](ctx context.Context, b Backoff, f func(ctx context.Context) (T, error)) (T, error) {
+	attempts := b.Attempts
+	if attempts <= 0 {
+		attempts = 3
+	}
+	clk := b.Clock
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	var zero T
+	for n := 1; ; n++ {
+		v, err := f(ctx)
+		if err == nil {
+			return v, nil
+		}
+		if p := (*permanent)(nil); errors.As(err, &p) {
+			return zero, err
+		}
+		if n == attempts {
+			return zero, fmt.Errorf("hof: gave up after %d attempts: %w", n, err)
+		}
+		wait := b.Delay(n)
+		if b.OnRetry != nil {
+			b.OnRetry(n, err, wait)
+		}
+		if cerr := sleep(ctx, clk, wait); cerr != nil {
+			return zero, fmt.Errorf("hof: %w after %d attempts: %w", cerr, n, err)
+		}
+	}
+}
+
+// sleep waits for d on clk, or until ctx ends.
+func sleep(ctx context.Context, clk clock.TimerClock, d time.Duration) error {
+	done := make(chan struct{})
+	t := clk.AfterFunc(d, func() { close(done) })
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		t.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,179 @@
+package hof
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ViKing-py/lets-go-in-go/04_control_flow/clock"
+)
+
+// ---------------------------------------------------------
+// 5. DEBOUNCE AND THROTTLE
+// ---------------------------------------------------------
+// Both take a burst of calls and make fewer calls to f. Debounce waits
+// for the burst to end and calls f once. Throttle calls f at the start
+// and then at most once per interval while the burst lasts. For a key
+// pressed every 100ms from 0 to 400ms, with 500ms for both, debounce
+// calls f at 900ms, throttle at 0 and 500ms.
+//
+// f gets the value of the last call it stands for, so a debounced save
+// writes the latest text. f runs in the goroutine of the Call that
+// starts it, or in the clock's timer goroutine (with a clock.Fake, the
+// one calling Advance).
+
+// Debounced holds calls back until they stop coming. Make one with
+// Debounce.
+type Debounced[T any] struct {
+	clk  clock.TimerClock
+	wait time.Duration
+	f    func(T)
+
+	mu      sync.Mutex
+	timer   clock.Timer // nil when nothing is pending
+	pending T
+	gen     int // identifies the current timer; see fire
+}
+
+// Debounce returns a Debounced that calls f once no Call has come for
+// wait. A nil clk means clock.Real{}.
+func Debounce[T any](clk clock.TimerClock, wait time.Duration, f func(T)) *Debounced[T] {
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &Debounced[T]{clk: clk, wait: wait, f: f}
+}
+
+// Call records v and starts the wait over.
+func (d *Debounced[T]) Call(v T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.pending = v
+	d.gen++
+	gen := d.gen
+	d.timer = d.clk.AfterFunc(d.wait, func() { d.fire(gen) })
+}
+
+// fire runs f for the timer numbered gen. A real timer can fire just as
+// Call or Cancel stops it; a stale gen tells fire it lost that race.
+func (d *Debounced[T]) fire(gen int) {
+	d.mu.Lock()
+	if d.timer == nil || d.gen != gen {
+		d.mu.Unlock()
+		return
+	}
+	v := d.take()
+	d.mu.Unlock()
+	d.f(v)
+}
+
+// take clears the pending call and returns its value. d.mu must be held.
+func (d *Debounced[T]) take() T {
+	v := d.pending
+	var zero T
+	d.pending, d.timer = zero, nil
+	return v
+}
+
+// Flush runs a pending call now instead of at the end of the wait, and
+// reports whether there was one. Use it before shutting down.
+func (d *Debounced[T]) Flush() bool {
+	d.mu.Lock()
+	if d.timer == nil {
+		d.mu.Unlock()
+		return false
+	}
+	d.timer.Stop()
+	v := d.take()
+	d.mu.Unlock()
+	d.f(v)
+	return true
+}
+
+// Cancel drops a pending call and reports whether there was one.
+func (d *Debounced[T]) Cancel() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer == nil {
+		return false
+	}
+	d.timer.Stop()
+	d.take()
+	return true
+}
+
+// Throttled calls f at most once per interval. Make one with Throttle.
+type Throttled[T any] struct {
+	clk   clock.TimerClock
+	every time.Duration
+	f     func(T)
+
+	mu      sync.Mutex
+	ran     bool
+	last    time.Time   // when f last started
+	timer   clock.Timer // nil when no trailing call is pending
+	pending T
+	gen     int
+}
+
+// Throttle returns a Throttled that calls f at most once every interval.
+// A nil clk means clock.Real{}.
+func Throttle[T any](clk clock.TimerClock, every time.Duration, f func(T)) *Throttled[T] {
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	return &Throttled[T]{clk: clk, every: every, f: f}
+}
+
+// Call runs f(v) at once if f has not run for an interval. Otherwise it
+// keeps v, replacing any value kept before, and f runs with it when the
+// interval is up. So the last call of a burst is never lost.
+//
+// If f takes longer than the interval, the next call may start before it
+// returns.
+func (t *Throttled[T]) Call(v T) {
+	t.mu.Lock()
+	now := t.clk.Now()
+	if t.timer == nil && (!t.ran || now.Sub(t.last) >= t.every) {
+		t.ran, t.last = true, now
+		t.mu.Unlock()
+		t.f(v)
+		return
+	}
+	defer t.mu.Unlock()
+	t.pending = v
+	if t.timer == nil {
+		t.gen++
+		gen := t.gen
+		t.timer = t.clk.AfterFunc(t.last.Add(t.every).Sub(now), func() { t.fire(gen) })
+	}
+}
+
+func (t *Throttled[T]) fire(gen int) {
+	t.mu.Lock()
+	if t.timer == nil || t.gen != gen {
+		t.mu.Unlock()
+		return
+	}
+	v := t.pending
+	var zero T
+	t.pending, t.timer = zero, nil
+	t.last = t.clk.Now()
+	t.mu.Unlock()
+	t.f(v)
+}
+
+// Cancel drops a pending trailing call and reports whether there was one.
+func (t *Throttled[T]) Cancel() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.timer == nil {
+		return false
+	}
+	t.timer.Stop()
+	var zero T
+	t.pending, t.timer = zero, nil
+	return true
+}
@@ -12,6 +12,8 @@ import "fmt"
 // you can omit the type from all but the last one.
 // (x int, y int) -> (x, y int)
 func add(x, y int) int {
+	// The ./calc REPL defines functions the same way, at run time:
+	// add(x, y) = x + y, then add(2, 3).
 	return x + y
 }
 
@@ -21,6 +23,8 @@ func add(x, y int) int {
 func divide(dividend, divisor int) (int, int) {
 	quotient := dividend / divisor
 	remainder := dividend % divisor
+	// divide(17, 0) panics. ./checked returns an error instead, and also
+	// catches overflow, which sumAll below lets wrap around silently.
 	return quotient, remainder
 }
 
@@ -80,6 +84,9 @@ func main() {
 	numbers := []int{100, 200, 300}
 	t3 := sumAll(numbers...)
 	fmt.Println("Total from slice:", t3)
+
+	// Functions are values too: they can be passed, returned and stored.
+	// ./hof builds on that with Memoize, Compose, Debounce, Retry and more.
 }
 
 // ---------------------------------------------------------